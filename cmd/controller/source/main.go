@@ -17,8 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/sink"
 	rabbitmq "knative.dev/eventing-rabbitmq/pkg/reconciler/source"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
 )
 
 const (
@@ -26,5 +31,7 @@ const (
 )
 
 func main() {
-	sharedmain.Main(component, rabbitmq.NewController)
+	// Only the pods of the receive adapters are watched.
+	ctx := filteredFactory.WithSelectors(signals.NewContext(), resources.ReceiveAdapterSelector)
+	sharedmain.MainWithContext(ctx, component, rabbitmq.NewController, sink.NewController, channel.NewController)
 }
@@ -44,6 +44,14 @@ type envConfig struct {
 	Retry         int           `envconfig:"RETRY" required:"false"`
 	BackoffPolicy string        `envconfig:"BACKOFF_POLICY" required:"false"`
 	BackoffDelay  time.Duration `envconfig:"BACKOFF_DELAY" required:"false"`
+
+	// How long in-flight deliveries are given to complete on shutdown. This
+	// should be kept below the Pod's terminationGracePeriodSeconds.
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"25s" required:"false"`
+
+	// Exchange messages that can't be converted into CloudEvents are
+	// dead-lettered to. If empty they are rejected instead.
+	DLXName string `envconfig:"DLX_NAME" required:"false"`
 }
 
 const (
@@ -107,7 +115,7 @@ func main() {
 		logging.FromContext(ctx).Fatal("Failed to create QoS: ", err)
 	}
 
-	d := dispatcher.NewDispatcher(env.BrokerIngressURL, env.SubscriberURL, env.Requeue, env.Retry, backoffDelay, backoffPolicy)
+	d := dispatcher.NewDispatcher(env.BrokerIngressURL, env.SubscriberURL, env.Requeue, env.Retry, backoffDelay, backoffPolicy, env.ShutdownTimeout, env.DLXName)
 	if err := d.ConsumeFromQueue(ctx, channel, env.QueueName); err != nil {
 		// ignore ctx cancelled and channel closed errors
 		if errors.Is(err, context.Canceled) || errors.Is(err, amqperr.ErrClosed) {
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -31,23 +33,35 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
 )
 
 const (
 	defaultMaxIdleConnections        = 1000
 	defaultMaxIdleConnectionsPerHost = 1000
+	defaultConfirmsBufferSize        = 1000
 )
 
 type envConfig struct {
 	Port         int    `envconfig:"PORT" default:"8080"`
 	BrokerURL    string `envconfig:"BROKER_URL" required:"true"`
 	ExchangeName string `envconfig:"EXCHANGE_NAME" required:"true"`
+	// How long pending requests are given to be confirmed on shutdown.
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"25s"`
 
 	channel *amqp.Channel
 	logger  *zap.SugaredLogger
+
+	// mu serializes publishing so that delivery tags can be matched with the
+	// publisher confirms sent back by RabbitMQ.
+	mu          sync.Mutex
+	deliveryTag uint64
+	pending     map[uint64]chan bool
 }
 
 func main() {
+	ctx := signals.NewContext()
+
 	var env envConfig
 	if err := envconfig.Process("", &env); err != nil {
 		log.Fatal("Failed to process env var", zap.Error(err))
@@ -65,7 +79,14 @@ func main() {
 	}
 	defer env.channel.Close()
 
-	env.logger = logging.FromContext(context.Background())
+	env.logger = logging.FromContext(ctx)
+
+	env.pending = make(map[uint64]chan bool)
+	confirms := env.channel.NotifyPublish(make(chan amqp.Confirmation, defaultConfirmsBufferSize))
+	if err := env.channel.Confirm(false); err != nil {
+		log.Fatalf("failed to put channel in confirm mode: %s", err)
+	}
+	go env.handleConfirms(confirms)
 
 	connectionArgs := kncloudevents.ConnectionArgs{
 		MaxIdleConns:        defaultMaxIdleConnections,
@@ -75,9 +96,12 @@ func main() {
 
 	receiver := kncloudevents.NewHTTPMessageReceiver(env.Port)
 
-	if err := receiver.StartListen(context.Background(), &env); err != nil {
+	// On shutdown the receiver stops accepting requests and waits for the ones
+	// in flight, and with them their publisher confirms, before returning.
+	if err := receiver.StartListen(kncloudevents.WithShutdownTimeout(ctx, env.ShutdownTimeout), &env); err != nil {
 		log.Fatalf("failed to start listen, %v", err)
 	}
+	env.logger.Info("ingress stopped, closing the RabbitMQ channel")
 }
 
 func (env *envConfig) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
@@ -114,14 +138,14 @@ func (env *envConfig) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
-	statusCode, err := env.send(event)
+	statusCode, err := env.send(ctx, event)
 	if err != nil {
 		env.logger.Error("failed to send event,", err)
 	}
 	writer.WriteHeader(statusCode)
 }
 
-func (env *envConfig) send(event *cloudevents.Event) (int, error) {
+func (env *envConfig) send(ctx context.Context, event *cloudevents.Event) (int, error) {
 	bytes, err := json.Marshal(event)
 	if err != nil {
 		return http.StatusBadRequest, fmt.Errorf("failed to marshal event, %w", err)
@@ -134,6 +158,7 @@ func (env *envConfig) send(event *cloudevents.Event) (int, error) {
 	for key, val := range event.Extensions() {
 		headers[key] = val
 	}
+	env.mu.Lock()
 	if err := env.channel.Publish(
 		env.ExchangeName,
 		"",    // routing key
@@ -144,7 +169,41 @@ func (env *envConfig) send(event *cloudevents.Event) (int, error) {
 			ContentType: "application/json",
 			Body:        bytes,
 		}); err != nil {
+		env.mu.Unlock()
 		return http.StatusInternalServerError, fmt.Errorf("failed to publish message")
 	}
+	env.deliveryTag++
+	confirmed := make(chan bool, 1)
+	env.pending[env.deliveryTag] = confirmed
+	env.mu.Unlock()
+
+	select {
+	case ack, ok := <-confirmed:
+		if !ok || !ack {
+			return http.StatusInternalServerError, fmt.Errorf("message was not confirmed")
+		}
+	case <-ctx.Done():
+		return http.StatusInternalServerError, fmt.Errorf("gave up waiting for message confirmation: %w", ctx.Err())
+	}
 	return http.StatusAccepted, nil
 }
+
+// handleConfirms hands the publisher confirms over to the requests waiting for
+// them. Once the channel is closed any requests still waiting are failed.
+func (env *envConfig) handleConfirms(confirms <-chan amqp.Confirmation) {
+	for confirm := range confirms {
+		env.mu.Lock()
+		if confirmed, ok := env.pending[confirm.DeliveryTag]; ok {
+			confirmed <- confirm.Ack
+			delete(env.pending, confirm.DeliveryTag)
+		}
+		env.mu.Unlock()
+	}
+
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	for tag, confirmed := range env.pending {
+		close(confirmed)
+		delete(env.pending, tag)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/kelseyhightower/envconfig"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/sink"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
+)
+
+const (
+	defaultMaxIdleConnections        = 1000
+	defaultMaxIdleConnectionsPerHost = 1000
+	defaultHeartbeat                 = 10 * time.Second
+)
+
+type envConfig struct {
+	Port          int    `envconfig:"PORT" default:"8080"`
+	BrokerURL     string `envconfig:"RABBITMQ_URL" required:"true"`
+	Exchange      string `envconfig:"RABBITMQ_EXCHANGE" required:"false"`
+	RoutingKey    string `envconfig:"RABBITMQ_ROUTING_KEY" required:"false"`
+	ContentMode   string `envconfig:"RABBITMQ_CONTENT_MODE" required:"false"`
+	SASLMechanism string `envconfig:"RABBITMQ_SASL_MECHANISM" required:"false"`
+	TLS           dialer.TLSConfig
+	// How long pending requests are given to be confirmed on shutdown.
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"25s"`
+}
+
+func main() {
+	ctx := signals.NewContext()
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatal("Failed to process env var", zap.Error(err))
+	}
+
+	config := origamqp.Config{Heartbeat: defaultHeartbeat}
+	var err error
+	if config.TLSClientConfig, err = env.TLS.Config(); err != nil {
+		log.Fatalf("failed to load the TLS configuration: %s", err)
+	}
+	if config.SASL, err = dialer.SASL(env.SASLMechanism); err != nil {
+		log.Fatalf("invalid SASL configuration: %s", err)
+	}
+
+	conn, err := dialer.ConfigDialer(config)(env.BrokerURL)
+	if err != nil {
+		log.Fatalf("failed to connect to RabbitMQ: %s", err)
+	}
+	defer conn.Close()
+
+	var channel wabbit.Channel
+	if channel, err = conn.Channel(); err != nil {
+		log.Fatalf("failed to open a channel: %s", err)
+	}
+	defer channel.Close()
+
+	logger := logging.FromContext(ctx)
+
+	// The sink can't publish once the channel is closed, by RabbitMQ or with
+	// the connection, so it stops to be restarted and connect again.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	channelClosed := make(chan struct{})
+	closes := channel.NotifyClose(make(chan wabbit.Error, 1))
+	go func() {
+		if err, ok := <-closes; ok {
+			logger.Errorw("RabbitMQ channel closed, stopping the sink", zap.Error(err))
+			close(channelClosed)
+			cancel()
+		}
+	}()
+	publisher, err := sink.NewPublisher(channel, env.Exchange, env.RoutingKey, env.ContentMode, logger)
+	if err != nil {
+		log.Fatalf("failed to create the publisher: %s", err)
+	}
+
+	connectionArgs := kncloudevents.ConnectionArgs{
+		MaxIdleConns:        defaultMaxIdleConnections,
+		MaxIdleConnsPerHost: defaultMaxIdleConnectionsPerHost,
+	}
+	kncloudevents.ConfigureConnectionArgs(&connectionArgs)
+
+	receiver := kncloudevents.NewHTTPMessageReceiver(env.Port)
+
+	// On shutdown the receiver stops accepting requests and waits for the ones
+	// in flight, and with them their publisher confirms, before returning.
+	if err := receiver.StartListen(kncloudevents.WithShutdownTimeout(ctx, env.ShutdownTimeout), publisher); err != nil {
+		log.Fatalf("failed to start listen, %v", err)
+	}
+	select {
+	case <-channelClosed:
+		log.Fatal("RabbitMQ channel closed")
+	default:
+	}
+	logger.Info("sink stopped, closing the RabbitMQ channel")
+}
@@ -32,14 +32,24 @@ import (
 	"knative.dev/pkg/webhook/certificates"
 	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
+	eventingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	messagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources"
 	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
 )
 
 var ourTypes = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha1.SchemeGroupVersion.WithKind("RabbitmqSource"): &v1alpha1.RabbitmqSource{},
+	v1beta1.SchemeGroupVersion.WithKind("RabbitmqSource"):  &v1beta1.RabbitmqSource{},
+
+	eventingv1alpha1.SchemeGroupVersion.WithKind("RabbitmqSink"): &eventingv1alpha1.RabbitmqSink{},
+
+	messagingv1alpha1.SchemeGroupVersion.WithKind("RabbitmqChannel"): &messagingv1alpha1.RabbitmqChannel{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -96,6 +106,38 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 	)
 }
 
+func NewConversionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+	var (
+		sourcesv1alpha1_ = v1alpha1.SchemeGroupVersion.Version
+		sourcesv1beta1_  = v1beta1.SchemeGroupVersion.Version
+	)
+
+	// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+	ctxFunc := func(ctx context.Context) context.Context {
+		return ctx
+	}
+
+	return conversion.NewConversionController(ctx,
+		// The path on which to serve the webhook.
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted.
+		map[schema.GroupKind]conversion.GroupKindConversion{
+			v1alpha1.Kind("RabbitmqSource"): {
+				DefinitionName: sources.RabbitMQResource.String(),
+				HubVersion:     sourcesv1alpha1_,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					sourcesv1alpha1_: &v1alpha1.RabbitmqSource{},
+					sourcesv1beta1_:  &v1beta1.RabbitmqSource{},
+				},
+			},
+		},
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		ctxFunc,
+	)
+}
+
 func NewConfigValidationController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
 	return configmaps.NewAdmissionController(ctx,
 		// Name of the configmap webhook.
@@ -127,5 +169,6 @@ func main() {
 		NewConfigValidationController,
 		NewValidationAdmissionController,
 		NewDefaultingAdmissionController,
+		NewConversionController,
 	)
 }
@@ -20,9 +20,11 @@ require (
 	github.com/pkg/errors v0.9.1
 	github.com/rabbitmq/cluster-operator v1.6.0
 	github.com/rabbitmq/messaging-topology-operator v0.8.0
+	github.com/rickb777/date v1.13.0
 	github.com/streadway/amqp v1.0.0
 	github.com/testcontainers/testcontainers-go v0.7.0
 	github.com/tiago4orion/conjure v0.0.0-20150908101743-93cb30b9d218 // indirect
+	go.opencensus.io v0.23.0
 	go.uber.org/zap v1.18.1
 	gotest.tools v2.2.0+incompatible
 	k8s.io/api v0.20.7
@@ -31,6 +33,7 @@ require (
 	k8s.io/client-go v0.20.7
 	k8s.io/code-generator v0.21.0
 	k8s.io/kube-openapi v0.0.0-20210305001622-591a79e4bda7
+	k8s.io/utils v0.0.0-20210111153108-fddb29f9d009
 	knative.dev/eventing v0.25.1-0.20210825092225-5a0aa5d10404
 	knative.dev/hack v0.0.0-20210806075220-815cd312d65c
 	knative.dev/pkg v0.0.0-20210825070025-a70bb26767b8
@@ -18,9 +18,14 @@ package rabbitmq
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	nethttp "net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/NeowayLabs/wabbit"
 	"github.com/NeowayLabs/wabbit/amqp"
@@ -28,17 +33,27 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	origamqp "github.com/streadway/amqp"
 	"go.uber.org/zap"
-	"k8s.io/apimachinery/pkg/util/uuid"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
 	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 )
 
 const (
 	resourceGroup = "rabbitmqsources.sources.knative.dev"
+
+	// Content type of events built from messages that have none, unless
+	// overridden by the source.
+	defaultContentType = "application/octet-stream"
+
+	// Bounds of the exponential backoff between reconnection attempts.
+	minReconnectDelay = 100 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
 )
 
 type ExchangeConfig struct {
@@ -62,19 +77,45 @@ type QueueConfig struct {
 	DeleteWhenUnused bool   `envconfig:"RABBITMQ_QUEUE_CONFIG_AUTO_DELETED" required:"false"`
 	Exclusive        bool   `envconfig:"RABBITMQ_QUEUE_CONFIG_EXCLUSIVE" required:"false"`
 	NoWait           bool   `envconfig:"RABBITMQ_QUEUE_CONFIG_NOWAIT" required:"false"`
+	Type             string `envconfig:"RABBITMQ_QUEUE_CONFIG_TYPE" required:"false"`
+	StreamOffset     string `envconfig:"RABBITMQ_QUEUE_CONFIG_STREAM_OFFSET" required:"false"`
+	// Arguments of the queue declaration, other than its type.
+	Arguments queueArguments `envconfig:"RABBITMQ_QUEUE_CONFIG_ARGUMENTS" required:"false"`
 }
 
 type adapterConfig struct {
 	adapter.EnvConfig
 
-	Brokers        string `envconfig:"RABBITMQ_BROKERS" required:"true"`
-	Topic          string `envconfig:"RABBITMQ_TOPIC" required:"true"`
-	User           string `envconfig:"RABBITMQ_USER" required:"false"`
-	Password       string `envconfig:"RABBITMQ_PASSWORD" required:"false"`
-	Vhost          string `envconfig:"RABBITMQ_VHOST" required:"false"`
-	ChannelConfig  ChannelConfig
-	ExchangeConfig ExchangeConfig
-	QueueConfig    QueueConfig
+	Brokers        string           `envconfig:"RABBITMQ_BROKERS" required:"false"`
+	Topic          string           `envconfig:"RABBITMQ_TOPIC" required:"true"`
+	User           string           `envconfig:"RABBITMQ_USER" required:"false"`
+	Password       string           `envconfig:"RABBITMQ_PASSWORD" required:"false"`
+	Vhost          string           `envconfig:"RABBITMQ_VHOST" required:"false"`
+	ContentType    string           `envconfig:"RABBITMQ_CONTENT_TYPE" required:"false"`
+	ForceWrap      bool             `envconfig:"RABBITMQ_FORCE_WRAP" required:"false"`
+	Extensions     extensionsConfig `envconfig:"RABBITMQ_EXTENSIONS" required:"false"`
+	Attributes     attributesConfig `envconfig:"RABBITMQ_ATTRIBUTES" required:"false"`
+	Delivery       deliveryConfig   `envconfig:"RABBITMQ_DELIVERY" required:"false"`
+	DeadLetterSink string           `envconfig:"RABBITMQ_DEAD_LETTER_SINK_URI" required:"false"`
+	Reply          replyConfig      `envconfig:"RABBITMQ_REPLY" required:"false"`
+	Consumer       consumerConfig   `envconfig:"RABBITMQ_CONSUMER" required:"false"`
+	HealthPort     int              `envconfig:"RABBITMQ_HEALTH_PORT" default:"8080" required:"false"`
+	SASLMechanism  string           `envconfig:"RABBITMQ_SASL_MECHANISM" required:"false"`
+	// Connection lists the nodes of RabbitMQ, replacing the host of the
+	// brokers, and the options of the connections.
+	Connection connectionConfig `envconfig:"RABBITMQ_CONNECTION" required:"false"`
+	// OffsetConfigMap is the ConfigMap storing the offset of the last
+	// message processed from a stream queue.
+	OffsetConfigMap string `envconfig:"RABBITMQ_OFFSET_CONFIGMAP" required:"false"`
+	TLS             dialer.TLSConfig
+	ChannelConfig   ChannelConfig
+	ExchangeConfig  ExchangeConfig
+	QueueConfig     QueueConfig
+	// Bindings of the queue replacing the bindings of the routing keys.
+	Bindings bindingsConfig `envconfig:"RABBITMQ_BINDINGS" required:"false"`
+	// TopologyMode is whether the exchange, queue and bindings are declared,
+	// only checked or used as they are.
+	TopologyMode string `envconfig:"RABBITMQ_TOPOLOGY_MODE" required:"false"`
 }
 
 func NewEnvConfig() adapter.EnvConfigAccessor {
@@ -87,6 +128,25 @@ type Adapter struct {
 	reporter          source.StatsReporter
 	logger            *zap.Logger
 	context           context.Context
+	retryConfig       *kncloudevents.RetryConfig
+	dialerFunc        dialer.DialerFunc
+	ceOverrides       *duckv1.CloudEventOverrides
+	// requeues is the number of messages requeued in a row, delaying the
+	// next requeue.
+	requeues int
+	// ready is 1 while messages are being consumed.
+	ready int32
+	// status is the state of the connection to RabbitMQ and unacked the
+	// number of messages being processed.
+	statusLock sync.Mutex
+	status     Status
+	unacked    int32
+	// offsets stores the offset of the last message processed from a stream
+	// queue, which offset holds until it's stored. Both are -1 until a
+	// message is processed.
+	offsets      offsetStore
+	offset       int64
+	storedOffset int64
 }
 
 var _ adapter.MessageAdapter = (*Adapter)(nil)
@@ -96,39 +156,69 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, httpMe
 	logger := logging.FromContext(ctx).Desugar()
 	config := processed.(*adapterConfig)
 
-	return &Adapter{
+	a := &Adapter{
 		config:            config,
 		httpMessageSender: httpMessageSender,
 		reporter:          reporter,
 		logger:            logger,
 		context:           ctx,
+		retryConfig:       config.Delivery.retryConfig(logger),
+		offset:            -1,
+		storedOffset:      -1,
+	}
+	a.dialerFunc = a.dial
+	var err error
+	if a.ceOverrides, err = config.GetCloudEventOverrides(); err != nil {
+		logger.Warn("Ignoring invalid CloudEvent overrides", zap.Error(err))
 	}
+	if a.isStream() && config.OffsetConfigMap != "" {
+		a.offsets = &configMapOffsetStore{
+			namespace: config.Namespace,
+			name:      config.OffsetConfigMap,
+		}
+	}
+	return a
 }
 
-func vhostHandler(brokers string, vhost string) string {
-	if len(vhost) > 0 && len(brokers) > 0 && !strings.HasSuffix(brokers, "/") &&
-		!strings.HasPrefix(vhost, "/") {
-		return fmt.Sprintf("%s/%s", brokers, vhost)
+// brokersURLs returns the URL of each node of RabbitMQ, including the given
+// credentials if any, in the order they are tried.
+func (a *Adapter) brokersURLs(user string, password string) ([]string, error) {
+	urls, err := dialer.Brokers{
+		URI:      a.config.Brokers,
+		Nodes:    a.config.Connection.nodes(),
+		User:     user,
+		Password: password,
+		Vhost:    a.config.Vhost,
+		TLS:      a.config.TLS.Enabled,
+	}.URLs()
+	if err != nil {
+		return nil, err
 	}
-
-	return fmt.Sprintf("%s%s", brokers, vhost)
+	if a.config.Connection.random() {
+		urls = dialer.Shuffle(urls)
+	}
+	return urls, nil
 }
 
 func (a *Adapter) CreateConn(user string, password string, logger *zap.Logger) (*amqp.Conn, error) {
-	if user != "" && password != "" {
-		a.config.Brokers = fmt.Sprintf(
-			"amqp://%s:%s@%s",
-			a.config.User,
-			a.config.Password,
-			vhostHandler(a.config.Brokers, a.config.Vhost),
-		)
+	config, err := a.amqpConfig()
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
 	}
-
-	conn, err := amqp.Dial(a.config.Brokers)
+	urls, err := a.brokersURLs(user, password)
 	if err != nil {
 		logger.Error(err.Error())
+		return nil, err
 	}
-	return conn, err
+	for _, url := range urls {
+		var conn *amqp.Conn
+		if conn, err = amqp.DialConfig(url, config); err == nil {
+			return conn, nil
+		}
+		logger.Error(err.Error(), zap.String("node", dialer.Redact(url)))
+	}
+	return nil, err
 }
 
 func (a *Adapter) CreateChannel(conn *amqp.Conn, connTest *amqptest.Conn,
@@ -146,11 +236,17 @@ func (a *Adapter) CreateChannel(conn *amqp.Conn, connTest *amqptest.Conn,
 		return nil, err
 	}
 
-	err = ch.Qos(a.config.ChannelConfig.PrefetchCount,
+	return ch, a.setQos(ch)
+}
+
+func (a *Adapter) setQos(ch wabbit.Channel) error {
+	prefetchCount := a.config.ChannelConfig.PrefetchCount
+	if prefetchCount == 0 && a.isStream() {
+		prefetchCount = defaultStreamPrefetchCount
+	}
+	return ch.Qos(prefetchCount,
 		0,
 		a.config.ChannelConfig.GlobalQos)
-
-	return ch, err
 }
 
 func (a *Adapter) Start(ctx context.Context) error {
@@ -167,26 +263,98 @@ func (a *Adapter) start(stopCh <-chan struct{}) error {
 		zap.String("Name", a.config.Name),
 		zap.String("Namespace", a.config.Namespace))
 
-	conn, err := a.CreateConn(a.config.User, a.config.Password, logger)
-	if err == nil {
-		defer conn.Close()
+	if a.config.HealthPort != 0 {
+		go a.serveHealth(stopCh)
 	}
 
-	ch, err := a.CreateChannel(conn, nil, logger)
-	if err == nil {
-		defer ch.Close()
+	if a.offsets != nil {
+		if err := a.loadOffset(); err != nil {
+			return err
+		}
+		done := make(chan struct{})
+		defer func() { <-done }()
+		go func() {
+			defer close(done)
+			a.storeOffsets(stopCh)
+		}()
+	}
+
+	delay := minReconnectDelay
+	for {
+		connected, err := a.consume(stopCh)
+		if err == nil {
+			return nil
+		}
+		if connected {
+			delay = minReconnectDelay
+		}
+		logger.Error("Consuming from RabbitMQ failed, reconnecting", zap.Error(err), zap.Duration("delay", delay))
+		a.reportReconnect()
+
+		select {
+		case <-stopCh:
+			return nil
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// consume connects to RabbitMQ, declares the topology and sends the messages
+// of the queue to the sink until stopCh is closed, returning nil, or until
+// the connection fails. connected reports whether consuming started.
+func (a *Adapter) consume(stopCh <-chan struct{}) (connected bool, err error) {
+	urls, err := a.brokersURLs(a.config.User, a.config.Password)
+	if err != nil {
+		return false, a.notConnected(fmt.Errorf("invalid brokers: %w", err))
+	}
+	// The nodes are tried again from the start, or in a new random order, on
+	// every reconnection, failing over from the nodes that went down.
+	conn, url, err := dialer.DialNodes(a.dialerFunc, urls)
+	if err != nil {
+		return false, a.notConnected(fmt.Errorf("failed to connect to RabbitMQ: %w", err))
+	}
+	defer conn.Close()
+	if len(urls) > 1 {
+		a.logger.Info("Connected to RabbitMQ", zap.String("node", dialer.Redact(url)))
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return false, a.notConnected(fmt.Errorf("failed to open a channel: %w", err))
+	}
+	defer ch.Close()
+
+	if err := a.setQos(ch); err != nil {
+		return false, a.notConnected(fmt.Errorf("failed to set the channel QoS: %w", err))
 	}
 
 	queue, err := a.StartAmqpClient(&ch)
 	if err != nil {
-		logger.Error(err.Error())
+		a.setStatus(Status{Connected: true, TopologyError: err.Error()})
+		return false, fmt.Errorf("failed to declare the topology: %w", err)
 	}
+	a.setStatus(Status{Connected: true, Queue: (*queue).Name()})
 
-	return a.PollForMessages(&ch, queue, stopCh)
+	if err := a.PollForMessages(&ch, queue, stopCh); err != nil {
+		return true, a.notConnected(err)
+	}
+	return true, nil
 }
 
 func (a *Adapter) StartAmqpClient(ch *wabbit.Channel) (*wabbit.Queue, error) {
 	logger := a.logger
+	switch a.config.TopologyMode {
+	case sourcesv1alpha1.TopologyModePassive, sourcesv1alpha1.TopologyModeNone:
+		queue, err := a.existingTopology(ch)
+		if err != nil {
+			logger.Error(err.Error())
+		}
+		return queue, err
+	}
+
 	exchangeConfig := fillDefaultValuesForExchangeConfig(&a.config.ExchangeConfig, a.config.Topic)
 
 	err := (*ch).ExchangeDeclare(
@@ -203,20 +371,41 @@ func (a *Adapter) StartAmqpClient(ch *wabbit.Channel) (*wabbit.Queue, error) {
 		return nil, err
 	}
 
-	queue, err := (*ch).QueueDeclare(
-		a.config.QueueConfig.Name,
-		wabbit.Option{
-			"durable":   a.config.QueueConfig.Durable,
-			"delete":    a.config.QueueConfig.DeleteWhenUnused,
-			"exclusive": a.config.QueueConfig.Exclusive,
-			"noWait":    a.config.QueueConfig.NoWait,
-		})
+	queueOptions := wabbit.Option{
+		"durable":   a.config.QueueConfig.Durable,
+		"delete":    a.config.QueueConfig.DeleteWhenUnused,
+		"exclusive": a.config.QueueConfig.Exclusive,
+		"noWait":    a.config.QueueConfig.NoWait,
+	}
+	if args := a.declareArguments(); len(args) > 0 {
+		queueOptions["args"] = args
+	}
+	queue, err := (*ch).QueueDeclare(a.config.QueueConfig.Name, queueOptions)
 	if err != nil {
 		logger.Error(err.Error())
 		return nil, err
 	}
 
-	if a.config.ExchangeConfig.TypeOf != "fanout" {
+	if len(a.config.Bindings) > 0 {
+		for _, binding := range a.config.Bindings {
+			exchange := binding.Exchange
+			if exchange == "" {
+				exchange = exchangeConfig.Name
+			}
+			err = (*ch).QueueBind(
+				queue.Name(),
+				binding.RoutingKey,
+				exchange,
+				wabbit.Option{
+					"noWait": a.config.QueueConfig.NoWait,
+					"args":   binding.Arguments,
+				})
+			if err != nil {
+				logger.Error(err.Error())
+				return nil, err
+			}
+		}
+	} else if a.config.ExchangeConfig.TypeOf != "fanout" {
 		routingKeys := strings.Split(a.config.QueueConfig.RoutingKey, ",")
 
 		for _, routingKey := range routingKeys {
@@ -251,15 +440,26 @@ func (a *Adapter) StartAmqpClient(ch *wabbit.Channel) (*wabbit.Queue, error) {
 
 func (a *Adapter) ConsumeMessages(channel *wabbit.Channel,
 	queue *wabbit.Queue, logger *zap.Logger) (<-chan wabbit.Delivery, error) {
-	msgs, err := (*channel).Consume(
-		(*queue).Name(),
-		"",
-		wabbit.Option{
-			"autoAck":   false,
-			"exclusive": a.config.QueueConfig.Exclusive,
-			"noLocal":   false,
-			"noWait":    a.config.QueueConfig.NoWait,
-		})
+	options := wabbit.Option{
+		"autoAck":   a.config.Consumer.autoAck(),
+		"exclusive": a.config.QueueConfig.Exclusive || a.config.Consumer.exclusive(),
+		"noLocal":   false,
+		"noWait":    a.config.QueueConfig.NoWait,
+	}
+	args := origamqp.Table{}
+	if a.isStream() {
+		offset, err := a.streamOffset()
+		if err != nil {
+			logger.Error(err.Error())
+			return nil, err
+		}
+		args[streamOffsetArg] = offset
+	}
+	a.config.Consumer.consumeArguments(args)
+	if len(args) > 0 {
+		options["args"] = args
+	}
+	msgs, err := (*channel).Consume((*queue).Name(), a.config.Consumer.tag(), options)
 
 	if err != nil {
 		logger.Error(err.Error())
@@ -271,88 +471,133 @@ func (a *Adapter) PollForMessages(channel *wabbit.Channel,
 	queue *wabbit.Queue, stopCh <-chan struct{}) error {
 	logger := a.logger
 
-	msgs, _ := a.ConsumeMessages(channel, queue, logger)
+	msgs, err := a.ConsumeMessages(channel, queue, logger)
+	if err != nil {
+		return fmt.Errorf("failed to consume from the queue: %w", err)
+	}
+	closed := (*channel).NotifyClose(make(chan wabbit.Error, 1))
+
+	acks := newAcker(a.config.Consumer)
+	tick, stopTicker := acks.ticker(a.config.Consumer)
+	defer stopTicker()
+
+	a.setReady(true)
+	defer a.setReady(false)
 
 	for {
 		select {
+		case <-tick:
+			if err := acks.flush(); err != nil {
+				logger.Error("Sending Ack of the batch failed", zap.Error(err))
+			}
+		case err, ok := <-closed:
+			if ok && err != nil {
+				return fmt.Errorf("channel closed: %w", err)
+			}
+			return errors.New("channel closed")
 		case msg, ok := <-msgs:
 			if ok {
-				logger.Info("Received: ", zap.Any("value", string(msg.Body())))
-
-				if err := a.postMessage(msg); err == nil {
-					logger.Info("Successfully sent event to sink")
-					err = msg.Ack(false)
-					if err != nil {
-						logger.Error("Sending Ack failed with Delivery Tag")
-					}
-				} else {
-					logger.Error("Sending event to sink failed: ", zap.Error(err))
-					err = msg.Nack(false, true)
-					if err != nil {
-						logger.Error("Sending Nack failed with Delivery Tag")
-					}
-				}
+				a.processMessage(*channel, msg, acks, stopCh)
 			} else {
-				return nil
+				return errors.New("delivery channel closed")
 			}
 		case <-stopCh:
 			logger.Info("Shutting down...")
+			if err := acks.flush(); err != nil {
+				logger.Error("Sending Ack of the batch failed", zap.Error(err))
+			}
 			return nil
 		}
 	}
 }
 
-func (a *Adapter) postMessage(msg wabbit.Delivery) error {
+// processMessage sends the event of msg to the sink and settles msg. The
+// reply of the sink, if any, is published once msg is settled, as failing to
+// publish it doesn't make the delivery to the sink fail.
+func (a *Adapter) processMessage(ch wabbit.Channel, msg wabbit.Delivery, acks *acker, stopCh <-chan struct{}) {
+	logger := a.logger
+	logger.Info("Received: ", zap.Any("value", string(msg.Body())))
+	atomic.AddInt32(&a.unacked, 1)
+	defer atomic.AddInt32(&a.unacked, -1)
+	if a.isStream() {
+		defer a.processedOffset(msg)
+	}
+
+	event, err := a.toEvent(msg)
+	var reply *cloudevents.Event
+	if err == nil {
+		reply, err = a.postMessage(event)
+	}
+	if err != nil {
+		logger.Error("Sending event to sink failed: ", zap.Error(err))
+		a.handleFailedMessage(msg, event, stopCh)
+		return
+	}
+
+	logger.Info("Successfully sent event to sink")
+	a.requeues = 0
+	if !a.config.Consumer.autoAck() {
+		if err := acks.ack(msg); err != nil {
+			logger.Error("Sending Ack failed with Delivery Tag")
+		}
+	}
+	if reply != nil {
+		a.handleReply(ch, msg, reply)
+	}
+}
+
+// postMessage sends the event to the sink, returning the event it replied
+// with if replies are published back to RabbitMQ.
+func (a *Adapter) postMessage(event *cloudevents.Event) (*cloudevents.Event, error) {
 	a.logger.Info("url ->" + a.httpMessageSender.Target)
-	req, err := a.httpMessageSender.NewCloudEventRequest(a.context)
+	res, err := a.sendEvent(event, a.httpMessageSender.Target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// TODO: See if the message is already a Cloud Event and if so, do not wrap, just use as is.
-	event := cloudevents.NewEvent()
-	if msg.MessageId() != "" {
-		event.SetID(msg.MessageId())
-	} else {
-		event.SetID(string(uuid.NewUUID()))
+	reportArgs := &source.ReportArgs{
+		Namespace:     a.config.Namespace,
+		Name:          a.config.Name,
+		ResourceGroup: resourceGroup,
 	}
-	event.SetTime(msg.Timestamp())
-	event.SetType(sourcesv1alpha1.RabbitmqEventType)
-	event.SetSource(sourcesv1alpha1.RabbitmqEventSource(a.config.Namespace, a.config.Name, a.config.Topic))
-	event.SetSubject(msg.MessageId())
-	event.SetExtension("key", msg.MessageId())
 
-	// TODO: Check the content type and use it instead.
-	err = event.SetData(*cloudevents.StringOfApplicationJSON(), msg.Body())
+	_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
+	return a.replyEvent(res)
+}
+
+// sendEvent sends the event to target, retrying according to the delivery
+// spec of the source.
+func (a *Adapter) sendEvent(event *cloudevents.Event, target string) (*nethttp.Response, error) {
+	req, err := a.httpMessageSender.NewCloudEventRequestWithTarget(a.context, target)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = http.WriteRequest(a.context, binding.ToMessage(&event), req)
+	err = http.WriteRequest(a.context, binding.ToMessage(event), req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	res, err := a.httpMessageSender.Send(req)
+	res, err := a.httpMessageSender.SendWithRetries(req, a.retryConfig)
 
 	if err != nil {
 		a.logger.Debug("Error while sending the message", zap.Error(err))
-		return err
+		return nil, err
 	}
 
 	if res.StatusCode/100 != 2 {
 		a.logger.Debug("Unexpected status code", zap.Int("status code", res.StatusCode))
-		return fmt.Errorf("%d %s", res.StatusCode, nethttp.StatusText(res.StatusCode))
-	}
-
-	reportArgs := &source.ReportArgs{
-		Namespace:     a.config.Namespace,
-		Name:          a.config.Name,
-		ResourceGroup: resourceGroup,
+		closeBody(res)
+		return nil, fmt.Errorf("%d %s", res.StatusCode, nethttp.StatusText(res.StatusCode))
 	}
+	return res, nil
+}
 
-	_ = a.reporter.ReportEventCount(reportArgs, res.StatusCode)
-	return nil
+// closeBody drains and closes the body of res, for its connection to be
+// reused.
+func closeBody(res *nethttp.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
 }
 
 func fillDefaultValuesForExchangeConfig(config *ExchangeConfig, topic string) *ExchangeConfig {
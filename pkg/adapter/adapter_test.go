@@ -17,6 +17,8 @@ limitations under the License.
 package rabbitmq
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"io/ioutil"
@@ -39,21 +41,87 @@ import (
 )
 
 func TestPostMessage_ServeHTTP(t *testing.T) {
+	data, err := json.Marshal(map[string]string{"key": "value"})
+	if err != nil {
+		t.Errorf("unexpected error, %v", err)
+	}
+	var gzipped bytes.Buffer
+	w := gzip.NewWriter(&gzipped)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
 	testCases := map[string]struct {
-		sink              func(http.ResponseWriter, *http.Request)
-		reqBody           string
-		attributes        map[string]string
-		expectedEventType string
-		error             bool
+		sink                func(http.ResponseWriter, *http.Request)
+		body                []byte
+		contentType         string
+		contentEncoding     string
+		sourceContentType   string
+		reqBody             string
+		expectedContentType string
+		error               bool
 	}{
 		"accepted": {
-			sink:    sinkAccepted,
-			reqBody: `{"key":"value"}`,
+			sink:                sinkAccepted,
+			body:                data,
+			contentType:         "application/json",
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/json",
 		},
 		"rejected": {
-			sink:    sinkRejected,
-			reqBody: `{"key":"value"}`,
-			error:   true,
+			sink:                sinkRejected,
+			body:                data,
+			contentType:         "application/json",
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/json",
+			error:               true,
+		},
+		"xml": {
+			sink:                sinkAccepted,
+			body:                []byte("<key>value</key>"),
+			contentType:         "application/xml",
+			reqBody:             "<key>value</key>",
+			expectedContentType: "application/xml",
+		},
+		"no content type": {
+			sink:                sinkAccepted,
+			body:                data,
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/octet-stream",
+		},
+		"no content type, source override": {
+			sink:                sinkAccepted,
+			body:                data,
+			sourceContentType:   "application/json",
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/json",
+		},
+		"content type, source override": {
+			sink:                sinkAccepted,
+			body:                []byte("<key>value</key>"),
+			contentType:         "application/xml",
+			sourceContentType:   "application/json",
+			reqBody:             "<key>value</key>",
+			expectedContentType: "application/xml",
+		},
+		"gzip": {
+			sink:                sinkAccepted,
+			body:                gzipped.Bytes(),
+			contentType:         "application/json",
+			contentEncoding:     "gzip",
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/json",
+		},
+		"unsupported content encoding": {
+			sink:                sinkAccepted,
+			body:                data,
+			contentType:         "application/json",
+			contentEncoding:     "br",
+			reqBody:             `{"key":"value"}`,
+			expectedContentType: "application/octet-stream",
 		},
 	}
 
@@ -90,6 +158,7 @@ func TestPostMessage_ServeHTTP(t *testing.T) {
 						Exclusive:        true,
 						NoWait:           false,
 					},
+					ContentType: tc.sourceContentType,
 				},
 				context:           context.TODO(),
 				httpMessageSender: s,
@@ -97,17 +166,18 @@ func TestPostMessage_ServeHTTP(t *testing.T) {
 				reporter:          statsReporter,
 			}
 
-			data, err := json.Marshal(map[string]string{"key": "value"})
-			if err != nil {
-				t.Errorf("unexpected error, %v", err)
-			}
-
 			m := &amqp.Delivery{}
 			m.Delivery = &origamqp.Delivery{
-				MessageId: "id",
-				Body:      data,
+				MessageId:       "id",
+				ContentType:     tc.contentType,
+				ContentEncoding: tc.contentEncoding,
+				Body:            tc.body,
 			}
-			err = a.postMessage(m)
+			event, err := a.toEvent(m)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			_, err = a.postMessage(event)
 
 			if tc.error && err == nil {
 				t.Errorf("expected error, but got %v", err)
@@ -116,6 +186,10 @@ func TestPostMessage_ServeHTTP(t *testing.T) {
 			if tc.reqBody != string(h.body) {
 				t.Errorf("Expected request body '%q', but got '%q'", tc.reqBody, h.body)
 			}
+
+			if got := h.header.Get("Content-Type"); tc.expectedContentType != got {
+				t.Errorf("Expected content type %q, but got %q", tc.expectedContentType, got)
+			}
 		})
 	}
 }
@@ -455,64 +529,6 @@ func sinkRejected(writer http.ResponseWriter, _ *http.Request) {
 	writer.WriteHeader(http.StatusRequestTimeout)
 }
 
-func TestAdapterVhostHandler(t *testing.T) {
-	for _, tt := range []struct {
-		name    string
-		brokers string
-		vhost   string
-		want    string
-	}{{
-		name:    "no brokers nor vhost",
-		brokers: "",
-		vhost:   "",
-		want:    "",
-	}, {
-		name:    "no vhost",
-		brokers: "amqp://localhost:5672",
-		vhost:   "",
-		want:    "amqp://localhost:5672",
-	}, {
-		name:    "no brokers",
-		brokers: "",
-		vhost:   "test-vhost",
-		want:    "test-vhost",
-	}, {
-		name:    "brokers and vhost without separating slash",
-		brokers: "amqp://localhost:5672",
-		vhost:   "test-vhost",
-		want:    "amqp://localhost:5672/test-vhost",
-	}, {
-		name:    "brokers and vhost without separating slash but vhost with ending slash",
-		brokers: "amqp://localhost:5672",
-		vhost:   "test-vhost/",
-		want:    "amqp://localhost:5672/test-vhost/",
-	}, {
-		name:    "brokers with trailing slash and vhost without the slash",
-		brokers: "amqp://localhost:5672/",
-		vhost:   "test-vhost",
-		want:    "amqp://localhost:5672/test-vhost",
-	}, {
-		name:    "vhost starting with slash and brokers without the slash",
-		brokers: "amqp://localhost:5672",
-		vhost:   "/test-vhost",
-		want:    "amqp://localhost:5672/test-vhost",
-	}, {
-		name:    "brokers and vhost with slash",
-		brokers: "amqp://localhost:5672/",
-		vhost:   "/test-vhost",
-		want:    "amqp://localhost:5672//test-vhost",
-	}} {
-		t.Run(tt.name, func(t *testing.T) {
-			tt := tt
-			t.Parallel()
-			got := vhostHandler(tt.brokers, tt.vhost)
-			if got != tt.want {
-				t.Errorf("Unexpected URI for %s/%s want:\n%+s\ngot:\n%+s", tt.brokers, tt.vhost, tt.want, got)
-			}
-		})
-	}
-}
-
 func TestAdapter_PollForMessages(t *testing.T) {
 	fakeServer := server.NewServer("amqp://localhost:5672/%2f")
 	err := fakeServer.Start()
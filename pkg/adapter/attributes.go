@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// attributesConfig holds the parsed templates of the attributes spec of the
+// source, nil for the attributes keeping their default.
+type attributesConfig struct {
+	Type    *template.Template
+	Source  *template.Template
+	Subject *template.Template
+	ID      *template.Template
+}
+
+// Decode implements envconfig.Decoder.
+func (c *attributesConfig) Decode(value string) error {
+	var spec sourcesv1alpha1.RabbitmqSourceAttributesSpec
+	if err := json.Unmarshal([]byte(value), &spec); err != nil {
+		return err
+	}
+	for _, attr := range []struct {
+		name string
+		text string
+		t    **template.Template
+	}{
+		{"type", spec.Type, &c.Type},
+		{"source", spec.Source, &c.Source},
+		{"subject", spec.Subject, &c.Subject},
+		{"id", spec.ID, &c.ID},
+	} {
+		if attr.text == "" {
+			continue
+		}
+		t, err := sourcesv1alpha1.ParseAttributeTemplate(attr.name, attr.text)
+		if err != nil {
+			return err
+		}
+		*attr.t = t
+	}
+	return nil
+}
+
+// setAttributes sets the attributes of the event wrapping the message from
+// their templates, keeping the default of attributes whose template fails or
+// produces an empty value.
+func (a *Adapter) setAttributes(event *cloudevents.Event, msg wabbit.Delivery) {
+	c := a.config.Attributes
+	if c.Type == nil && c.Source == nil && c.Subject == nil && c.ID == nil {
+		return
+	}
+	data := templateData(msg)
+	for _, attr := range []struct {
+		t   *template.Template
+		set func(string)
+	}{
+		{c.Type, event.SetType},
+		{c.Source, event.SetSource},
+		{c.Subject, event.SetSubject},
+		{c.ID, event.SetID},
+	} {
+		if attr.t == nil {
+			continue
+		}
+		var b strings.Builder
+		if err := attr.t.Execute(&b, data); err != nil {
+			a.logger.Debug("Failed to execute attribute template, using the default",
+				zap.String("attribute", attr.t.Name()), zap.Error(err))
+			continue
+		}
+		if b.Len() > 0 {
+			attr.set(b.String())
+		}
+	}
+}
+
+// templateData returns the message properties the attribute templates are
+// executed on, named like the properties mapped into extensions.
+func templateData(msg wabbit.Delivery) map[string]interface{} {
+	data := map[string]interface{}{
+		"messageId":           msg.MessageId(),
+		propertyCorrelationID: "",
+		propertyReplyTo:       "",
+		propertyAppID:         "",
+		"type":                "",
+		propertyRoutingKey:    "",
+		propertyExchange:      "",
+		propertyHeaders:       map[string]interface{}(msg.Headers()),
+	}
+	if d, ok := msg.(*amqp.Delivery); ok && d.Delivery != nil {
+		data[propertyCorrelationID] = d.Delivery.CorrelationId
+		data[propertyReplyTo] = d.Delivery.ReplyTo
+		data[propertyAppID] = d.Delivery.AppId
+		data["type"] = d.Delivery.Type
+		data[propertyRoutingKey] = d.Delivery.RoutingKey
+		data[propertyExchange] = d.Delivery.Exchange
+	}
+	return data
+}
+
+// applyOverrides sets the extensions of the CloudEvent overrides of the
+// source, replacing those already set on the event.
+func (a *Adapter) applyOverrides(event *cloudevents.Event) {
+	if a.ceOverrides == nil {
+		return
+	}
+	for name, val := range a.ceOverrides.Extensions {
+		if err := event.Context.SetExtension(name, val); err != nil {
+			a.logger.Warn("Failed to set extension override", zap.String("name", name), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/wabbit/amqp"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+func TestAdapter_Attributes(t *testing.T) {
+	delivery := &origamqp.Delivery{
+		MessageId:  "msg-id",
+		RoutingKey: "orders.created",
+		Exchange:   "orders",
+		AppId:      "shop",
+		Headers: origamqp.Table{
+			"region":     "eu",
+			"x-order-id": "42",
+		},
+		Body: []byte("hello"),
+	}
+	defaultSource := sourcesv1alpha1.RabbitmqEventSource("", "", "topic")
+
+	type attributes struct {
+		Type, Source, Subject, ID string
+	}
+	testCases := map[string]struct {
+		spec string
+		want attributes
+	}{
+		"defaults": {
+			spec: `{}`,
+			want: attributes{
+				Type:    sourcesv1alpha1.RabbitmqEventType,
+				Source:  defaultSource,
+				Subject: "msg-id",
+				ID:      "msg-id",
+			},
+		},
+		"templates": {
+			spec: `{
+				"type": "com.example.{{ .routingKey }}",
+				"source": "/{{ .exchange }}/{{ .headers.region }}",
+				"subject": "{{ index .headers \"x-order-id\" }}",
+				"id": "{{ .appId }}-{{ .messageId }}"
+			}`,
+			want: attributes{
+				Type:    "com.example.orders.created",
+				Source:  "/orders/eu",
+				Subject: "42",
+				ID:      "shop-msg-id",
+			},
+		},
+		"missing header and empty value": {
+			spec: `{"source": "{{ .headers.missing }}", "subject": "{{ .replyTo }}"}`,
+			want: attributes{
+				Type:    sourcesv1alpha1.RabbitmqEventType,
+				Source:  defaultSource,
+				Subject: "msg-id",
+				ID:      "msg-id",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var c attributesConfig
+			if err := c.Decode(tc.spec); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			a := &Adapter{
+				config: &adapterConfig{
+					Topic:      "topic",
+					ForceWrap:  true,
+					Attributes: c,
+				},
+				logger: zap.NewNop(),
+			}
+
+			event, err := a.toEvent(&amqp.Delivery{Delivery: delivery})
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			got := attributes{
+				Type:    event.Type(),
+				Source:  event.Source(),
+				Subject: event.Subject(),
+				ID:      event.ID(),
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected attributes (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestAttributesConfig_Decode(t *testing.T) {
+	var c attributesConfig
+	if err := c.Decode(`{"type":"{{ .routingKey"}`); err == nil {
+		t.Error("expected error decoding invalid template")
+	}
+	if err := c.Decode("not json"); err == nil {
+		t.Error("expected error decoding invalid config")
+	}
+}
+
+func TestAdapter_CloudEventOverrides(t *testing.T) {
+	a := &Adapter{
+		config: &adapterConfig{Topic: "topic"},
+		logger: zap.NewNop(),
+		ceOverrides: &duckv1.CloudEventOverrides{
+			Extensions: map[string]string{"team": "billing", "key": "overridden"},
+		},
+	}
+
+	// Both wrapped messages and forwarded events are overridden.
+	for n, msg := range map[string]*origamqp.Delivery{
+		"wrapped": {MessageId: "msg-id", Body: []byte("hello")},
+		"forwarded": {
+			ContentType: "text/plain",
+			Headers: origamqp.Table{
+				"cloudEvents_specversion": "1.0",
+				"cloudEvents_id":          "event-id",
+				"cloudEvents_source":      "/source",
+				"cloudEvents_type":        "com.example",
+			},
+			Body: []byte("hello"),
+		},
+	} {
+		t.Run(n, func(t *testing.T) {
+			event, err := a.toEvent(&amqp.Delivery{Delivery: msg})
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			want := map[string]interface{}{"team": "billing", "key": "overridden"}
+			if diff := cmp.Diff(want, event.Extensions()); diff != "" {
+				t.Error("unexpected extensions (-want, +got) =", diff)
+			}
+		})
+	}
+}
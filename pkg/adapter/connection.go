@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// connectionConfig is the JSON encoded connection spec of the source, nil if
+// the brokers are connected to with the defaults.
+type connectionConfig struct {
+	*sourcesv1alpha1.RabbitmqSourceConnectionSpec
+}
+
+// Decode implements envconfig.Decoder.
+func (c *connectionConfig) Decode(value string) error {
+	c.RabbitmqSourceConnectionSpec = &sourcesv1alpha1.RabbitmqSourceConnectionSpec{}
+	return json.Unmarshal([]byte(value), c.RabbitmqSourceConnectionSpec)
+}
+
+// nodes returns the nodes replacing the host of the brokers, if any.
+func (c *connectionConfig) nodes() []string {
+	if c.RabbitmqSourceConnectionSpec == nil {
+		return nil
+	}
+	return c.Nodes
+}
+
+// random reports whether the nodes are tried in a random order.
+func (c *connectionConfig) random() bool {
+	return c.RabbitmqSourceConnectionSpec != nil && c.NodeOrder == sourcesv1alpha1.NodeOrderRandom
+}
+
+// options returns the options of the connections, the heartbeat defaulting
+// to defaultHeartbeat.
+func (c *connectionConfig) options() dialer.ConnectionOptions {
+	opts := dialer.ConnectionOptions{Heartbeat: defaultHeartbeat}
+	if c.RabbitmqSourceConnectionSpec == nil {
+		return opts
+	}
+	if c.Heartbeat != nil {
+		opts.Heartbeat = c.Heartbeat.Duration
+	}
+	if c.Timeout != nil {
+		opts.Timeout = c.Timeout.Duration
+	}
+	opts.ChannelMax = int(c.ChannelMax)
+	opts.Name = c.Name
+	return opts
+}
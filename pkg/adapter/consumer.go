@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	origamqp "github.com/streadway/amqp"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// consumerPriorityArg is the consume argument setting the priority of the
+// consumer.
+const consumerPriorityArg = "x-priority"
+
+// consumerConfig is the JSON encoded consumer spec of the source, nil if
+// messages are consumed with the defaults.
+type consumerConfig struct {
+	*sourcesv1alpha1.RabbitmqSourceConsumerSpec
+}
+
+// Decode implements envconfig.Decoder.
+func (c *consumerConfig) Decode(value string) error {
+	c.RabbitmqSourceConsumerSpec = &sourcesv1alpha1.RabbitmqSourceConsumerSpec{}
+	return json.Unmarshal([]byte(value), c.RabbitmqSourceConsumerSpec)
+}
+
+// autoAck reports whether RabbitMQ acknowledges messages on delivery, in
+// which case they must not be acknowledged nor rejected by the adapter.
+func (c *consumerConfig) autoAck() bool {
+	return c.GetAckMode() == sourcesv1alpha1.AckModeAtMostOnce
+}
+
+// tag returns the consumer tag, empty for the server to generate one.
+func (c *consumerConfig) tag() string {
+	if c.RabbitmqSourceConsumerSpec == nil {
+		return ""
+	}
+	return c.Tag
+}
+
+// exclusive reports whether the consumer must be the only one of the queue.
+func (c *consumerConfig) exclusive() bool {
+	return c.RabbitmqSourceConsumerSpec != nil && c.Exclusive
+}
+
+// consumeArguments adds the arguments of the consumer to args.
+func (c *consumerConfig) consumeArguments(args origamqp.Table) {
+	if c.RabbitmqSourceConsumerSpec != nil && c.Priority != nil {
+		args[consumerPriorityArg] = *c.Priority
+	}
+}
+
+// acker acknowledges the messages whose events were accepted by the sink,
+// one at a time or, in batch mode, size at a time. Acknowledging the last
+// message of a batch acknowledges the previous ones, leaving out the failed
+// messages that were already rejected.
+type acker struct {
+	size int
+	// last is the last accepted message not acknowledged yet, and pending
+	// the number of such messages.
+	last    wabbit.Delivery
+	pending int
+}
+
+func newAcker(c consumerConfig) *acker {
+	if c.GetAckMode() != sourcesv1alpha1.AckModeBatch {
+		return &acker{size: 1}
+	}
+	return &acker{size: int(c.GetAckBatchSize())}
+}
+
+// ack acknowledges msg, once its batch is full in batch mode.
+func (k *acker) ack(msg wabbit.Delivery) error {
+	if k.size <= 1 {
+		return msg.Ack(false)
+	}
+	k.last = msg
+	if k.pending++; k.pending < k.size {
+		return nil
+	}
+	return k.flush()
+}
+
+// flush acknowledges the messages of the current batch, if any.
+func (k *acker) flush() error {
+	if k.last == nil {
+		return nil
+	}
+	last := k.last
+	k.last, k.pending = nil, 0
+	return last.Ack(true)
+}
+
+// ticker returns the channel flushing the batches that don't fill up in
+// time, nil unless in batch mode, and the function stopping it.
+func (k *acker) ticker(c consumerConfig) (<-chan time.Time, func()) {
+	if k.size <= 1 {
+		return nil, func() {}
+	}
+	t := time.NewTicker(c.GetAckBatchInterval())
+	return t.C, t.Stop
+}
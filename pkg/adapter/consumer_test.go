@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// ackedDelivery records the acknowledgements and rejections of a message.
+type ackedDelivery struct {
+	wabbit.Delivery
+	tag  uint64
+	acks *[]string
+}
+
+func (d *ackedDelivery) Ack(multiple bool) error {
+	*d.acks = append(*d.acks, fmt.Sprintf("%d/%t", d.tag, multiple))
+	return nil
+}
+
+func (d *ackedDelivery) Nack(multiple, requeue bool) error {
+	*d.acks = append(*d.acks, fmt.Sprintf("nack %d/%t/%t", d.tag, multiple, requeue))
+	return nil
+}
+
+func TestConsumerConfig(t *testing.T) {
+	var c consumerConfig
+	if c.autoAck() || c.exclusive() || c.tag() != "" {
+		t.Errorf("Expected the default consumer, but got %+v", c)
+	}
+	args := origamqp.Table{}
+	c.consumeArguments(args)
+	if len(args) != 0 {
+		t.Errorf("Expected no consume arguments, but got %v", args)
+	}
+
+	if err := c.Decode(`{"ackMode":"at-most-once","tag":"adapter","priority":5,"exclusive":true}`); err != nil {
+		t.Fatal(err)
+	}
+	if !c.autoAck() || !c.exclusive() || c.tag() != "adapter" {
+		t.Errorf("Unexpected consumer %+v", c.RabbitmqSourceConsumerSpec)
+	}
+	c.consumeArguments(args)
+	if diff := cmp.Diff(origamqp.Table{consumerPriorityArg: int32(5)}, args); diff != "" {
+		t.Errorf("Unexpected consume arguments (-want, +got) = %v", diff)
+	}
+}
+
+func TestAcker(t *testing.T) {
+	batchSize := int32(3)
+	testCases := map[string]struct {
+		consumer *sourcesv1alpha1.RabbitmqSourceConsumerSpec
+		want     []string
+	}{
+		"default": {
+			want: []string{"1/false", "2/false", "3/false", "4/false"},
+		},
+		"at-least-once": {
+			consumer: &sourcesv1alpha1.RabbitmqSourceConsumerSpec{AckMode: sourcesv1alpha1.AckModeAtLeastOnce},
+			want:     []string{"1/false", "2/false", "3/false", "4/false"},
+		},
+		"batch": {
+			consumer: &sourcesv1alpha1.RabbitmqSourceConsumerSpec{
+				AckMode:      sourcesv1alpha1.AckModeBatch,
+				AckBatchSize: &batchSize,
+			},
+			// The last message is acknowledged by the final flush.
+			want: []string{"3/true", "4/true"},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var acks []string
+			k := newAcker(consumerConfig{tc.consumer})
+			for tag := uint64(1); tag <= 4; tag++ {
+				if err := k.ack(&ackedDelivery{tag: tag, acks: &acks}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := k.flush(); err != nil {
+				t.Fatal(err)
+			}
+			// Flushing an empty batch acknowledges nothing.
+			if err := k.flush(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, acks); diff != "" {
+				t.Errorf("Unexpected acknowledgements (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	defaultBackoffPolicy = eventingduckv1.BackoffPolicyExponential
+	defaultBackoffDelay  = "PT0.1S"
+
+	// minRequeueDelay and maxRequeueDelay bound the delay before requeuing
+	// a message, doubling with each message requeued in a row.
+	minRequeueDelay = 100 * time.Millisecond
+	maxRequeueDelay = 30 * time.Second
+)
+
+// deliveryConfig is the JSON encoded delivery spec of the source.
+type deliveryConfig struct {
+	*eventingduckv1.DeliverySpec
+}
+
+// Decode implements envconfig.Decoder.
+func (c *deliveryConfig) Decode(value string) error {
+	c.DeliverySpec = &eventingduckv1.DeliverySpec{}
+	return json.Unmarshal([]byte(value), c.DeliverySpec)
+}
+
+// retryConfig returns the retry configuration of the delivery spec, or nil if
+// the source has none.
+func (c *deliveryConfig) retryConfig(logger *zap.Logger) *kncloudevents.RetryConfig {
+	if c.DeliverySpec == nil {
+		return nil
+	}
+	spec := *c.DeliverySpec
+	if spec.BackoffPolicy == nil {
+		policy := defaultBackoffPolicy
+		spec.BackoffPolicy = &policy
+	}
+	if spec.BackoffDelay == nil {
+		delay := defaultBackoffDelay
+		spec.BackoffDelay = &delay
+	}
+	config, err := kncloudevents.RetryConfigFromDeliverySpec(spec)
+	if err != nil {
+		logger.Error("Invalid delivery spec, using the default backoff", zap.Error(err))
+		delay := defaultBackoffDelay
+		spec.BackoffDelay = &delay
+		spec.Timeout = nil
+		config, _ = kncloudevents.RetryConfigFromDeliverySpec(spec)
+	}
+	return &config
+}
+
+// handleFailedMessage settles a message whose event could not be sent to the
+// sink. Without a delivery spec the message is requeued. Otherwise its event
+// is sent to the dead letter sink or, if there's none, the message is
+// rejected so that RabbitMQ routes it to the dead letter exchange of the
+// queue, if any. Messages are only requeued if the dead letter sink fails,
+// after a delay so that failing messages don't loop, and never if they can't
+// be turned into events. Messages acknowledged on delivery can only be sent
+// to the dead letter sink and are otherwise lost.
+func (a *Adapter) handleFailedMessage(msg wabbit.Delivery, event *cloudevents.Event, stopCh <-chan struct{}) {
+	logger := a.logger
+
+	if a.config.Consumer.autoAck() {
+		if a.config.DeadLetterSink == "" || event == nil {
+			logger.Error("Dropping the message acknowledged on delivery")
+		} else if err := a.deadLetter(event); err != nil {
+			logger.Error("Sending event to dead letter sink failed, dropping the message acknowledged on delivery: ", zap.Error(err))
+		} else {
+			logger.Info("Sent event to dead letter sink")
+		}
+		return
+	}
+
+	requeue := a.config.Delivery.DeliverySpec == nil && event != nil
+	if a.config.Delivery.DeliverySpec != nil && a.config.DeadLetterSink != "" && event != nil {
+		if err := a.deadLetter(event); err != nil {
+			logger.Error("Sending event to dead letter sink failed: ", zap.Error(err))
+			requeue = true
+		} else {
+			logger.Info("Sent event to dead letter sink")
+			a.requeues = 0
+			if err := msg.Ack(false); err != nil {
+				logger.Error("Sending Ack failed with Delivery Tag")
+			}
+			return
+		}
+	}
+
+	if requeue {
+		a.waitRequeue(stopCh)
+	} else {
+		a.requeues = 0
+	}
+	if err := msg.Nack(false, requeue); err != nil {
+		logger.Error("Sending Nack failed with Delivery Tag")
+	}
+}
+
+// deadLetter sends the event to the dead letter sink.
+func (a *Adapter) deadLetter(event *cloudevents.Event) error {
+	res, err := a.sendEvent(event, a.config.DeadLetterSink)
+	if err != nil {
+		return err
+	}
+	closeBody(res)
+	return nil
+}
+
+// waitRequeue waits before requeuing a message, longer with each message
+// requeued in a row, or until stopCh is closed.
+func (a *Adapter) waitRequeue(stopCh <-chan struct{}) {
+	delay := minRequeueDelay
+	for i := 0; i < a.requeues && delay < maxRequeueDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRequeueDelay {
+		delay = maxRequeueDelay
+	}
+	a.requeues++
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-stopCh:
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+func TestAdapter_Retries(t *testing.T) {
+	var attempts int32
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sink.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, _ := source.NewStatsReporter()
+
+	retry := int32(2)
+	delivery := deliveryConfig{&eventingduckv1.DeliverySpec{Retry: &retry}}
+	a := &Adapter{
+		config:            &adapterConfig{Topic: "topic", Delivery: delivery},
+		context:           context.TODO(),
+		httpMessageSender: s,
+		logger:            zap.NewNop(),
+		reporter:          statsReporter,
+		retryConfig:       delivery.retryConfig(zap.NewNop()),
+	}
+
+	event, err := a.wrapMessage(&server.Delivery{}, "text/plain", []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.postMessage(event); err != nil {
+		t.Error("unexpected error:", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("Expected 3 attempts, but got %d", got)
+	}
+}
+
+func TestAdapter_HandleFailedMessage(t *testing.T) {
+	testCases := map[string]struct {
+		delivery       *eventingduckv1.DeliverySpec
+		consumer       *sourcesv1alpha1.RabbitmqSourceConsumerSpec
+		deadLetterSink func(http.ResponseWriter, *http.Request)
+		undecodable    bool
+		wantRequeued   bool
+		wantDeadLetter bool
+	}{
+		"no delivery spec": {
+			wantRequeued: true,
+		},
+		"dead letter sink": {
+			delivery:       &eventingduckv1.DeliverySpec{},
+			deadLetterSink: sinkAccepted,
+			wantDeadLetter: true,
+		},
+		"dead letter sink fails": {
+			delivery:       &eventingduckv1.DeliverySpec{},
+			deadLetterSink: sinkRejected,
+			wantRequeued:   true,
+			wantDeadLetter: true,
+		},
+		"no dead letter sink": {
+			delivery: &eventingduckv1.DeliverySpec{},
+		},
+		"undecodable message": {
+			undecodable: true,
+		},
+		"undecodable message with dead letter sink": {
+			delivery:       &eventingduckv1.DeliverySpec{},
+			deadLetterSink: sinkAccepted,
+			undecodable:    true,
+		},
+		"acknowledged on delivery": {
+			consumer: &sourcesv1alpha1.RabbitmqSourceConsumerSpec{AckMode: sourcesv1alpha1.AckModeAtMostOnce},
+		},
+		"acknowledged on delivery with dead letter sink": {
+			delivery:       &eventingduckv1.DeliverySpec{},
+			consumer:       &sourcesv1alpha1.RabbitmqSourceConsumerSpec{AckMode: sourcesv1alpha1.AckModeAtMostOnce},
+			deadLetterSink: sinkRejected,
+			wantDeadLetter: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			url := "amqp://localhost:5672/" + t.Name()
+			fakeServer := server.NewServer(url)
+			if err := fakeServer.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer fakeServer.Stop()
+
+			conn, err := amqptest.Dial(url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ch, err := conn.Channel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ch.Close()
+
+			if _, err := ch.QueueDeclare("queue", nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := ch.Publish("", "queue", []byte("hello"), nil); err != nil {
+				t.Fatal(err)
+			}
+			msgs, err := ch.Consume("queue", "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			msg := <-msgs
+
+			h := &fakeHandler{handler: sinkAccepted}
+			var dls string
+			if tc.deadLetterSink != nil {
+				h.handler = tc.deadLetterSink
+				dlsServer := httptest.NewServer(h)
+				defer dlsServer.Close()
+				dls = dlsServer.URL
+			}
+			s, err := kncloudevents.NewHTTPMessageSenderWithTarget("http://sink.invalid")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := &Adapter{
+				config: &adapterConfig{
+					Topic:          "topic",
+					Delivery:       deliveryConfig{tc.delivery},
+					Consumer:       consumerConfig{tc.consumer},
+					DeadLetterSink: dls,
+				},
+				context:           context.TODO(),
+				httpMessageSender: s,
+				logger:            zap.NewNop(),
+			}
+			event, err := a.toEvent(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.undecodable {
+				event = nil
+			}
+			a.handleFailedMessage(msg, event, nil)
+
+			if got := h.body != nil; got != tc.wantDeadLetter {
+				t.Errorf("Expected dead letter sink to be called: %t, but got %t", tc.wantDeadLetter, got)
+			}
+
+			select {
+			case m := <-msgs:
+				if !tc.wantRequeued {
+					t.Errorf("Expected message not to be requeued, but got %q", m.Body())
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.wantRequeued {
+					t.Error("Expected message to be requeued")
+				}
+			}
+		})
+	}
+}
+
+func TestAdapter_WaitRequeue(t *testing.T) {
+	a := &Adapter{requeues: 2}
+	start := time.Now()
+	a.waitRequeue(nil)
+	if got := time.Since(start); got < 4*minRequeueDelay {
+		t.Errorf("Expected the third requeue to wait %v, but waited %v", 4*minRequeueDelay, got)
+	}
+	if a.requeues != 3 {
+		t.Errorf("Expected 3 requeues in a row, but got %d", a.requeues)
+	}
+
+	// The delay is bounded and cut short by stopping.
+	a.requeues = 100
+	stopCh := make(chan struct{})
+	close(stopCh)
+	a.waitRequeue(stopCh)
+}
+
+func TestDeliveryConfig_RetryConfig(t *testing.T) {
+	var c deliveryConfig
+	if got := c.retryConfig(zap.NewNop()); got != nil {
+		t.Errorf("Expected no retry config, but got %+v", got)
+	}
+
+	if err := c.Decode(`{"retry":3,"backoffPolicy":"linear","backoffDelay":"PT1S"}`); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	got := c.retryConfig(zap.NewNop())
+	if got.RetryMax != 3 {
+		t.Errorf("Expected 3 retries, but got %d", got.RetryMax)
+	}
+	if d := got.Backoff(2, nil); d != 2*time.Second {
+		t.Errorf("Expected linear backoff of 2s, but got %v", d)
+	}
+
+	if err := c.Decode(`{"backoffDelay":"invalid"}`); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	got = c.retryConfig(zap.NewNop())
+	if d := got.Backoff(1, nil); d != 200*time.Millisecond {
+		t.Errorf("Expected default exponential backoff of 200ms, but got %v", d)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// Message properties that can be mapped into extensions.
+const (
+	propertyRoutingKey    = "routingKey"
+	propertyExchange      = "exchange"
+	propertyCorrelationID = "correlationId"
+	propertyReplyTo       = "replyTo"
+	propertyAppID         = "appId"
+	propertyPriority      = "priority"
+	propertyRedelivered   = "redelivered"
+	propertyHeaders       = "headers"
+)
+
+// extensionsConfig is the JSON encoded extensions spec of the source.
+type extensionsConfig sourcesv1alpha1.RabbitmqSourceExtensionsSpec
+
+// Decode implements envconfig.Decoder.
+func (c *extensionsConfig) Decode(value string) error {
+	return json.Unmarshal([]byte(value), c)
+}
+
+// setExtensions maps the allowed properties of the message into extensions of
+// the event. Extensions already set on the event are left untouched.
+func (a *Adapter) setExtensions(event *cloudevents.Event, msg wabbit.Delivery) {
+	for name, val := range a.extensions(msg) {
+		if _, ok := event.Extensions()[name]; ok || isContextAttribute(name) {
+			continue
+		}
+		if err := event.Context.SetExtension(name, val); err != nil {
+			a.logger.Warn("Failed to set extension", zap.String("name", name), zap.Error(err))
+		}
+	}
+}
+
+// extensions returns the allowed properties of the message by extension name.
+// When several properties map to the same name the first one wins.
+func (a *Adapter) extensions(msg wabbit.Delivery) map[string]interface{} {
+	c := a.config.Extensions
+	if len(c.Properties) == 0 {
+		return nil
+	}
+	d, _ := msg.(*amqp.Delivery)
+
+	exts := make(map[string]interface{})
+	add := func(key string, val interface{}) {
+		name := c.extensionName(key)
+		if _, ok := exts[name]; ok {
+			return
+		}
+		if v := extensionValue(val); v != nil {
+			exts[name] = v
+		}
+	}
+	for _, p := range c.Properties {
+		switch p {
+		case propertyHeaders:
+			flattenHeaders(propertyHeaders, msg.Headers(), add)
+		case propertyRoutingKey, propertyExchange, propertyCorrelationID, propertyReplyTo,
+			propertyAppID, propertyPriority, propertyRedelivered:
+			if d == nil || d.Delivery == nil {
+				continue
+			}
+			add(p, deliveryProperty(d.Delivery, p))
+		default:
+			a.logger.Warn("Ignoring unknown message property", zap.String("property", p))
+		}
+	}
+	return exts
+}
+
+func deliveryProperty(d *origamqp.Delivery, property string) interface{} {
+	switch property {
+	case propertyRoutingKey:
+		return d.RoutingKey
+	case propertyExchange:
+		return d.Exchange
+	case propertyCorrelationID:
+		return d.CorrelationId
+	case propertyReplyTo:
+		return d.ReplyTo
+	case propertyAppID:
+		return d.AppId
+	case propertyPriority:
+		return int32(d.Priority)
+	case propertyRedelivered:
+		return d.Redelivered
+	}
+	return nil
+}
+
+// flattenHeaders calls add for every header, with nested tables flattened
+// into keys joined with ".". Keys are visited in order so that conflicting
+// extension names are resolved deterministically.
+func flattenHeaders(prefix string, headers map[string]interface{}, add func(string, interface{})) {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := prefix + "." + k
+		switch v := headers[k].(type) {
+		case origamqp.Table:
+			flattenHeaders(key, v, add)
+		case wabbit.Option:
+			flattenHeaders(key, v, add)
+		case map[string]interface{}:
+			flattenHeaders(key, v, add)
+		default:
+			add(key, v)
+		}
+	}
+}
+
+// extensionName returns the name of the extension of a property or header.
+func (c *extensionsConfig) extensionName(key string) string {
+	if name, ok := c.Names[key]; ok {
+		return name
+	}
+	// Headers are named after their key, without the "headers." prefix.
+	key = strings.TrimPrefix(key, propertyHeaders+".")
+	return sanitizeExtensionName(key)
+}
+
+// sanitizeExtensionName lowercases name and strips the characters that
+// CloudEvents extension names can't contain.
+func sanitizeExtensionName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// extensionValue converts an AMQP value into a CloudEvents attribute value,
+// or returns nil for empty values. Values with no CloudEvents counterpart are
+// converted to strings.
+func extensionValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return v
+	case bool, int32, []byte, time.Time:
+		return v
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	if v, err := types.Validate(val); err == nil {
+		return v
+	}
+	return fmt.Sprint(val)
+}
+
+// isContextAttribute returns whether name is a CloudEvents context attribute,
+// which can't be set as an extension.
+func isContextAttribute(name string) bool {
+	switch name {
+	case "specversion", "id", "source", "type", "subject", "time",
+		"datacontenttype", "dataschema", "data", "data_base64":
+		return true
+	}
+	return false
+}
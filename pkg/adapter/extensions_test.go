@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/wabbit/amqp"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+)
+
+func TestAdapter_Extensions(t *testing.T) {
+	delivery := &origamqp.Delivery{
+		MessageId:     "msg-id",
+		RoutingKey:    "orders.created",
+		Exchange:      "orders",
+		CorrelationId: "corr-id",
+		ReplyTo:       "replies",
+		AppId:         "shop",
+		Priority:      5,
+		Redelivered:   true,
+		Headers: origamqp.Table{
+			"X-Tenant":   "acme",
+			"retries":    int64(3),
+			"ratio":      1.5,
+			"big":        int64(1) << 40,
+			"id":         "clash",
+			"empty":      "",
+			"tracing":    origamqp.Table{"span-id": "abc"},
+			"tags":       []interface{}{"a", "b"},
+			"compressed": true,
+		},
+		Body: []byte("hello"),
+	}
+
+	testCases := map[string]struct {
+		config extensionsConfig
+		want   map[string]interface{}
+	}{
+		"none": {
+			want: map[string]interface{}{"key": "msg-id"},
+		},
+		"properties": {
+			config: extensionsConfig{
+				Properties: []string{"routingKey", "exchange", "correlationId", "replyTo", "appId", "priority", "redelivered", "unknown"},
+			},
+			want: map[string]interface{}{
+				"key":           "msg-id",
+				"routingkey":    "orders.created",
+				"exchange":      "orders",
+				"correlationid": "corr-id",
+				"replyto":       "replies",
+				"appid":         "shop",
+				"priority":      int32(5),
+				"redelivered":   true,
+			},
+		},
+		"headers": {
+			config: extensionsConfig{
+				Properties: []string{"headers"},
+			},
+			want: map[string]interface{}{
+				"key":           "msg-id",
+				"xtenant":       "acme",
+				"retries":       int32(3),
+				"ratio":         "1.5",
+				"big":           "1099511627776",
+				"tracingspanid": "abc",
+				"tags":          "[a b]",
+				"compressed":    true,
+			},
+		},
+		"names": {
+			config: extensionsConfig{
+				Properties: []string{"routingKey", "headers"},
+				Names: map[string]string{
+					"routingKey":              "topic",
+					"headers.X-Tenant":        "tenant",
+					"headers.tracing.span-id": "spanid",
+					"headers.id":              "originalid",
+				},
+			},
+			want: map[string]interface{}{
+				"key":        "msg-id",
+				"topic":      "orders.created",
+				"tenant":     "acme",
+				"retries":    int32(3),
+				"ratio":      "1.5",
+				"big":        "1099511627776",
+				"spanid":     "abc",
+				"tags":       "[a b]",
+				"compressed": true,
+				"originalid": "clash",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &adapterConfig{
+					Topic:      "topic",
+					Extensions: tc.config,
+				},
+				logger: zap.NewNop(),
+			}
+
+			event, err := a.toEvent(&amqp.Delivery{Delivery: delivery})
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if diff := cmp.Diff(tc.want, event.Extensions()); diff != "" {
+				t.Error("unexpected extensions (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestExtensionsConfig_Decode(t *testing.T) {
+	var c extensionsConfig
+	if err := c.Decode(`{"properties":["headers"],"names":{"headers.a:b":"ab"}}`); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want := extensionsConfig{
+		Properties: []string{"headers"},
+		Names:      map[string]string{"headers.a:b": "ab"},
+	}
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Error("unexpected config (-want, +got) =", diff)
+	}
+
+	if err := c.Decode("not json"); err == nil {
+		t.Error("expected error decoding invalid config")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"fmt"
+	nethttp "net/http"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// HealthPath is the path of the readiness endpoint of the adapter.
+const HealthPath = "/healthz"
+
+func (a *Adapter) setReady(ready bool) {
+	var v int32
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&a.ready, v)
+}
+
+func (a *Adapter) isReady() bool {
+	return atomic.LoadInt32(&a.ready) == 1
+}
+
+// healthHandler reports the adapter ready while it is consuming messages.
+func (a *Adapter) healthHandler(w nethttp.ResponseWriter, _ *nethttp.Request) {
+	if !a.isReady() {
+		nethttp.Error(w, "not consuming from RabbitMQ", nethttp.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(nethttp.StatusOK)
+}
+
+// serveHealth serves the readiness and status endpoints until stopCh is closed.
+func (a *Adapter) serveHealth(stopCh <-chan struct{}) {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc(HealthPath, a.healthHandler)
+	mux.HandleFunc(StatusPath, a.statusHandler)
+	srv := &nethttp.Server{
+		Addr:    fmt.Sprintf(":%d", a.config.HealthPort),
+		Handler: mux,
+	}
+	go func() {
+		<-stopCh
+		srv.Close()
+	}()
+	if err := srv.ListenAndServe(); err != nil && err != nethttp.ErrServerClosed {
+		a.logger.Error("Readiness endpoint failed", zap.Error(err))
+	}
+}
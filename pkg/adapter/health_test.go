@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"go.uber.org/zap"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+func TestAdapter_HealthHandler(t *testing.T) {
+	a := &Adapter{}
+
+	rec := httptest.NewRecorder()
+	a.healthHandler(rec, httptest.NewRequest(http.MethodGet, HealthPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d when not consuming, but got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	a.setReady(true)
+	rec = httptest.NewRecorder()
+	a.healthHandler(rec, httptest.NewRequest(http.MethodGet, HealthPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d when consuming, but got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestAdapter_Reconnect(t *testing.T) {
+	url := "amqp://localhost:5672/reconnect"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	received := make(chan []byte, 1)
+	h := &fakeHandler{handler: func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}}
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		received <- h.body
+	}))
+	defer sink.Close()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sink.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, _ := source.NewStatsReporter()
+
+	a := &Adapter{
+		config: &adapterConfig{
+			Topic:   "topic",
+			Brokers: url,
+			ExchangeConfig: ExchangeConfig{
+				Name:   "exchange",
+				TypeOf: "direct",
+			},
+			QueueConfig: QueueConfig{
+				Name:       "queue",
+				RoutingKey: "key",
+			},
+		},
+		context:           context.TODO(),
+		httpMessageSender: s,
+		logger:            zap.NewNop(),
+		reporter:          statsReporter,
+		dialerFunc:        dialer.TestDialer,
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- a.start(stopCh)
+	}()
+
+	waitForReady := func(ready bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for a.isReady() != ready {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out waiting for ready to be %t", ready)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	waitForReady(true)
+	if err := fakeServer.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	waitForReady(false)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	waitForReady(true)
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.Publish("exchange", "key", []byte("hello"), nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case body := <-received:
+		if string(body) != "hello" {
+			t.Errorf("Expected body %q, but got %q", "hello", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Timed out waiting for the message after reconnecting")
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Error("Expected adapter to stop without error, but got", err)
+	}
+}
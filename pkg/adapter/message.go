@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// Prefixes of the application properties carrying the attributes of
+// CloudEvents published in binary content mode. The AMQP binding uses
+// "cloudEvents_" (":" in earlier drafts), while some publishers reuse the
+// HTTP "ce-" headers.
+var binaryVersions = []*spec.Versions{
+	spec.WithPrefix("cloudEvents_"),
+	spec.WithPrefix("cloudEvents:"),
+	spec.WithPrefix("ce-"),
+}
+
+// toEvent converts the message into the CloudEvent sent to the sink. Messages
+// that already are CloudEvents are forwarded unchanged unless the source
+// forces wrapping. Message properties are only mapped into the attributes and
+// extensions of wrapped events, while the CloudEvent overrides of the source
+// apply to every event.
+func (a *Adapter) toEvent(msg wabbit.Delivery) (*cloudevents.Event, error) {
+	contentType, body := a.messageData(msg)
+
+	var event *cloudevents.Event
+	var err error
+	if !a.config.ForceWrap {
+		event, err = messageToEvent(msg.Headers(), contentType, body)
+		if err != nil {
+			a.logger.Warn("Message is not a valid CloudEvent, wrapping it", zap.Error(err))
+			event = nil
+		}
+	}
+	if event == nil {
+		if event, err = a.wrapMessage(msg, contentType, body); err != nil {
+			return nil, err
+		}
+	}
+
+	a.applyOverrides(event)
+	return event, nil
+}
+
+// wrapMessage wraps the message into a new event.
+func (a *Adapter) wrapMessage(msg wabbit.Delivery, contentType string, body []byte) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if msg.MessageId() != "" {
+		event.SetID(msg.MessageId())
+	} else {
+		event.SetID(string(uuid.NewUUID()))
+	}
+	event.SetTime(msg.Timestamp())
+	event.SetType(sourcesv1alpha1.RabbitmqEventType)
+	event.SetSource(sourcesv1alpha1.RabbitmqEventSource(a.config.Namespace, a.config.Name, a.config.Topic))
+	event.SetSubject(msg.MessageId())
+	event.SetExtension("key", msg.MessageId())
+	a.setAttributes(&event, msg)
+	a.setExtensions(&event, msg)
+
+	if err := event.SetData(contentType, body); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// messageToEvent returns the CloudEvent carried by a message in binary or
+// structured content mode, or nil if the message is not a CloudEvent.
+func messageToEvent(headers wabbit.Option, contentType string, body []byte) (*cloudevents.Event, error) {
+	if event, err := dialer.BinaryToEvent(binaryVersions, headers, contentType, body); event != nil || err != nil {
+		return event, err
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != cloudevents.ApplicationCloudEventsJSON {
+		return nil, nil
+	}
+	event := cloudevents.NewEvent()
+	if err := event.UnmarshalJSON(body); err != nil {
+		return nil, err
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// messageData returns the content type and the decoded body of the message.
+// Bodies with a content encoding that can't be decoded are passed through as
+// opaque bytes.
+func (a *Adapter) messageData(msg wabbit.Delivery) (string, []byte) {
+	contentType := a.config.ContentType
+	var contentEncoding string
+	if d, ok := msg.(*amqp.Delivery); ok && d.Delivery != nil {
+		if d.Delivery.ContentType != "" {
+			contentType = d.Delivery.ContentType
+		}
+		contentEncoding = d.Delivery.ContentEncoding
+	}
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+
+	body, err := decodeBody(contentEncoding, msg.Body())
+	if err != nil {
+		a.logger.Warn("Failed to decode message body, sending it as is",
+			zap.String("contentEncoding", contentEncoding), zap.Error(err))
+		return defaultContentType, msg.Body()
+	}
+	return contentType, body
+}
+
+// decodeBody decompresses a body encoded with the given AMQP content-encoding.
+func decodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	var r io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		r, err = zlib.NewReader(bytes.NewReader(body))
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
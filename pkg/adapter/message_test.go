@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/wabbit/amqp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+func TestAdapter_ToEvent(t *testing.T) {
+	structured := `{"specversion":"1.0","id":"ce-id","type":"ce.type","source":"ce/source","datacontenttype":"application/json","data":{"key":"value"}}`
+
+	testCases := map[string]struct {
+		contentType string
+		headers     origamqp.Table
+		body        string
+		forceWrap   bool
+		wantID      string
+		wantType    string
+		wantSource  string
+		wantExt     map[string]interface{}
+		wantData    string
+	}{
+		"structured": {
+			contentType: "application/cloudevents+json; charset=utf-8",
+			body:        structured,
+			wantID:      "ce-id",
+			wantType:    "ce.type",
+			wantSource:  "ce/source",
+			wantData:    `{"key":"value"}`,
+		},
+		"structured, force wrap": {
+			contentType: "application/cloudevents+json",
+			body:        structured,
+			forceWrap:   true,
+			wantID:      "msg-id",
+			wantType:    sourcesv1alpha1.RabbitmqEventType,
+			wantSource:  sourcesv1alpha1.RabbitmqEventSource("ns", "name", "topic"),
+			wantData:    structured,
+		},
+		"invalid structured": {
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion":"1.0"}`,
+			wantID:      "msg-id",
+			wantType:    sourcesv1alpha1.RabbitmqEventType,
+			wantSource:  sourcesv1alpha1.RabbitmqEventSource("ns", "name", "topic"),
+			wantData:    `{"specversion":"1.0"}`,
+		},
+		"json that is not structured": {
+			contentType: "application/json",
+			body:        structured,
+			wantID:      "msg-id",
+			wantType:    sourcesv1alpha1.RabbitmqEventType,
+			wantSource:  sourcesv1alpha1.RabbitmqEventSource("ns", "name", "topic"),
+			wantData:    structured,
+		},
+		"binary": {
+			contentType: "application/json",
+			headers: origamqp.Table{
+				"cloudEvents_specversion": "1.0",
+				"cloudEvents_id":          "ce-id",
+				"cloudEvents_type":        "ce.type",
+				"cloudEvents_source":      "ce/source",
+				"cloudEvents_myext":       "ext",
+			},
+			body:       `{"key":"value"}`,
+			wantID:     "ce-id",
+			wantType:   "ce.type",
+			wantSource: "ce/source",
+			wantExt:    map[string]interface{}{"myext": "ext"},
+			wantData:   `{"key":"value"}`,
+		},
+		"binary, http headers": {
+			contentType: "text/plain",
+			headers: origamqp.Table{
+				"ce-specversion": "1.0",
+				"ce-id":          "ce-id",
+				"ce-type":        "ce.type",
+				"ce-source":      "ce/source",
+			},
+			body:       "hello",
+			wantID:     "ce-id",
+			wantType:   "ce.type",
+			wantSource: "ce/source",
+			wantData:   "hello",
+		},
+		"binary, force wrap": {
+			contentType: "text/plain",
+			headers: origamqp.Table{
+				"cloudEvents:specversion": "1.0",
+				"cloudEvents:id":          "ce-id",
+				"cloudEvents:type":        "ce.type",
+				"cloudEvents:source":      "ce/source",
+			},
+			body:       "hello",
+			forceWrap:  true,
+			wantID:     "msg-id",
+			wantType:   sourcesv1alpha1.RabbitmqEventType,
+			wantSource: sourcesv1alpha1.RabbitmqEventSource("ns", "name", "topic"),
+			wantData:   "hello",
+		},
+		"binary, missing attributes": {
+			contentType: "text/plain",
+			headers: origamqp.Table{
+				"cloudEvents_specversion": "1.0",
+			},
+			body:       "hello",
+			wantID:     "msg-id",
+			wantType:   sourcesv1alpha1.RabbitmqEventType,
+			wantSource: sourcesv1alpha1.RabbitmqEventSource("ns", "name", "topic"),
+			wantData:   "hello",
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &adapterConfig{
+					Topic:     "topic",
+					ForceWrap: tc.forceWrap,
+				},
+				logger: zap.NewNop(),
+			}
+			a.config.Namespace = "ns"
+			a.config.Name = "name"
+
+			m := &amqp.Delivery{}
+			m.Delivery = &origamqp.Delivery{
+				MessageId:   "msg-id",
+				ContentType: tc.contentType,
+				Headers:     tc.headers,
+				Body:        []byte(tc.body),
+			}
+			event, err := a.toEvent(m)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if event.ID() != tc.wantID {
+				t.Errorf("Expected id %q, but got %q", tc.wantID, event.ID())
+			}
+			if event.Type() != tc.wantType {
+				t.Errorf("Expected type %q, but got %q", tc.wantType, event.Type())
+			}
+			if event.Source() != tc.wantSource {
+				t.Errorf("Expected source %q, but got %q", tc.wantSource, event.Source())
+			}
+			for k, v := range tc.wantExt {
+				if got := event.Extensions()[k]; got != v {
+					t.Errorf("Expected extension %q to be %v, but got %v", k, v, got)
+				}
+			}
+			if string(event.Data()) != tc.wantData {
+				t.Errorf("Expected data %q, but got %q", tc.wantData, event.Data())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	origamqp "github.com/streadway/amqp"
+)
+
+// queueArguments are the arguments of the queue declaration, decoded from
+// a JSON object.
+type queueArguments origamqp.Table
+
+// Decode implements envconfig.Decoder.
+func (q *queueArguments) Decode(value string) error {
+	if value == "" {
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewBufferString(value))
+	d.UseNumber()
+	var args map[string]interface{}
+	if err := d.Decode(&args); err != nil {
+		return fmt.Errorf("invalid queue arguments: %w", err)
+	}
+	*q = queueArguments(toTable(args))
+	return nil
+}
+
+// bindingConfig is a binding of the queue to an exchange.
+type bindingConfig struct {
+	Exchange   string
+	RoutingKey string
+	Arguments  origamqp.Table
+}
+
+// bindingsConfig are the bindings of the queue, decoded from the JSON list of
+// the bindings of the source.
+type bindingsConfig []bindingConfig
+
+// Decode implements envconfig.Decoder.
+func (b *bindingsConfig) Decode(value string) error {
+	if value == "" {
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewBufferString(value))
+	d.UseNumber()
+	var bindings []struct {
+		Exchange   string                 `json:"exchange"`
+		RoutingKey string                 `json:"routingKey"`
+		Arguments  map[string]interface{} `json:"arguments"`
+	}
+	if err := d.Decode(&bindings); err != nil {
+		return fmt.Errorf("invalid bindings: %w", err)
+	}
+	*b = make(bindingsConfig, 0, len(bindings))
+	for _, binding := range bindings {
+		*b = append(*b, bindingConfig{
+			Exchange:   binding.Exchange,
+			RoutingKey: binding.RoutingKey,
+			Arguments:  toTable(binding.Arguments),
+		})
+	}
+	return nil
+}
+
+// declareArguments returns the arguments of the queue declaration, including
+// its type.
+func (a *Adapter) declareArguments() origamqp.Table {
+	args := origamqp.Table{}
+	for k, v := range a.config.QueueConfig.Arguments {
+		args[k] = v
+	}
+	if a.config.QueueConfig.Type != "" {
+		args[queueTypeArg] = a.config.QueueConfig.Type
+	}
+	return args
+}
+
+// toTable converts decoded JSON into the types of AMQP field tables.
+func toTable(m map[string]interface{}) origamqp.Table {
+	t := origamqp.Table{}
+	for k, v := range m {
+		t[k] = toFieldValue(v)
+	}
+	return t
+}
+
+func toFieldValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		return toTable(v)
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i := range v {
+			a[i] = toFieldValue(v[i])
+		}
+		return a
+	default:
+		return v
+	}
+}
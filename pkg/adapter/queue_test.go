@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+)
+
+func TestAdapter_DeclareArguments(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		arguments string
+		queueType string
+		want      origamqp.Table
+		wantErr   bool
+	}{{
+		name: "none",
+		want: origamqp.Table{},
+	}, {
+		name:      "type",
+		queueType: "quorum",
+		want:      origamqp.Table{"x-queue-type": "quorum"},
+	}, {
+		name:      "arguments",
+		arguments: `{"x-message-ttl": 60000, "x-overflow": "reject-publish", "x-single-active-consumer": true, "ratio": 0.5, "nested": {"list": [1, "a"]}}`,
+		queueType: "classic",
+		want: origamqp.Table{
+			"x-queue-type":             "classic",
+			"x-message-ttl":            int64(60000),
+			"x-overflow":               "reject-publish",
+			"x-single-active-consumer": true,
+			"ratio":                    0.5,
+			"nested":                   origamqp.Table{"list": []interface{}{int64(1), "a"}},
+		},
+	}, {
+		name:      "invalid",
+		arguments: `["x-message-ttl"]`,
+		wantErr:   true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			var args queueArguments
+			err := args.Decode(tt.arguments)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error: %t, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			a := &Adapter{config: &adapterConfig{QueueConfig: QueueConfig{Type: tt.queueType, Arguments: args}}}
+			got := a.declareArguments()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unexpected arguments (-want, +got) = %v", diff)
+			}
+			if err := got.Validate(); err != nil {
+				t.Error("Arguments are not a valid AMQP table:", err)
+			}
+		})
+	}
+}
+
+func TestBindingsConfig_Decode(t *testing.T) {
+	var got bindingsConfig
+	err := got.Decode(`[{"exchange":"headers","arguments":{"x-match":"all","version":2}},{"routingKey":"key"}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bindingsConfig{{
+		Exchange:  "headers",
+		Arguments: origamqp.Table{"x-match": "all", "version": int64(2)},
+	}, {
+		RoutingKey: "key",
+		Arguments:  origamqp.Table{},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected bindings (-want, +got) = %v", diff)
+	}
+
+	if err := got.Decode(`{"exchange":"headers"}`); err == nil {
+		t.Error("Expected an error decoding an object")
+	}
+}
+
+func TestAdapter_StartAmqpClientWithBindings(t *testing.T) {
+	url := "amqp://localhost:5672/bindings"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.ExchangeDeclare("other", "direct", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Adapter{
+		config: &adapterConfig{
+			Topic: "topic",
+			ExchangeConfig: ExchangeConfig{
+				Name:   "exchange",
+				TypeOf: "direct",
+			},
+			QueueConfig: QueueConfig{
+				Name:       "queue",
+				RoutingKey: "ignored",
+			},
+			Bindings: bindingsConfig{{
+				RoutingKey: "a",
+			}, {
+				Exchange:   "other",
+				RoutingKey: "b",
+			}},
+		},
+		logger: zap.NewNop(),
+	}
+	queue, err := a.StartAmqpClient(&ch)
+	if err != nil {
+		t.Fatal("Failed to declare the topology:", err)
+	}
+
+	for _, p := range []struct{ exchange, key string }{{"exchange", "a"}, {"other", "b"}} {
+		if err := ch.Publish(p.exchange, p.key, []byte(p.exchange+"/"+p.key), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The routing key of the queue config is replaced by the bindings.
+	if err := ch.Publish("exchange", "ignored", []byte("ignored"), nil); err == nil {
+		t.Error("Expected the routing key of the queue config not to be bound")
+	}
+
+	msgs, err := ch.Consume((*queue).Name(), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for len(got) < 2 {
+		select {
+		case msg := <-msgs:
+			got = append(got, string(msg.Body()))
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for messages, got", got)
+		}
+	}
+	if diff := cmp.Diff([]string{"exchange/a", "other/b"}, got); diff != "" {
+		t.Errorf("Unexpected messages (-want, +got) = %v", diff)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// replyConfig is the JSON encoded reply spec of the source, nil if replies
+// are dropped.
+type replyConfig struct {
+	*sourcesv1alpha1.RabbitmqSourceReplySpec
+}
+
+// Decode implements envconfig.Decoder.
+func (c *replyConfig) Decode(value string) error {
+	c.RabbitmqSourceReplySpec = &sourcesv1alpha1.RabbitmqSourceReplySpec{}
+	return json.Unmarshal([]byte(value), c.RabbitmqSourceReplySpec)
+}
+
+// replyEvent returns the event replied by the sink in res, nil if there's
+// none or if replies are dropped. The body of res is closed.
+func (a *Adapter) replyEvent(res *nethttp.Response) (*cloudevents.Event, error) {
+	message := http.NewMessageFromHttpResponse(res)
+	defer message.Finish(nil)
+
+	if a.config.Reply.RabbitmqSourceReplySpec == nil {
+		return nil, nil
+	}
+	event, err := binding.ToEvent(a.context, message)
+	if errors.Is(err, binding.ErrUnknownEncoding) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid reply: %w", err)
+	}
+	return event, nil
+}
+
+// publishReply publishes the event replied by the sink to msg in binary
+// content mode. Replies to messages with a reply-to property are published to
+// the default exchange with it as routing key, and the correlation-id of msg.
+func (a *Adapter) publishReply(ch wabbit.Channel, msg wabbit.Delivery, reply *cloudevents.Event) error {
+	exchange, routingKey := a.config.Reply.Exchange, a.config.Reply.RoutingKey
+	publishing := BinaryMessage(reply)
+	if d, ok := msg.(*amqp.Delivery); ok && d.Delivery != nil {
+		if d.Delivery.ReplyTo != "" {
+			exchange, routingKey = "", d.Delivery.ReplyTo
+		}
+		publishing.CorrelationId = d.Delivery.CorrelationId
+	}
+
+	if c, ok := ch.(*amqp.Channel); ok {
+		return c.Channel.Publish(exchange, routingKey, false, false, publishing)
+	}
+	// Other channels don't support every message property.
+	return ch.Publish(exchange, routingKey, publishing.Body, wabbit.Option{
+		"headers":      publishing.Headers,
+		"contentType":  publishing.ContentType,
+		"messageId":    publishing.MessageId,
+		"deliveryMode": publishing.DeliveryMode,
+	})
+}
+
+// handleReply publishes the event replied by the sink to msg. Replies that
+// can't be published are sent to the dead letter sink, if any, and otherwise
+// dropped.
+func (a *Adapter) handleReply(ch wabbit.Channel, msg wabbit.Delivery, reply *cloudevents.Event) {
+	err := a.publishReply(ch, msg, reply)
+	if err == nil {
+		return
+	}
+	logger := a.logger.With(zap.String("id", reply.ID()))
+	if a.config.DeadLetterSink == "" {
+		logger.Error("Publishing the reply failed, dropping it", zap.Error(err))
+		return
+	}
+	logger.Error("Publishing the reply failed, sending it to the dead letter sink", zap.Error(err))
+	if err := a.deadLetter(reply); err != nil {
+		logger.Error("Sending reply to dead letter sink failed, dropping it", zap.Error(err))
+	}
+}
+
+// BinaryMessage returns the persistent message carrying the event in binary
+// content mode, its attributes being application properties prefixed with
+// "cloudEvents_", as consumed by the adapter.
+func BinaryMessage(event *cloudevents.Event) origamqp.Publishing {
+	// PrefixedName lower-cases the prefix, so names are prefixed here.
+	prefix := binaryVersions[0].Prefix()
+	headers := origamqp.Table{}
+	if version := binaryVersions[0].Version(event.SpecVersion()); version != nil {
+		for _, attr := range version.Attributes() {
+			if attr.Kind() == spec.DataContentType {
+				continue
+			}
+			if v := attr.Get(event.Context); v != nil {
+				if s, err := types.Format(v); err == nil && s != "" {
+					headers[prefix+attr.Name()] = s
+				}
+			}
+		}
+	}
+	for name, v := range event.Extensions() {
+		if s, err := types.Format(v); err == nil {
+			headers[prefix+name] = s
+		}
+	}
+
+	contentType := event.DataContentType()
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	return origamqp.Publishing{
+		Headers:      headers,
+		ContentType:  contentType,
+		DeliveryMode: origamqp.Persistent,
+		MessageId:    event.ID(),
+		Body:         event.Data(),
+	}
+}
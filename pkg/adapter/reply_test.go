@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+func TestReplyConfig_Decode(t *testing.T) {
+	var c replyConfig
+	if err := c.Decode(`{"exchange":"replies","routingKey":"orders"}`); err != nil {
+		t.Fatal(err)
+	}
+	want := &sourcesv1alpha1.RabbitmqSourceReplySpec{Exchange: "replies", RoutingKey: "orders"}
+	if diff := cmp.Diff(want, c.RabbitmqSourceReplySpec); diff != "" {
+		t.Errorf("unexpected reply spec (-want, +got) = %v", diff)
+	}
+}
+
+func TestAdapter_Reply(t *testing.T) {
+	url := "amqp://localhost:5672/reply"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ch.Close()
+
+	replying := true
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !replying {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("ce-specversion", "1.0")
+		w.Header().Set("ce-id", "reply-id")
+		w.Header().Set("ce-source", "/replier")
+		w.Header().Set("ce-type", "com.example.reply")
+		w.Header().Set("ce-team", "payments")
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("pong"))
+	}))
+	defer sink.Close()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sink.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, _ := source.NewStatsReporter()
+
+	a := &Adapter{
+		config: &adapterConfig{
+			Topic: "topic",
+			Reply: replyConfig{&sourcesv1alpha1.RabbitmqSourceReplySpec{RoutingKey: "replies"}},
+		},
+		context:           context.TODO(),
+		httpMessageSender: s,
+		logger:            zap.NewNop(),
+		reporter:          statsReporter,
+	}
+
+	event, err := a.wrapMessage(&server.Delivery{}, "text/plain", []byte("ping"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		msg   wabbit.Delivery
+		queue string
+	}{{
+		name:  "routing key of the source",
+		msg:   &server.Delivery{},
+		queue: "replies",
+	}, {
+		name: "reply-to of the message",
+		msg: &amqp.Delivery{Delivery: &origamqp.Delivery{
+			ReplyTo:       "rpc",
+			CorrelationId: "42",
+		}},
+		queue: "rpc",
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ch.QueueDeclare(tc.queue, wabbit.Option{}); err != nil {
+				t.Fatal(err)
+			}
+			msgs, err := ch.Consume(tc.queue, "", wabbit.Option{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reply, err := a.postMessage(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reply == nil {
+				t.Fatal("Expected the reply of the sink")
+			}
+			if err := a.publishReply(ch, tc.msg, reply); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case got := <-msgs:
+				if string(got.Body()) != "pong" {
+					t.Errorf("Expected body %q, but got %q", "pong", got.Body())
+				}
+				if got.MessageId() != "reply-id" {
+					t.Errorf("Expected message id %q, but got %q", "reply-id", got.MessageId())
+				}
+				replied, err := messageToEvent(got.Headers(), "text/plain", got.Body())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if replied.Type() != "com.example.reply" || replied.Source() != "/replier" || replied.Extensions()["team"] != "payments" {
+					t.Errorf("Unexpected reply event %v", replied)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the reply")
+			}
+		})
+	}
+
+	replying = false
+	if reply, err := a.postMessage(event); err != nil || reply != nil {
+		t.Errorf("Expected no reply, but got %v, %v", reply, err)
+	}
+
+	replying = true
+	a.config.Reply = replyConfig{}
+	if reply, err := a.postMessage(event); err != nil || reply != nil {
+		t.Errorf("Expected the reply to be dropped, but got %v, %v", reply, err)
+	}
+}
+
+func TestAdapter_ReplyPublishingFails(t *testing.T) {
+	url := "amqp://localhost:5672/reply-fails"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ch.Close()
+
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("ce-specversion", "1.0")
+		w.Header().Set("ce-id", "reply-id")
+		w.Header().Set("ce-source", "/replier")
+		w.Header().Set("ce-type", "com.example.reply")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer sink.Close()
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sink.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, _ := source.NewStatsReporter()
+
+	for _, withDeadLetterSink := range []bool{false, true} {
+		dls := &fakeHandler{handler: sinkAccepted}
+		dlsServer := httptest.NewServer(dls)
+		defer dlsServer.Close()
+
+		a := &Adapter{
+			config: &adapterConfig{
+				Topic: "topic",
+				// The exchange doesn't exist, failing the publishing.
+				Reply: replyConfig{&sourcesv1alpha1.RabbitmqSourceReplySpec{Exchange: "missing"}},
+			},
+			context:           context.TODO(),
+			httpMessageSender: s,
+			logger:            zap.NewNop(),
+			reporter:          statsReporter,
+		}
+		if withDeadLetterSink {
+			a.config.DeadLetterSink = dlsServer.URL
+		}
+
+		var acks []string
+		msg := &ackedDelivery{Delivery: &server.Delivery{}, tag: 1, acks: &acks}
+		a.processMessage(ch, msg, newAcker(a.config.Consumer), nil)
+
+		// The sink processed the event, so its message is acknowledged.
+		if diff := cmp.Diff([]string{"1/false"}, acks); diff != "" {
+			t.Errorf("Unexpected acknowledgements with dead letter sink %t (-want, +got) = %v", withDeadLetterSink, diff)
+		}
+		if got := dls.header.Get("ce-id"); withDeadLetterSink && got != "reply-id" {
+			t.Errorf("Expected the reply to be sent to the dead letter sink, but got id %q", got)
+		} else if !withDeadLetterSink && dls.body != nil {
+			t.Error("Expected no dead letter sink to be called")
+		}
+	}
+}
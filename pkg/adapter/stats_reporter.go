@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	// reconnectCountM is a counter which records the number of times the
+	// adapter reconnected to RabbitMQ.
+	reconnectCountM = stats.Int64(
+		"rabbitmq_reconnect_count",
+		"Number of reconnections to RabbitMQ",
+		stats.UnitDimensionless,
+	)
+
+	namespaceKey           = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+	sourceNameKey          = tag.MustNewKey(eventingmetrics.LabelName)
+	sourceResourceGroupKey = tag.MustNewKey(eventingmetrics.LabelResourceGroup)
+)
+
+func init() {
+	if err := view.Register(
+		&view.View{
+			Description: reconnectCountM.Description(),
+			Measure:     reconnectCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{namespaceKey, sourceNameKey, sourceResourceGroupKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// reportReconnect records a reconnection to RabbitMQ.
+func (a *Adapter) reportReconnect() {
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(namespaceKey, a.config.Namespace),
+		tag.Insert(sourceNameKey, a.config.Name),
+		tag.Insert(sourceResourceGroupKey, resourceGroup))
+	if err != nil {
+		a.logger.Warn("Failed to tag the reconnect count", zap.Error(err))
+		return
+	}
+	metrics.Record(ctx, reconnectCountM.M(1))
+}
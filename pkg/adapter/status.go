@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"sync/atomic"
+)
+
+// StatusPath is the path of the endpoint reporting the state of the
+// connection of the adapter to RabbitMQ, which the controller reflects in the
+// status of the source.
+const StatusPath = "/status"
+
+// Status is the state of the connection of the adapter to RabbitMQ.
+type Status struct {
+	// Connected is whether the adapter is connected to RabbitMQ.
+	Connected bool `json:"connected"`
+	// Error is why the adapter isn't connected, if it isn't.
+	Error string `json:"error,omitempty"`
+	// TopologyError is why the topology couldn't be declared, if it
+	// couldn't.
+	TopologyError string `json:"topologyError,omitempty"`
+	// Queue is the name of the queue consumed, including the names
+	// generated by the server.
+	Queue string `json:"queue,omitempty"`
+	// MessagesUnacknowledged is the number of messages delivered to the
+	// adapter and not yet acknowledged.
+	MessagesUnacknowledged int32 `json:"messagesUnacknowledged"`
+}
+
+func (a *Adapter) setStatus(status Status) {
+	a.statusLock.Lock()
+	defer a.statusLock.Unlock()
+	a.status = status
+}
+
+func (a *Adapter) getStatus() Status {
+	a.statusLock.Lock()
+	status := a.status
+	a.statusLock.Unlock()
+	status.MessagesUnacknowledged = atomic.LoadInt32(&a.unacked)
+	return status
+}
+
+// notConnected records that the adapter isn't connected to RabbitMQ because
+// of err, which it returns.
+func (a *Adapter) notConnected(err error) error {
+	a.setStatus(Status{Error: err.Error()})
+	return err
+}
+
+// statusHandler serves the status of the adapter as JSON.
+func (a *Adapter) statusHandler(w nethttp.ResponseWriter, _ *nethttp.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.getStatus()); err != nil {
+		a.logger.Error("Failed to write the status")
+	}
+}
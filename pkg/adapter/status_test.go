@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"go.uber.org/zap"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+)
+
+func TestAdapter_StatusHandler(t *testing.T) {
+	url := "amqp://localhost:5672/status"
+	a := &Adapter{
+		config: &adapterConfig{
+			Brokers: url,
+			ExchangeConfig: ExchangeConfig{
+				Name:   "exchange",
+				TypeOf: "direct",
+			},
+			QueueConfig: QueueConfig{
+				Name:       "queue",
+				RoutingKey: "key",
+			},
+		},
+		logger:     zap.NewNop(),
+		dialerFunc: dialer.TestDialer,
+	}
+
+	getStatus := func() Status {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		a.statusHandler(rec, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, but got %d", http.StatusOK, rec.Code)
+		}
+		var status Status
+		if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+			t.Fatal(err)
+		}
+		return status
+	}
+
+	stopCh := make(chan struct{})
+	if _, err := a.consume(stopCh); err == nil {
+		t.Fatal("Expected consuming without a server to fail")
+	}
+	if status := getStatus(); status.Connected || status.Error == "" {
+		t.Errorf("Expected the adapter to report the connection error, but got %+v", status)
+	}
+
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.consume(stopCh)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for !a.isReady() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the adapter to consume")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := Status{Connected: true, Queue: "queue"}
+	if status := getStatus(); status != want {
+		t.Errorf("Expected status %+v, but got %+v", want, status)
+	}
+	close(stopCh)
+	<-done
+}
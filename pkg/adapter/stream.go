@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+const (
+	queueTypeArg    = "x-queue-type"
+	streamOffsetArg = "x-stream-offset"
+
+	// RabbitMQ requires a prefetch count to consume from streams.
+	defaultStreamPrefetchCount = 100
+
+	// OffsetConfigMapKey is the key of the offset of the last processed
+	// message in the offset ConfigMap.
+	OffsetConfigMapKey = "offset"
+
+	// Interval between two writes of the offset of the last processed message.
+	offsetStoreInterval = 5 * time.Second
+)
+
+// offsetStore persists the offset of the last message processed from a
+// stream queue.
+type offsetStore interface {
+	// load returns the stored offset, if any.
+	load(ctx context.Context) (offset int64, ok bool, err error)
+	store(ctx context.Context, offset int64) error
+}
+
+func (a *Adapter) isStream() bool {
+	return a.config.QueueConfig.Type == sourcesv1alpha1.QueueTypeStream
+}
+
+// streamOffset returns the x-stream-offset consumer argument: the offset
+// following the last processed message, if any, or the configured offset.
+func (a *Adapter) streamOffset() (interface{}, error) {
+	if offset := atomic.LoadInt64(&a.offset); offset >= 0 {
+		return offset + 1, nil
+	}
+	return parseStreamOffset(a.config.QueueConfig.StreamOffset)
+}
+
+// parseStreamOffset converts the offset of the source into the value of the
+// x-stream-offset argument.
+func parseStreamOffset(offset string) (interface{}, error) {
+	switch offset {
+	case "":
+		return "next", nil
+	case "first", "last", "next":
+		return offset, nil
+	}
+	if i, err := strconv.ParseInt(offset, 10, 64); err == nil {
+		if i < 0 {
+			return nil, fmt.Errorf("invalid stream offset %q: must not be negative", offset)
+		}
+		return i, nil
+	}
+	if t, err := time.Parse(time.RFC3339, offset); err == nil {
+		return t, nil
+	}
+	return nil, fmt.Errorf("invalid stream offset %q: expected first, last, next, an offset or an RFC 3339 timestamp", offset)
+}
+
+// processedOffset records the offset of a message processed from a stream.
+func (a *Adapter) processedOffset(msg wabbit.Delivery) {
+	if offset, ok := msg.Headers()[streamOffsetArg].(int64); ok {
+		atomic.StoreInt64(&a.offset, offset)
+	}
+}
+
+// loadOffset resumes consuming after the stored offset, if any.
+func (a *Adapter) loadOffset() error {
+	offset, ok, err := a.offsets.load(a.context)
+	if err != nil {
+		return fmt.Errorf("failed to load the stream offset: %w", err)
+	}
+	if ok {
+		a.logger.Info("Resuming from the stored stream offset", zap.Int64("offset", offset))
+		atomic.StoreInt64(&a.offset, offset)
+		a.storedOffset = offset
+	}
+	return nil
+}
+
+// storeOffsets periodically stores the offset of the last processed message
+// until stopCh is closed, storing it a last time then.
+func (a *Adapter) storeOffsets(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(offsetStoreInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.storeOffset()
+		case <-stopCh:
+			a.storeOffset()
+			return
+		}
+	}
+}
+
+func (a *Adapter) storeOffset() {
+	offset := atomic.LoadInt64(&a.offset)
+	if offset < 0 || offset == a.storedOffset {
+		return
+	}
+	// The adapter context is done when stopping, while storing must complete.
+	ctx, cancel := context.WithTimeout(context.Background(), offsetStoreInterval)
+	defer cancel()
+	if err := a.offsets.store(ctx, offset); err != nil {
+		a.logger.Error("Failed to store the stream offset", zap.Int64("offset", offset), zap.Error(err))
+		return
+	}
+	a.storedOffset = offset
+}
+
+// configMapOffsetStore stores the offset in a ConfigMap created by the
+// controller.
+type configMapOffsetStore struct {
+	namespace string
+	name      string
+	client    corev1client.ConfigMapsGetter
+}
+
+func (s *configMapOffsetStore) configMaps() (corev1client.ConfigMapInterface, error) {
+	if s.client == nil {
+		cfg, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, err
+		}
+		kc, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		s.client = kc.CoreV1()
+	}
+	return s.client.ConfigMaps(s.namespace), nil
+}
+
+func (s *configMapOffsetStore) load(ctx context.Context) (int64, bool, error) {
+	configMaps, err := s.configMaps()
+	if err != nil {
+		return 0, false, err
+	}
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return 0, false, err
+	}
+	val, ok := cm.Data[OffsetConfigMapKey]
+	if !ok || val == "" {
+		return 0, false, nil
+	}
+	offset, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid offset %q in ConfigMap %s/%s: %w", val, s.namespace, s.name, err)
+	}
+	return offset, true, nil
+}
+
+func (s *configMapOffsetStore) store(ctx context.Context, offset int64) error {
+	configMaps, err := s.configMaps()
+	if err != nil {
+		return err
+	}
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[OffsetConfigMapKey] = strconv.FormatInt(offset, 10)
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
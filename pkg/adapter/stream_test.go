@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"github.com/google/go-cmp/cmp"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/metrics/source"
+)
+
+func TestParseStreamOffset(t *testing.T) {
+	timestamp := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		offset  string
+		want    interface{}
+		wantErr bool
+	}{{
+		offset: "",
+		want:   "next",
+	}, {
+		offset: "first",
+		want:   "first",
+	}, {
+		offset: "last",
+		want:   "last",
+	}, {
+		offset: "next",
+		want:   "next",
+	}, {
+		offset: "42",
+		want:   int64(42),
+	}, {
+		offset: "2021-06-01T12:00:00Z",
+		want:   timestamp,
+	}, {
+		offset:  "-1",
+		wantErr: true,
+	}, {
+		offset:  "yesterday",
+		wantErr: true,
+	}} {
+		t.Run(tt.offset, func(t *testing.T) {
+			got, err := parseStreamOffset(tt.offset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error: %t, but got %v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unexpected offset (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestConfigMapOffsetStore(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "offset"},
+	})
+	s := &configMapOffsetStore{namespace: "ns", name: "offset", client: client.CoreV1()}
+
+	if _, ok, err := s.load(ctx); err != nil || ok {
+		t.Fatalf("Expected no stored offset, but got %t, %v", ok, err)
+	}
+	if err := s.store(ctx, 12); err != nil {
+		t.Fatal("Failed to store the offset:", err)
+	}
+	offset, ok, err := s.load(ctx)
+	if err != nil || !ok || offset != 12 {
+		t.Errorf("Expected stored offset 12, but got %d, %t, %v", offset, ok, err)
+	}
+
+	missing := &configMapOffsetStore{namespace: "ns", name: "missing", client: client.CoreV1()}
+	if _, _, err := missing.load(ctx); err == nil {
+		t.Error("Expected an error loading from a missing ConfigMap")
+	}
+}
+
+func TestAdapter_StreamOffset(t *testing.T) {
+	url := "amqp://localhost:5672/stream"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	received := make(chan struct{}, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		received <- struct{}{}
+	}))
+	defer sink.Close()
+
+	s, err := kncloudevents.NewHTTPMessageSenderWithTarget(sink.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsReporter, _ := source.NewStatsReporter()
+
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "offset"},
+		Data:       map[string]string{OffsetConfigMapKey: "4"},
+	})
+	a := &Adapter{
+		config: &adapterConfig{
+			Topic:   "topic",
+			Brokers: url,
+			ExchangeConfig: ExchangeConfig{
+				Name:   "exchange",
+				TypeOf: "direct",
+			},
+			QueueConfig: QueueConfig{
+				Name:       "queue",
+				RoutingKey: "key",
+				Durable:    true,
+				Type:       "stream",
+			},
+		},
+		context:           context.TODO(),
+		httpMessageSender: s,
+		logger:            zap.NewNop(),
+		reporter:          statsReporter,
+		dialerFunc:        dialer.TestDialer,
+		offsets:           &configMapOffsetStore{namespace: "ns", name: "offset", client: client.CoreV1()},
+		offset:            -1,
+		storedOffset:      -1,
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- a.start(stopCh)
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !a.isReady() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the adapter to consume")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if offset, err := a.streamOffset(); err != nil || offset != int64(5) {
+		t.Errorf("Expected to resume from offset 5, but got %v, %v", offset, err)
+	}
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ch.Publish("exchange", "key", []byte("hello"), wabbit.Option{
+		"headers": origamqp.Table{streamOffsetArg: int64(10)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the message")
+	}
+	for atomic.LoadInt64(&a.offset) != 10 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the message to be processed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Error("Expected adapter to stop without error, but got", err)
+	}
+
+	cm, err := client.CoreV1().ConfigMaps("ns").Get(context.Background(), "offset", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cm.Data[OffsetConfigMapKey]; got != "10" {
+		t.Errorf("Expected stored offset %q, but got %q", "10", got)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	origamqp "github.com/streadway/amqp"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+)
+
+const (
+	defaultHeartbeat = 10 * time.Second
+	defaultLocale    = "en_US"
+)
+
+// amqpConfig returns the configuration used to connect to RabbitMQ.
+func (a *Adapter) amqpConfig() (origamqp.Config, error) {
+	config := origamqp.Config{
+		Heartbeat: defaultHeartbeat,
+		Locale:    defaultLocale,
+	}
+	a.config.Connection.options().Apply(&config)
+	tlsConfig, err := a.config.TLS.Config()
+	if err != nil {
+		return config, err
+	}
+	config.TLSClientConfig = tlsConfig
+
+	config.SASL, err = dialer.SASL(a.config.SASLMechanism)
+	return config, err
+}
+
+// dial connects to RabbitMQ with the TLS and SASL configuration of the source.
+func (a *Adapter) dial(url string) (wabbit.Conn, error) {
+	config, err := a.amqpConfig()
+	if err != nil {
+		return nil, err
+	}
+	return dialer.ConfigDialer(config)(url)
+}
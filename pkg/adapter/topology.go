@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"fmt"
+
+	"github.com/NeowayLabs/wabbit"
+
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// existingQueue is a queue consumed without being declared, so nothing is
+// known about it but its name.
+type existingQueue string
+
+func (q existingQueue) Name() string {
+	return string(q)
+}
+
+func (q existingQueue) Messages() int {
+	return 0
+}
+
+func (q existingQueue) Consumers() int {
+	return 0
+}
+
+// existingTopology returns the queue to consume from when the topology isn't
+// declared by the adapter, checking first that the queue and the named
+// exchanges exist in the passive mode.
+func (a *Adapter) existingTopology(ch *wabbit.Channel) (*wabbit.Queue, error) {
+	var queue wabbit.Queue = existingQueue(a.config.QueueConfig.Name)
+	if a.config.TopologyMode != sourcesv1alpha1.TopologyModePassive {
+		return &queue, nil
+	}
+
+	exchanges := []ExchangeConfig{}
+	if a.config.ExchangeConfig.Name != "" {
+		exchanges = append(exchanges, a.config.ExchangeConfig)
+	}
+	for _, binding := range a.config.Bindings {
+		if binding.Exchange != "" {
+			exchanges = append(exchanges, ExchangeConfig{Name: binding.Exchange})
+		}
+	}
+	for _, exchange := range exchanges {
+		if err := (*ch).ExchangeDeclarePassive(exchange.Name, exchange.TypeOf, wabbit.Option{}); err != nil {
+			return nil, fmt.Errorf("exchange %q not found: %w", exchange.Name, err)
+		}
+	}
+
+	queue, err := (*ch).QueueDeclarePassive(a.config.QueueConfig.Name, wabbit.Option{})
+	if err != nil {
+		return nil, fmt.Errorf("queue %q not found: %w", a.config.QueueConfig.Name, err)
+	}
+	return &queue, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"go.uber.org/zap"
+
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+func TestAdapter_StartAmqpClientWithExistingTopology(t *testing.T) {
+	url := "amqp://localhost:5672/topology"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ch.ExchangeDeclare("exchange", "direct", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ch.QueueDeclare("queue", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		mode     string
+		exchange string
+		queue    string
+		wantErr  bool
+	}{
+		"passive": {
+			mode:     sourcesv1alpha1.TopologyModePassive,
+			exchange: "exchange",
+			queue:    "queue",
+		},
+		"passive without exchange": {
+			mode:  sourcesv1alpha1.TopologyModePassive,
+			queue: "queue",
+		},
+		"passive missing queue": {
+			mode:     sourcesv1alpha1.TopologyModePassive,
+			exchange: "exchange",
+			queue:    "missing",
+			wantErr:  true,
+		},
+		"passive missing exchange": {
+			mode:     sourcesv1alpha1.TopologyModePassive,
+			exchange: "missing",
+			queue:    "queue",
+			wantErr:  true,
+		},
+		"none missing queue": {
+			mode:     sourcesv1alpha1.TopologyModeNone,
+			exchange: "missing",
+			queue:    "missing",
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			a := &Adapter{
+				config: &adapterConfig{
+					Topic:        "topic",
+					TopologyMode: tc.mode,
+					ExchangeConfig: ExchangeConfig{
+						Name:   tc.exchange,
+						TypeOf: "direct",
+					},
+					QueueConfig: QueueConfig{
+						Name:       tc.queue,
+						RoutingKey: "key",
+					},
+				},
+				logger: zap.NewNop(),
+			}
+			queue, err := a.StartAmqpClient(&ch)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Expected the topology check to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Failed to check the topology:", err)
+			}
+			if got := (*queue).Name(); got != tc.queue {
+				t.Errorf("Unexpected queue %q, want %q", got, tc.queue)
+			}
+		})
+	}
+
+	// Nothing is bound in the passive and none modes.
+	if err := ch.Publish("exchange", "key", []byte("message"), nil); err == nil {
+		t.Error("Expected the routing key not to be bound")
+	}
+}
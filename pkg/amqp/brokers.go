@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	origamqp "github.com/streadway/amqp"
+)
+
+// Brokers describes how to reach the nodes of RabbitMQ.
+type Brokers struct {
+	// URI of the brokers, whose scheme is optional.
+	URI string
+	// Nodes are the addresses, host:port, replacing the host of URI.
+	Nodes []string
+	// User and Password replace the credentials of URI when both are set.
+	User     string
+	Password string
+	// Vhost is appended to the path of URI when set, see JoinVhost.
+	Vhost string
+	// TLS defaults the scheme to amqps.
+	TLS bool
+}
+
+// URLs returns the URL of each node, or the URL of the brokers if there are
+// no nodes.
+func (b Brokers) URLs() ([]string, error) {
+	scheme := "amqp"
+	if b.TLS {
+		scheme = "amqps"
+	}
+	raw := b.URI
+	if !strings.Contains(raw, "://") {
+		raw = scheme + "://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid brokers URI: %w", err)
+	}
+	if b.User != "" && b.Password != "" {
+		u.User = url.UserPassword(b.User, b.Password)
+	}
+	if err := JoinVhost(u, b.Vhost); err != nil {
+		return nil, err
+	}
+
+	if len(b.Nodes) == 0 {
+		if u.Host == "" {
+			return nil, errors.New("missing host")
+		}
+		return []string{u.String()}, nil
+	}
+	urls := make([]string, 0, len(b.Nodes))
+	for _, node := range b.Nodes {
+		u.Host = node
+		urls = append(urls, u.String())
+	}
+	return urls, nil
+}
+
+// JoinVhost appends vhost, as written in the URI of RabbitMQ, to the path of
+// u, separated by a slash unless either already has one: with no path, both
+// foo and /foo are the vhost foo, while //foo is the vhost /foo.
+func JoinVhost(u *url.URL, vhost string) error {
+	if vhost == "" {
+		return nil
+	}
+	path := u.EscapedPath()
+	if !strings.HasSuffix(path, "/") && !strings.HasPrefix(vhost, "/") {
+		path += "/"
+	}
+	path += vhost
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return fmt.Errorf("invalid vhost: %w", err)
+	}
+	u.Path, u.RawPath = unescaped, path
+	return nil
+}
+
+// ConnectionOptions are the options of connections to RabbitMQ. The zero
+// values leave the defaults of the client.
+type ConnectionOptions struct {
+	Heartbeat  time.Duration
+	Timeout    time.Duration
+	ChannelMax int
+	// Name of the connection, shown by the management UI.
+	Name string
+}
+
+// Apply sets the options in the client configuration.
+func (o ConnectionOptions) Apply(config *origamqp.Config) {
+	if o.Heartbeat != 0 {
+		config.Heartbeat = o.Heartbeat
+	}
+	if o.Timeout != 0 {
+		config.Dial = origamqp.DefaultDial(o.Timeout)
+	}
+	if o.ChannelMax != 0 {
+		config.ChannelMax = o.ChannelMax
+	}
+	if o.Name != "" {
+		if config.Properties == nil {
+			config.Properties = origamqp.Table{}
+		}
+		config.Properties["connection_name"] = o.Name
+	}
+}
+
+var (
+	randomMu sync.Mutex
+	random   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Shuffle returns the URLs in a random order, for the connections of several
+// clients to be spread over the nodes.
+func Shuffle(urls []string) []string {
+	shuffled := append([]string(nil), urls...)
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// DialNodes connects to the first of the URLs accepting the connection,
+// returning the URL connected to.
+func DialNodes(dial DialerFunc, urls []string) (wabbit.Conn, string, error) {
+	var errs []string
+	for _, u := range urls {
+		conn, err := dial(u)
+		if err == nil {
+			return conn, u, nil
+		}
+		if len(urls) == 1 {
+			return nil, "", err
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", Redact(u), err))
+	}
+	if len(errs) == 0 {
+		return nil, "", errors.New("no node to connect to")
+	}
+	return nil, "", fmt.Errorf("failed to connect to any node: %s", strings.Join(errs, "; "))
+}
+
+// Redact returns the URL without its password, to be logged.
+func Redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	origamqp "github.com/streadway/amqp"
+)
+
+const (
+	SASLMechanismPlain    = "PLAIN"
+	SASLMechanismExternal = "EXTERNAL"
+)
+
+// ExternalAuth is the EXTERNAL SASL mechanism, authenticating with the TLS
+// client certificate.
+type ExternalAuth struct{}
+
+func (ExternalAuth) Mechanism() string { return SASLMechanismExternal }
+func (ExternalAuth) Response() string  { return "" }
+
+// SASL returns the authentication of the SASL mechanism, nil for PLAIN to
+// use the credentials of the URL.
+func SASL(mechanism string) ([]origamqp.Authentication, error) {
+	switch strings.ToUpper(mechanism) {
+	case "", SASLMechanismPlain:
+		return nil, nil
+	case SASLMechanismExternal:
+		return []origamqp.Authentication{ExternalAuth{}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", mechanism)
+	}
+}
+
+// ConfigDialer returns a DialerFunc connecting with the given configuration.
+func ConfigDialer(config origamqp.Config) DialerFunc {
+	return func(rabbitURL string) (wabbit.Conn, error) {
+		conn, err := amqp.DialConfig(rabbitURL, config)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeowayLabs/wabbit"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+)
+
+// BinaryToEvent returns the CloudEvent carried in binary content mode by a
+// message, its attributes being the application properties in headers
+// prefixed as in one of versions. nil is returned if none of the headers is
+// a specversion. The content type of the event is left unset if contentType
+// is empty.
+func BinaryToEvent(versions []*spec.Versions, headers wabbit.Option, contentType string, body []byte) (*cloudevents.Event, error) {
+	for _, vs := range versions {
+		var version spec.Version
+		for key, val := range headers {
+			if strings.EqualFold(key, vs.PrefixedSpecVersionName()) {
+				sv, _ := val.(string)
+				if version = vs.Version(sv); version == nil {
+					return nil, fmt.Errorf("invalid binary CloudEvent: unknown specversion %q", sv)
+				}
+				break
+			}
+		}
+		if version == nil {
+			continue
+		}
+
+		event := cloudevents.Event{Context: version.NewContext()}
+		for key, val := range headers {
+			if err := version.SetAttribute(event.Context, key, val); err != nil {
+				return nil, fmt.Errorf("invalid binary CloudEvent attribute %q: %w", key, err)
+			}
+		}
+		if contentType != "" {
+			event.SetDataContentType(contentType)
+		}
+		if len(body) > 0 {
+			if err := event.SetData(event.DataContentType(), body); err != nil {
+				return nil, fmt.Errorf("invalid binary CloudEvent data: %w", err)
+			}
+		}
+		if err := event.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid binary CloudEvent: %w", err)
+		}
+		return &event, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/wabbit"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBinaryToEvent(t *testing.T) {
+	versions := []*spec.Versions{spec.WithPrefix("cloudEvents_"), spec.WithPrefix("ce-")}
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	event.SetType("type")
+	event.SetSource("source")
+	event.SetExtension("traceparent", "00-1-2-01")
+	if err := event.SetData("text/plain", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		headers     wabbit.Option
+		contentType string
+		want        *cloudevents.Event
+		wantErr     bool
+	}{{
+		name: "first prefix",
+		headers: wabbit.Option{
+			"cloudEvents_specversion": "1.0",
+			"cloudEvents_id":          "1234",
+			"cloudEvents_type":        "type",
+			"cloudEvents_source":      "source",
+			"cloudEvents_traceparent": "00-1-2-01",
+		},
+		contentType: "text/plain",
+		want:        &event,
+	}, {
+		name: "other prefix, case insensitive",
+		headers: wabbit.Option{
+			"Ce-Specversion": "1.0",
+			"ce-id":          "1234",
+			"ce-type":        "type",
+			"ce-source":      "source",
+			"ce-traceparent": "00-1-2-01",
+		},
+		contentType: "text/plain",
+		want:        &event,
+	}, {
+		name:    "no specversion",
+		headers: wabbit.Option{"cloudEvents_id": "1234"},
+	}, {
+		name:    "unknown specversion",
+		headers: wabbit.Option{"cloudEvents_specversion": "0.1"},
+		wantErr: true,
+	}, {
+		name: "missing attribute",
+		headers: wabbit.Option{
+			"cloudEvents_specversion": "1.0",
+			"cloudEvents_id":          "1234",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BinaryToEvent(versions, tt.headers, tt.contentType, []byte("data"))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got event %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error("unexpected event (-want, +got) =", diff)
+			}
+		})
+	}
+}
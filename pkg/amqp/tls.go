@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// TLSConfig is the TLS configuration of the connections to RabbitMQ, read
+// from the environment.
+type TLSConfig struct {
+	Enabled    bool   `envconfig:"RABBITMQ_TLS_ENABLED" required:"false"`
+	CAFile     string `envconfig:"RABBITMQ_TLS_CA_FILE" required:"false"`
+	CertFile   string `envconfig:"RABBITMQ_TLS_CERT_FILE" required:"false"`
+	KeyFile    string `envconfig:"RABBITMQ_TLS_KEY_FILE" required:"false"`
+	ServerName string `envconfig:"RABBITMQ_TLS_SERVER_NAME" required:"false"`
+}
+
+// Config loads the certificates of the TLS configuration, returning nil if
+// TLS isn't enabled. Files are read on every call so that rotated
+// certificates are picked up.
+func (c *TLSConfig) Config() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	config := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in the CA bundle")
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package amqp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate and its key to dir.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rabbitmq"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	for _, tt := range []struct {
+		name      string
+		config    TLSConfig
+		wantNil   bool
+		wantCAs   bool
+		wantCerts int
+		wantErr   bool
+	}{{
+		name:    "disabled",
+		config:  TLSConfig{CAFile: certFile},
+		wantNil: true,
+	}, {
+		name:   "system CAs",
+		config: TLSConfig{Enabled: true, ServerName: "rabbitmq"},
+	}, {
+		name:    "CA bundle",
+		config:  TLSConfig{Enabled: true, CAFile: certFile},
+		wantCAs: true,
+	}, {
+		name:      "client certificate",
+		config:    TLSConfig{Enabled: true, CAFile: certFile, CertFile: certFile, KeyFile: keyFile},
+		wantCAs:   true,
+		wantCerts: 1,
+	}, {
+		name:    "missing CA bundle",
+		config:  TLSConfig{Enabled: true, CAFile: filepath.Join(dir, "missing")},
+		wantErr: true,
+	}, {
+		name:    "invalid CA bundle",
+		config:  TLSConfig{Enabled: true, CAFile: keyFile},
+		wantErr: true,
+	}, {
+		name:    "missing client key",
+		config:  TLSConfig{Enabled: true, CertFile: certFile},
+		wantErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.Config()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error: %t, but got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != tt.wantNil {
+				t.Fatalf("Expected nil config: %t, but got %v", tt.wantNil, got)
+			}
+			if got == nil {
+				return
+			}
+			if got.ServerName != tt.config.ServerName {
+				t.Errorf("Expected server name %q, but got %q", tt.config.ServerName, got.ServerName)
+			}
+			if (got.RootCAs != nil) != tt.wantCAs {
+				t.Errorf("Expected CA pool: %t, but got %v", tt.wantCAs, got.RootCAs)
+			}
+			if len(got.Certificates) != tt.wantCerts {
+				t.Errorf("Expected %d client certificates, but got %d", tt.wantCerts, len(got.Certificates))
+			}
+		})
+	}
+}
@@ -46,7 +46,21 @@ type Rabbit struct {
 var _ duck.Implementable = (*Rabbit)(nil)
 
 // RabbiSpec
-type RabbitSpec struct{}
+type RabbitSpec struct {
+	// TLS is the TLS configuration of the RabbitmqCluster.
+	TLS *RabbitTLSSpec `json:"tls,omitempty"`
+}
+
+// RabbitTLSSpec is the subset of the TLS configuration of a RabbitmqCluster
+// needed to connect to it.
+type RabbitTLSSpec struct {
+	// SecretName is the kubernetes.io/tls Secret holding the server certificate.
+	SecretName string `json:"secretName,omitempty"`
+	// CASecretName is the Secret holding the CA certificate in its ca.crt key.
+	CASecretName string `json:"caSecretName,omitempty"`
+	// DisableNonTLSListeners is true if the cluster only accepts TLS connections.
+	DisableNonTLSListeners bool `json:"disableNonTLSListeners,omitempty"`
+}
 
 // RabbitStatus.
 type RabbitStatus struct {
@@ -29,7 +29,7 @@ func (in *Rabbit) DeepCopyInto(out *Rabbit) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -137,6 +137,11 @@ func (in *RabbitReference) DeepCopy() *RabbitReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitSpec) DeepCopyInto(out *RabbitSpec) {
 	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RabbitTLSSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -177,3 +182,19 @@ func (in *RabbitStatus) DeepCopy() *RabbitStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitTLSSpec) DeepCopyInto(out *RabbitTLSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitTLSSpec.
+func (in *RabbitTLSSpec) DeepCopy() *RabbitTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
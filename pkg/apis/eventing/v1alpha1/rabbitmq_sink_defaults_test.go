@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRabbitmqSinkSetDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		spec RabbitmqSinkSpec
+		want RabbitmqSinkSpec
+	}{
+		"empty": {
+			want: RabbitmqSinkSpec{ContentMode: ContentModeBinary},
+		},
+		"set": {
+			spec: RabbitmqSinkSpec{ContentMode: ContentModeStructured},
+			want: RabbitmqSinkSpec{ContentMode: ContentModeStructured},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sink := &RabbitmqSink{Spec: tc.spec}
+			sink.SetDefaults(context.TODO())
+			if diff := cmp.Diff(tc.want, sink.Spec); diff != "" {
+				t.Errorf("Unexpected defaults (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing/pkg/apis/duck"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	RabbitmqSinkConditionReady = apis.ConditionReady
+
+	// RabbitmqSinkConditionConnectionReady is true when the connection to
+	// RabbitMQ is resolved into the Secret read by the publisher.
+	RabbitmqSinkConditionConnectionReady apis.ConditionType = "ConnectionReady"
+
+	RabbitmqSinkConditionDeployed apis.ConditionType = "Deployed"
+
+	RabbitmqSinkConditionAddressable apis.ConditionType = "Addressable"
+)
+
+var RabbitmqSinkCondSet = apis.NewLivingConditionSet(
+	RabbitmqSinkConditionConnectionReady,
+	RabbitmqSinkConditionDeployed,
+	RabbitmqSinkConditionAddressable)
+
+func (s *RabbitmqSinkStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return RabbitmqSinkCondSet.Manage(s).GetCondition(t)
+}
+
+func (s *RabbitmqSinkStatus) GetTopLevelCondition() *apis.Condition {
+	return RabbitmqSinkCondSet.Manage(s).GetTopLevelCondition()
+}
+
+func (in *RabbitmqSink) GetStatus() *duckv1.Status {
+	return &in.Status.Status
+}
+
+func (in *RabbitmqSink) GetConditionSet() apis.ConditionSet {
+	return RabbitmqSinkCondSet
+}
+
+func (s *RabbitmqSinkStatus) IsReady() bool {
+	return RabbitmqSinkCondSet.Manage(s).IsHappy()
+}
+
+func (s *RabbitmqSinkStatus) InitializeConditions() {
+	RabbitmqSinkCondSet.Manage(s).InitializeConditions()
+}
+
+func (s *RabbitmqSinkStatus) MarkConnectionReady() {
+	RabbitmqSinkCondSet.Manage(s).MarkTrue(RabbitmqSinkConditionConnectionReady)
+}
+
+func (s *RabbitmqSinkStatus) MarkConnectionNotReady(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqSinkCondSet.Manage(s).MarkFalse(RabbitmqSinkConditionConnectionReady, reason, messageFormat, messageA...)
+}
+
+func (s *RabbitmqSinkStatus) MarkDeployed(d *appsv1.Deployment) {
+	if duck.DeploymentIsAvailable(&d.Status, false) {
+		RabbitmqSinkCondSet.Manage(s).MarkTrue(RabbitmqSinkConditionDeployed)
+	} else {
+		RabbitmqSinkCondSet.Manage(s).MarkFalse(RabbitmqSinkConditionDeployed, "DeploymentUnavailable", "The Deployment '%s' is unavailable.", d.Name)
+	}
+}
+
+func (s *RabbitmqSinkStatus) MarkNotDeployed(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqSinkCondSet.Manage(s).MarkFalse(RabbitmqSinkConditionDeployed, reason, messageFormat, messageA...)
+}
+
+// MarkAddress sets the address of the sink, which is addressable once the
+// endpoints of its Service are available.
+func (s *RabbitmqSinkStatus) MarkAddress(url *apis.URL, ep *corev1.Endpoints) {
+	s.Address = &duckv1.Addressable{URL: url}
+	if duck.EndpointsAreAvailable(ep) {
+		RabbitmqSinkCondSet.Manage(s).MarkTrue(RabbitmqSinkConditionAddressable)
+	} else {
+		RabbitmqSinkCondSet.Manage(s).MarkFalse(RabbitmqSinkConditionAddressable, "EndpointsUnavailable", "Endpoints %q are unavailable.", ep.Name)
+	}
+}
+
+func (s *RabbitmqSinkStatus) MarkNoAddress(reason, messageFormat string, messageA ...interface{}) {
+	s.Address = nil
+	RabbitmqSinkCondSet.Manage(s).MarkFalse(RabbitmqSinkConditionAddressable, reason, messageFormat, messageA...)
+}
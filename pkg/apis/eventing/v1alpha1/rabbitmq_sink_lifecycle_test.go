@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var (
+	availableDeployment = &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	availableEndpoints = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "127.0.0.1"}},
+		}},
+	}
+
+	unavailableEndpoints = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink"},
+	}
+)
+
+var _ = duck.VerifyType(&RabbitmqSink{}, &duckv1.Conditions{})
+var _ = duck.VerifyType(&RabbitmqSink{}, &duckv1.Addressable{})
+
+func TestRabbitmqSinkStatusGetCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         *RabbitmqSinkStatus
+		condQuery apis.ConditionType
+		want      *apis.Condition
+	}{{
+		name:      "uninitialized",
+		s:         &RabbitmqSinkStatus{},
+		condQuery: RabbitmqSinkConditionReady,
+		want:      nil,
+	}, {
+		name: "initialized",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:   RabbitmqSinkConditionReady,
+			Status: corev1.ConditionUnknown,
+		},
+	}, {
+		name: "mark connection ready, deployed and addressable",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			s.MarkConnectionReady()
+			s.MarkDeployed(availableDeployment)
+			s.MarkAddress(apis.HTTP("sink.ns.svc.cluster.local"), availableEndpoints)
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:   RabbitmqSinkConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark connection not ready",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			s.MarkConnectionNotReady("SecretNotFound", "secret %q not found", "rabbit")
+			s.MarkDeployed(availableDeployment)
+			s.MarkAddress(apis.HTTP("sink.ns.svc.cluster.local"), availableEndpoints)
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqSinkConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "SecretNotFound",
+			Message: `secret "rabbit" not found`,
+		},
+	}, {
+		name: "mark deployment unavailable",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			s.MarkConnectionReady()
+			s.MarkDeployed(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "sink"}})
+			s.MarkAddress(apis.HTTP("sink.ns.svc.cluster.local"), availableEndpoints)
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqSinkConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "DeploymentUnavailable",
+			Message: "The Deployment 'sink' is unavailable.",
+		},
+	}, {
+		name: "mark endpoints unavailable",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			s.MarkConnectionReady()
+			s.MarkDeployed(availableDeployment)
+			s.MarkAddress(apis.HTTP("sink.ns.svc.cluster.local"), unavailableEndpoints)
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqSinkConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "EndpointsUnavailable",
+			Message: `Endpoints "sink" are unavailable.`,
+		},
+	}, {
+		name: "mark ready then no address",
+		s: func() *RabbitmqSinkStatus {
+			s := &RabbitmqSinkStatus{}
+			s.InitializeConditions()
+			s.MarkConnectionReady()
+			s.MarkDeployed(availableDeployment)
+			s.MarkAddress(apis.HTTP("sink.ns.svc.cluster.local"), availableEndpoints)
+			s.MarkNoAddress("ServiceNotFound", "hi%s", "")
+			return s
+		}(),
+		condQuery: RabbitmqSinkConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqSinkConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ServiceNotFound",
+			Message: "hi",
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.s.GetCondition(test.condQuery)
+			ignoreTime := cmpopts.IgnoreFields(apis.Condition{},
+				"LastTransitionTime", "Severity")
+			if diff := cmp.Diff(test.want, got, ignoreTime); diff != "" {
+				t.Errorf("unexpected condition (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqSink is an Addressable accepting CloudEvents over HTTP and
+// publishing them to a RabbitMQ exchange.
+// +k8s:openapi-gen=true
+type RabbitmqSink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitmqSinkSpec   `json:"spec,omitempty"`
+	Status RabbitmqSinkStatus `json:"status,omitempty"`
+}
+
+var _ runtime.Object = (*RabbitmqSink)(nil)
+var _ resourcesemantics.GenericCRD = (*RabbitmqSink)(nil)
+var _ kmeta.OwnerRefable = (*RabbitmqSink)(nil)
+var _ apis.Defaultable = (*RabbitmqSink)(nil)
+var _ apis.Validatable = (*RabbitmqSink)(nil)
+var _ duckv1.KRShaped = (*RabbitmqSink)(nil)
+
+type RabbitmqSinkSpec struct {
+	// Connection to RabbitMQ, configured as for RabbitmqSources.
+	Connection sourcesv1beta1.RabbitmqSourceConnectionSpec `json:"connection"`
+	// Exchange the events are published to, which must already exist.
+	// Defaults to the default exchange, which routes messages to the queue
+	// named after their routing key.
+	// +optional
+	Exchange string `json:"exchange,omitempty"`
+	// RoutingKey of the messages, a text/template template executed on the
+	// attributes and extensions of the events, e.g. "orders.{{ .type }}".
+	// +optional
+	RoutingKey string `json:"routingKey,omitempty"`
+	// ContentMode of the messages carrying the events: binary, the default,
+	// publishes the data of the event as body and its attributes as
+	// application properties prefixed with "cloudEvents_", while structured
+	// publishes the whole event encoded in JSON.
+	// +optional
+	ContentMode string `json:"contentMode,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount running the
+	// publisher Deployment.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+const (
+	// ContentModeBinary publishes the attributes of events as application
+	// properties.
+	ContentModeBinary = "binary"
+	// ContentModeStructured publishes events encoded in JSON.
+	ContentModeStructured = "structured"
+)
+
+type RabbitmqSinkStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// AddressStatus is the part where the RabbitmqSink fulfills the
+	// Addressable contract.
+	duckv1.AddressStatus `json:",inline"`
+}
+
+func (s *RabbitmqSink) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("RabbitmqSink")
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqSinkList contains a list of RabbitmqSinks.
+type RabbitmqSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitmqSink `json:"items"`
+}
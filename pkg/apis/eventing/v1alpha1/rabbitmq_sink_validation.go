@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"text/template"
+
+	"knative.dev/pkg/apis"
+)
+
+func (current *RabbitmqSink) Validate(ctx context.Context) *apis.FieldError {
+	return current.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (s *RabbitmqSinkSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := s.Connection.Validate(ctx).ViaField("connection")
+
+	if _, err := ParseRoutingKeyTemplate(s.RoutingKey); err != nil {
+		errs = errs.Also(invalidValue(s.RoutingKey, "routingKey", err.Error()))
+	}
+	switch s.ContentMode {
+	case "", ContentModeBinary, ContentModeStructured:
+	default:
+		errs = errs.Also(invalidValue(s.ContentMode, "contentMode", "expected binary or structured"))
+	}
+	return errs
+}
+
+// ParseRoutingKeyTemplate parses the template of the routing key, whose
+// execution fails on missing attributes.
+func ParseRoutingKeyTemplate(text string) (*template.Template, error) {
+	return template.New("routingKey").Option("missingkey=error").Parse(text)
+}
+
+// invalidValue returns an invalid value error explaining what's expected.
+func invalidValue(value interface{}, field, details string) *apis.FieldError {
+	err := apis.ErrInvalidValue(value, field)
+	err.Details = details
+	return err
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var fullSpec = RabbitmqSinkSpec{
+	Connection: sourcesv1beta1.RabbitmqSourceConnectionSpec{
+		URI: "amqp://rabbitmq:5672",
+		Credentials: &sourcesv1beta1.RabbitmqSourceCredentialsSpec{
+			User:     secretKeySelector("credentials", "user"),
+			Password: secretKeySelector("credentials", "password"),
+		},
+	},
+	Exchange:    "orders",
+	RoutingKey:  "orders.{{ .type }}",
+	ContentMode: ContentModeBinary,
+}
+
+func secretKeySelector(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+func TestRabbitmqSinkValidate(t *testing.T) {
+	testCases := map[string]struct {
+		update  func(*RabbitmqSinkSpec)
+		allowed bool
+	}{
+		"full spec": {
+			update:  func(*RabbitmqSinkSpec) {},
+			allowed: true,
+		},
+		"default exchange and constant routing key": {
+			update: func(s *RabbitmqSinkSpec) {
+				s.Exchange = ""
+				s.RoutingKey = "orders"
+			},
+			allowed: true,
+		},
+		"connection reference": {
+			update: func(s *RabbitmqSinkSpec) {
+				s.Connection = sourcesv1beta1.RabbitmqSourceConnectionSpec{
+					Ref: &duckv1.KReference{Kind: "RabbitmqCluster", APIVersion: "rabbitmq.com/v1beta1", Name: "rabbit"},
+				}
+			},
+			allowed: true,
+		},
+		"no connection": {
+			update: func(s *RabbitmqSinkSpec) { s.Connection = sourcesv1beta1.RabbitmqSourceConnectionSpec{} },
+		},
+		"invalid routing key template": {
+			update: func(s *RabbitmqSinkSpec) { s.RoutingKey = "orders.{{ .type" },
+		},
+		"structured content mode": {
+			update:  func(s *RabbitmqSinkSpec) { s.ContentMode = ContentModeStructured },
+			allowed: true,
+		},
+		"invalid content mode": {
+			update: func(s *RabbitmqSinkSpec) { s.ContentMode = "batched" },
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			sink := &RabbitmqSink{Spec: *fullSpec.DeepCopy()}
+			tc.update(&sink.Spec)
+			err := sink.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/eventing/pkg/apis/messaging"
+	"knative.dev/pkg/apis"
+)
+
+func (c *RabbitmqChannel) SetDefaults(ctx context.Context) {
+	// Declare the version of the subscribable duck the channel conforms to,
+	// which is how Subscriptions know the shape of the subscribers.
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]string)
+	}
+	if _, ok := c.Annotations[messaging.SubscribableDuckVersionAnnotation]; !ok {
+		c.Annotations[messaging.SubscribableDuckVersionAnnotation] = "v1"
+	}
+
+	ctx = apis.WithinParent(ctx, c.ObjectMeta)
+	c.Spec.SetDefaults(ctx)
+}
+
+func (s *RabbitmqChannelSpec) SetDefaults(ctx context.Context) {
+	if s.Delivery != nil && s.Delivery.DeadLetterSink != nil {
+		s.Delivery.DeadLetterSink.SetDefaults(ctx)
+	}
+}
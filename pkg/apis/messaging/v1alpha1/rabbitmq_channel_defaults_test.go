@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestRabbitmqChannelSetDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		channel RabbitmqChannel
+		want    RabbitmqChannel
+	}{
+		"empty": {
+			want: RabbitmqChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1"},
+				},
+			},
+		},
+		"annotation set, dead letter sink": {
+			channel: RabbitmqChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1beta1"},
+				},
+				Spec: channelSpec(""),
+			},
+			want: RabbitmqChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Annotations: map[string]string{"messaging.knative.dev/subscribable": "v1beta1"},
+				},
+				Spec: channelSpec("ns"),
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			channel := tc.channel.DeepCopy()
+			channel.SetDefaults(context.TODO())
+			if diff := cmp.Diff(tc.want, *channel); diff != "" {
+				t.Errorf("Unexpected defaults (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+// channelSpec returns a spec whose dead letter sink references a Service in
+// namespace.
+func channelSpec(namespace string) RabbitmqChannelSpec {
+	return RabbitmqChannelSpec{
+		ChannelableSpec: eventingduckv1.ChannelableSpec{
+			Delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{
+					Ref: &duckv1.KReference{Kind: "Service", APIVersion: "v1", Name: "dls", Namespace: namespace},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing/pkg/apis/duck"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	RabbitmqChannelConditionReady = apis.ConditionReady
+
+	// RabbitmqChannelConditionConnectionReady is true when the connection to
+	// RabbitMQ is resolved into the Secret read by the ingress and
+	// dispatchers.
+	RabbitmqChannelConditionConnectionReady apis.ConditionType = "ConnectionReady"
+
+	// RabbitmqChannelConditionExchangeReady is true when the fanout exchange
+	// of the channel is declared.
+	RabbitmqChannelConditionExchangeReady apis.ConditionType = "ExchangeReady"
+
+	// RabbitmqChannelConditionIngressReady is true when the ingress
+	// Deployment publishing to the exchange is available.
+	RabbitmqChannelConditionIngressReady apis.ConditionType = "IngressReady"
+
+	RabbitmqChannelConditionAddressable apis.ConditionType = "Addressable"
+)
+
+var RabbitmqChannelCondSet = apis.NewLivingConditionSet(
+	RabbitmqChannelConditionConnectionReady,
+	RabbitmqChannelConditionExchangeReady,
+	RabbitmqChannelConditionIngressReady,
+	RabbitmqChannelConditionAddressable)
+
+func (s *RabbitmqChannelStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return RabbitmqChannelCondSet.Manage(s).GetCondition(t)
+}
+
+func (s *RabbitmqChannelStatus) GetTopLevelCondition() *apis.Condition {
+	return RabbitmqChannelCondSet.Manage(s).GetTopLevelCondition()
+}
+
+func (in *RabbitmqChannel) GetStatus() *duckv1.Status {
+	return &in.Status.Status
+}
+
+func (in *RabbitmqChannel) GetConditionSet() apis.ConditionSet {
+	return RabbitmqChannelCondSet
+}
+
+func (s *RabbitmqChannelStatus) IsReady() bool {
+	return RabbitmqChannelCondSet.Manage(s).IsHappy()
+}
+
+func (s *RabbitmqChannelStatus) InitializeConditions() {
+	RabbitmqChannelCondSet.Manage(s).InitializeConditions()
+}
+
+func (s *RabbitmqChannelStatus) MarkConnectionReady() {
+	RabbitmqChannelCondSet.Manage(s).MarkTrue(RabbitmqChannelConditionConnectionReady)
+}
+
+func (s *RabbitmqChannelStatus) MarkConnectionNotReady(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionConnectionReady, reason, messageFormat, messageA...)
+}
+
+func (s *RabbitmqChannelStatus) MarkExchangeReady() {
+	RabbitmqChannelCondSet.Manage(s).MarkTrue(RabbitmqChannelConditionExchangeReady)
+}
+
+func (s *RabbitmqChannelStatus) MarkExchangeFailed(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionExchangeReady, reason, messageFormat, messageA...)
+}
+
+func (s *RabbitmqChannelStatus) MarkIngressDeployed(d *appsv1.Deployment) {
+	if duck.DeploymentIsAvailable(&d.Status, false) {
+		RabbitmqChannelCondSet.Manage(s).MarkTrue(RabbitmqChannelConditionIngressReady)
+	} else {
+		RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionIngressReady, "DeploymentUnavailable", "The Deployment '%s' is unavailable.", d.Name)
+	}
+}
+
+func (s *RabbitmqChannelStatus) MarkIngressFailed(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionIngressReady, reason, messageFormat, messageA...)
+}
+
+// MarkAddress sets the address of the channel, which is addressable once the
+// endpoints of its ingress Service are available.
+func (s *RabbitmqChannelStatus) MarkAddress(url *apis.URL, ep *corev1.Endpoints) {
+	s.Address = &duckv1.Addressable{URL: url}
+	if duck.EndpointsAreAvailable(ep) {
+		RabbitmqChannelCondSet.Manage(s).MarkTrue(RabbitmqChannelConditionAddressable)
+	} else {
+		RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionAddressable, "EndpointsUnavailable", "Endpoints %q are unavailable.", ep.Name)
+	}
+}
+
+func (s *RabbitmqChannelStatus) MarkNoAddress(reason, messageFormat string, messageA ...interface{}) {
+	s.Address = nil
+	RabbitmqChannelCondSet.Manage(s).MarkFalse(RabbitmqChannelConditionAddressable, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var (
+	availableDeployment = &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{
+					Type:   appsv1.DeploymentAvailable,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	availableEndpoints = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "channel"},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "127.0.0.1"}},
+		}},
+	}
+
+	unavailableEndpoints = &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: "channel"},
+	}
+)
+
+var _ = duck.VerifyType(&RabbitmqChannel{}, &eventingduckv1.Channelable{})
+var _ = duck.VerifyType(&RabbitmqChannel{}, &duckv1.Conditions{})
+
+func TestRabbitmqChannelStatusGetCondition(t *testing.T) {
+	ready := func(s *RabbitmqChannelStatus) {
+		s.InitializeConditions()
+		s.MarkConnectionReady()
+		s.MarkExchangeReady()
+		s.MarkIngressDeployed(availableDeployment)
+		s.MarkAddress(apis.HTTP("channel.ns.svc.cluster.local"), availableEndpoints)
+	}
+
+	tests := []struct {
+		name      string
+		s         *RabbitmqChannelStatus
+		condQuery apis.ConditionType
+		want      *apis.Condition
+	}{{
+		name:      "uninitialized",
+		s:         &RabbitmqChannelStatus{},
+		condQuery: RabbitmqChannelConditionReady,
+		want:      nil,
+	}, {
+		name: "initialized",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			s.InitializeConditions()
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:   RabbitmqChannelConditionReady,
+			Status: corev1.ConditionUnknown,
+		},
+	}, {
+		name: "mark connection, exchange and ingress ready and addressable",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:   RabbitmqChannelConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}, {
+		name: "mark connection not ready",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkConnectionNotReady("SecretNotFound", "secret %q not found", "rabbit")
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "SecretNotFound",
+			Message: `secret "rabbit" not found`,
+		},
+	}, {
+		name: "mark exchange failed",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkExchangeFailed("ExchangeFailure", "Failed to declare exchange: %s", "boom")
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ExchangeFailure",
+			Message: "Failed to declare exchange: boom",
+		},
+	}, {
+		name: "mark ingress unavailable",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkIngressDeployed(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "channel"}})
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "DeploymentUnavailable",
+			Message: "The Deployment 'channel' is unavailable.",
+		},
+	}, {
+		name: "mark ingress failed",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkIngressFailed("DeploymentFailure", "hi%s", "")
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "DeploymentFailure",
+			Message: "hi",
+		},
+	}, {
+		name: "mark endpoints unavailable",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkAddress(apis.HTTP("channel.ns.svc.cluster.local"), unavailableEndpoints)
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "EndpointsUnavailable",
+			Message: `Endpoints "channel" are unavailable.`,
+		},
+	}, {
+		name: "mark ready then no address",
+		s: func() *RabbitmqChannelStatus {
+			s := &RabbitmqChannelStatus{}
+			ready(s)
+			s.MarkNoAddress("ServiceNotFound", "hi%s", "")
+			return s
+		}(),
+		condQuery: RabbitmqChannelConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqChannelConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ServiceNotFound",
+			Message: "hi",
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.s.GetCondition(test.condQuery)
+			ignoreTime := cmpopts.IgnoreFields(apis.Condition{},
+				"LastTransitionTime", "Severity")
+			if diff := cmp.Diff(test.want, got, ignoreTime); diff != "" {
+				t.Errorf("unexpected condition (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
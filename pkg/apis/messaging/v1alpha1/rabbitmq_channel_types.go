@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqChannel is a Channel backed by RabbitMQ: events sent to it are
+// published to a fanout exchange and delivered to each subscriber from its
+// own durable queue.
+// +k8s:openapi-gen=true
+type RabbitmqChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitmqChannelSpec   `json:"spec,omitempty"`
+	Status RabbitmqChannelStatus `json:"status,omitempty"`
+}
+
+var _ runtime.Object = (*RabbitmqChannel)(nil)
+var _ resourcesemantics.GenericCRD = (*RabbitmqChannel)(nil)
+var _ kmeta.OwnerRefable = (*RabbitmqChannel)(nil)
+var _ apis.Defaultable = (*RabbitmqChannel)(nil)
+var _ apis.Validatable = (*RabbitmqChannel)(nil)
+var _ apis.HasSpec = (*RabbitmqChannel)(nil)
+var _ duckv1.KRShaped = (*RabbitmqChannel)(nil)
+
+type RabbitmqChannelSpec struct {
+	// Connection to RabbitMQ, configured as for RabbitmqSources. The ingress
+	// and dispatchers of the channel connect with the PLAIN mechanism and
+	// without TLS.
+	Connection sourcesv1beta1.RabbitmqSourceConnectionSpec `json:"connection"`
+
+	// Channel conforms to Duck type Channelable.
+	eventingduckv1.ChannelableSpec `json:",inline"`
+}
+
+type RabbitmqChannelStatus struct {
+	// Channel conforms to Duck type ChannelableStatus.
+	eventingduckv1.ChannelableStatus `json:",inline"`
+}
+
+func (c *RabbitmqChannel) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("RabbitmqChannel")
+}
+
+// GetUntypedSpec returns the spec of the RabbitmqChannel.
+func (c *RabbitmqChannel) GetUntypedSpec() interface{} {
+	return c.Spec
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqChannelList contains a list of RabbitmqChannels.
+type RabbitmqChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitmqChannel `json:"items"`
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+func (c *RabbitmqChannel) Validate(ctx context.Context) *apis.FieldError {
+	return c.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (s *RabbitmqChannelSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := s.Connection.Validate(ctx)
+	// The ingress and dispatchers dial the URL of the connection as is.
+	if s.Connection.TLS != nil {
+		err := apis.ErrDisallowedFields("tls")
+		err.Details = "TLS connections aren't supported by RabbitmqChannels"
+		errs = errs.Also(err)
+	}
+	if strings.EqualFold(s.Connection.SASLMechanism, "EXTERNAL") {
+		err := apis.ErrInvalidValue(s.Connection.SASLMechanism, "saslMechanism")
+		err.Details = "only PLAIN authentication is supported by RabbitmqChannels"
+		errs = errs.Also(err)
+	}
+	errs = errs.ViaField("connection")
+
+	for i, sub := range s.Subscribers {
+		if sub.ReplyURI == nil && sub.SubscriberURI == nil {
+			err := apis.ErrMissingField("replyURI", "subscriberURI")
+			err.Details = "expected at least one of, got none"
+			errs = errs.Also(err.ViaIndex(i).ViaField("subscribers"))
+		}
+		if sub.Delivery != nil {
+			errs = errs.Also(sub.Delivery.Validate(ctx).ViaField(fmt.Sprintf("subscribers[%d]", i), "delivery"))
+		}
+	}
+	if s.Delivery != nil {
+		errs = errs.Also(s.Delivery.Validate(ctx).ViaField("delivery"))
+	}
+	return errs
+}
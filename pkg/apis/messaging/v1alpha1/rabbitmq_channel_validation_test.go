@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var (
+	retry    = int32(3)
+	badDelay = "5 seconds"
+
+	fullSpec = RabbitmqChannelSpec{
+		Connection: sourcesv1beta1.RabbitmqSourceConnectionSpec{
+			URI: "amqp://rabbitmq:5672",
+			Credentials: &sourcesv1beta1.RabbitmqSourceCredentialsSpec{
+				User:     secretKeySelector("credentials", "user"),
+				Password: secretKeySelector("credentials", "password"),
+			},
+		},
+		ChannelableSpec: eventingduckv1.ChannelableSpec{
+			SubscribableSpec: eventingduckv1.SubscribableSpec{
+				Subscribers: []eventingduckv1.SubscriberSpec{{
+					UID:           "uid",
+					SubscriberURI: apis.HTTP("subscriber.ns.svc.cluster.local"),
+					ReplyURI:      apis.HTTP("reply.ns.svc.cluster.local"),
+					Delivery:      &eventingduckv1.DeliverySpec{Retry: &retry},
+				}},
+			},
+			Delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: apis.HTTP("dls.ns.svc.cluster.local")},
+			},
+		},
+	}
+)
+
+func secretKeySelector(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+func TestRabbitmqChannelValidate(t *testing.T) {
+	testCases := map[string]struct {
+		update  func(*RabbitmqChannelSpec)
+		allowed bool
+	}{
+		"full spec": {
+			update:  func(*RabbitmqChannelSpec) {},
+			allowed: true,
+		},
+		"connection reference": {
+			update: func(s *RabbitmqChannelSpec) {
+				s.Connection = sourcesv1beta1.RabbitmqSourceConnectionSpec{
+					Ref: &duckv1.KReference{Kind: "RabbitmqCluster", APIVersion: "rabbitmq.com/v1beta1", Name: "rabbit"},
+				}
+			},
+			allowed: true,
+		},
+		"no connection": {
+			update: func(s *RabbitmqChannelSpec) { s.Connection = sourcesv1beta1.RabbitmqSourceConnectionSpec{} },
+		},
+		"TLS connection": {
+			update: func(s *RabbitmqChannelSpec) { s.Connection.TLS = &sourcesv1beta1.RabbitmqSourceTLSSpec{} },
+		},
+		"no subscribers": {
+			update:  func(s *RabbitmqChannelSpec) { s.Subscribers = nil },
+			allowed: true,
+		},
+		"subscriber without URIs": {
+			update: func(s *RabbitmqChannelSpec) { s.Subscribers[0].SubscriberURI, s.Subscribers[0].ReplyURI = nil, nil },
+		},
+		"reply only subscriber": {
+			update:  func(s *RabbitmqChannelSpec) { s.Subscribers[0].SubscriberURI = nil },
+			allowed: true,
+		},
+		"invalid subscriber delivery": {
+			update: func(s *RabbitmqChannelSpec) { s.Subscribers[0].Delivery.BackoffDelay = &badDelay },
+		},
+		"invalid delivery": {
+			update: func(s *RabbitmqChannelSpec) { s.Delivery.DeadLetterSink = &duckv1.Destination{} },
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			channel := &RabbitmqChannel{Spec: *fullSpec.DeepCopy()}
+			tc.update(&channel.Spec)
+			err := channel.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
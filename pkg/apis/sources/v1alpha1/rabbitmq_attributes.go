@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"text/template"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// ParseAttributeTemplate parses the template of an event attribute, whose
+// execution fails on missing keys so that the default attribute is used.
+func ParseAttributeTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// isTemplate returns whether the attribute depends on the message.
+func isTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// CloudEventAttributes returns the types and source of the events wrapping
+// messages, as far as they are known without the messages.
+func (s *RabbitmqSource) CloudEventAttributes() []duckv1.CloudEventAttributes {
+	source := RabbitmqEventSource(s.Namespace, s.Name, s.Spec.Topic)
+	types := []string{RabbitmqEventType}
+	if attrs := s.Spec.Attributes; attrs != nil {
+		if attrs.Source != "" {
+			source = attrs.Source
+			if isTemplate(source) {
+				source = ""
+			}
+		}
+		if attrs.Type != "" {
+			types = []string{attrs.Type}
+			if isTemplate(attrs.Type) {
+				types = attrs.Types
+			}
+		}
+	}
+
+	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(types))
+	for _, t := range types {
+		ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
+			Type:   t,
+			Source: source,
+		})
+	}
+	return ceAttributes
+}
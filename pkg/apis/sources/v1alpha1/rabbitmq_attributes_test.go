@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestRabbitmqSourceCloudEventAttributes(t *testing.T) {
+	defaultSource := RabbitmqEventSource("ns", "name", "topic")
+
+	testCases := map[string]struct {
+		attributes *RabbitmqSourceAttributesSpec
+		want       []duckv1.CloudEventAttributes
+	}{
+		"default": {
+			want: []duckv1.CloudEventAttributes{{Type: RabbitmqEventType, Source: defaultSource}},
+		},
+		"literal": {
+			attributes: &RabbitmqSourceAttributesSpec{Type: "com.example.order", Source: "/orders"},
+			want:       []duckv1.CloudEventAttributes{{Type: "com.example.order", Source: "/orders"}},
+		},
+		"templated type": {
+			attributes: &RabbitmqSourceAttributesSpec{
+				Type:  "com.example.{{ .routingKey }}",
+				Types: []string{"com.example.created", "com.example.deleted"},
+			},
+			want: []duckv1.CloudEventAttributes{
+				{Type: "com.example.created", Source: defaultSource},
+				{Type: "com.example.deleted", Source: defaultSource},
+			},
+		},
+		"templated source": {
+			attributes: &RabbitmqSourceAttributesSpec{Source: "/{{ .exchange }}"},
+			want:       []duckv1.CloudEventAttributes{{Type: RabbitmqEventType}},
+		},
+		"templated type without types": {
+			attributes: &RabbitmqSourceAttributesSpec{Type: "{{ .type }}"},
+			want:       []duckv1.CloudEventAttributes{},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "name"},
+				Spec: RabbitmqSourceSpec{
+					Topic:      "topic",
+					Attributes: tc.attributes,
+				},
+			}
+			if diff := cmp.Diff(tc.want, src.CloudEventAttributes()); diff != "" {
+				t.Errorf("unexpected attributes (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "time"
+
+// The consumer options are defaulted and validated as those of v1beta1
+// sources.
+
+func (c *RabbitmqSourceConsumerSpec) GetAckMode() string {
+	return c.toV1beta1().GetAckMode()
+}
+
+func (c *RabbitmqSourceConsumerSpec) GetAckBatchSize() int32 {
+	return c.toV1beta1().GetAckBatchSize()
+}
+
+func (c *RabbitmqSourceConsumerSpec) GetAckBatchInterval() time.Duration {
+	return c.toV1beta1().GetAckBatchInterval()
+}
@@ -0,0 +1,455 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+)
+
+const (
+	// topicAnnotation holds, on v1beta1 sources, the topic of the v1alpha1
+	// source when it isn't the name of its exchange, as v1beta1 sources have
+	// no topic.
+	topicAnnotation = "sources.knative.dev/v1alpha1-topic"
+	// exchangeNameAnnotation holds, on v1beta1 sources, the name of the
+	// topic exchange of the v1alpha1 source when it isn't its topic, which
+	// names v1beta1 topic exchanges.
+	exchangeNameAnnotation = "sources.knative.dev/v1alpha1-exchange-name"
+	// bindingsAnnotation marks v1beta1 sources whose v1alpha1 source lists
+	// bindings that would otherwise be converted back into the routing key
+	// of its queue.
+	bindingsAnnotation = "sources.knative.dev/v1alpha1-bindings"
+)
+
+// ConvertTo implements apis.Convertible.
+// Converts source from v1alpha1.RabbitmqSource into v1beta1.RabbitmqSource.
+func (source *RabbitmqSource) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch v := to.(type) {
+	case *v1beta1.RabbitmqSource:
+		source.ObjectMeta.DeepCopyInto(&v.ObjectMeta)
+		source.Spec.convertTo(&v.Spec)
+		if source.Spec.Topic != v.Spec.Exchange.Name {
+			setAnnotation(&v.ObjectMeta, topicAnnotation, source.Spec.Topic)
+		}
+		if source.Spec.ExchangeConfig.Name != v.Spec.Exchange.Name {
+			setAnnotation(&v.ObjectMeta, exchangeNameAnnotation, source.Spec.ExchangeConfig.Name)
+		}
+		if _, ok := routingKeys(v.Spec.Bindings); ok && len(source.Spec.Bindings) > 0 {
+			setAnnotation(&v.ObjectMeta, bindingsAnnotation, "true")
+		}
+		source.Status.convertTo(&v.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", v)
+	}
+}
+
+// ConvertFrom implements apis.Convertible.
+// Converts from v1beta1.RabbitmqSource into v1alpha1.RabbitmqSource.
+func (source *RabbitmqSource) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch v := from.(type) {
+	case *v1beta1.RabbitmqSource:
+		v.ObjectMeta.DeepCopyInto(&source.ObjectMeta)
+		source.Spec.convertFrom(&v.Spec)
+		if topic, ok := v.Annotations[topicAnnotation]; ok {
+			source.Spec.Topic = topic
+		}
+		if name, ok := v.Annotations[exchangeNameAnnotation]; ok {
+			source.Spec.ExchangeConfig.Name = name
+		}
+		if _, ok := v.Annotations[bindingsAnnotation]; ok && len(v.Spec.Bindings) > 0 {
+			source.Spec.QueueConfig.RoutingKey = ""
+			source.Spec.Bindings = convertBindingsFrom(v.Spec.Bindings)
+		}
+		delete(source.Annotations, topicAnnotation)
+		delete(source.Annotations, exchangeNameAnnotation)
+		delete(source.Annotations, bindingsAnnotation)
+		if len(source.Annotations) == 0 {
+			source.Annotations = nil
+		}
+		source.Status.convertFrom(&v.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", v)
+	}
+}
+
+// setAnnotation sets the annotation key of meta, which must not be shared
+// with the converted object.
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string, 1)
+	}
+	meta.Annotations[key] = value
+}
+
+func (s *RabbitmqSourceSpec) convertTo(to *v1beta1.RabbitmqSourceSpec) {
+	if s.Sink != nil {
+		to.Sink = *s.Sink.DeepCopy()
+	}
+	to.CloudEventOverrides = s.CloudEventOverrides.DeepCopy()
+
+	to.Connection = v1beta1.RabbitmqSourceConnectionSpec{
+		URI:           s.Brokers,
+		Ref:           s.ConnectionRef.DeepCopy(),
+		Vhost:         s.Vhost,
+		SASLMechanism: s.SASLMechanism,
+	}
+	if c := s.Connection; c != nil {
+		to.Connection.Nodes = append([]string(nil), c.Nodes...)
+		to.Connection.NodeOrder = c.NodeOrder
+		to.Connection.Heartbeat = c.Heartbeat.DeepCopy()
+		to.Connection.Timeout = c.Timeout.DeepCopy()
+		to.Connection.ChannelMax = c.ChannelMax
+		to.Connection.Name = c.Name
+	}
+	if s.User.SecretKeyRef != nil || s.Password.SecretKeyRef != nil {
+		to.Connection.Credentials = &v1beta1.RabbitmqSourceCredentialsSpec{}
+		if s.User.SecretKeyRef != nil {
+			to.Connection.Credentials.User = *s.User.SecretKeyRef.DeepCopy()
+		}
+		if s.Password.SecretKeyRef != nil {
+			to.Connection.Credentials.Password = *s.Password.SecretKeyRef.DeepCopy()
+		}
+	}
+	if s.TLS != nil {
+		to.Connection.TLS = &v1beta1.RabbitmqSourceTLSSpec{
+			CACert:           s.TLS.CACert.DeepCopy(),
+			ClientCertSecret: s.TLS.ClientCertSecret.DeepCopy(),
+			ServerName:       s.TLS.ServerName,
+		}
+	}
+
+	to.Exchange = v1beta1.RabbitmqSourceExchangeSpec{
+		Name:       s.ExchangeConfig.Name,
+		Type:       s.ExchangeConfig.TypeOf,
+		Durable:    s.ExchangeConfig.Durable,
+		AutoDelete: s.ExchangeConfig.AutoDeleted,
+		Internal:   s.ExchangeConfig.Internal,
+		NoWait:     s.ExchangeConfig.NoWait,
+	}
+	if s.ExchangeConfig.TypeOf == "topic" {
+		// The topic names topic exchanges.
+		to.Exchange.Name = s.Topic
+	}
+
+	q := s.QueueConfig.DeepCopy()
+	to.Queue = v1beta1.RabbitmqSourceQueueSpec{
+		Name:                 q.Name,
+		Type:                 q.Type,
+		Durable:              q.Durable,
+		AutoDelete:           q.DeleteWhenUnused,
+		Exclusive:            q.Exclusive,
+		NoWait:               q.NoWait,
+		StreamOffset:         q.StreamOffset,
+		MessageTTL:           q.MessageTTL,
+		MaxLength:            q.MaxLength,
+		MaxLengthBytes:       q.MaxLengthBytes,
+		Overflow:             q.Overflow,
+		DeadLetterExchange:   q.DeadLetterExchange,
+		DeadLetterRoutingKey: q.DeadLetterRoutingKey,
+		SingleActiveConsumer: q.SingleActiveConsumer,
+		DeliveryLimit:        q.DeliveryLimit,
+		Arguments:            q.Arguments,
+	}
+
+	if len(s.Bindings) > 0 {
+		to.Bindings = make([]v1beta1.RabbitmqSourceBinding, 0, len(s.Bindings))
+		for _, b := range s.Bindings {
+			to.Bindings = append(to.Bindings, v1beta1.RabbitmqSourceBinding{
+				Exchange:   b.Exchange,
+				RoutingKey: b.RoutingKey,
+				Arguments:  b.Arguments.DeepCopy(),
+			})
+		}
+	} else if s.QueueConfig.RoutingKey != "" {
+		for _, key := range strings.Split(s.QueueConfig.RoutingKey, ",") {
+			to.Bindings = append(to.Bindings, v1beta1.RabbitmqSourceBinding{RoutingKey: key})
+		}
+	}
+
+	to.TopologyMode = s.TopologyMode
+	to.Channel = v1beta1.RabbitmqSourceChannelSpec{
+		PrefetchCount: int32(s.ChannelConfig.PrefetchCount),
+		GlobalQos:     s.ChannelConfig.GlobalQos,
+	}
+	to.Consumer = s.Consumer.toV1beta1()
+	to.Delivery = s.Delivery.DeepCopy()
+	if s.Reply != nil {
+		to.Reply = &v1beta1.RabbitmqSourceReplySpec{
+			Exchange:   s.Reply.Exchange,
+			RoutingKey: s.Reply.RoutingKey,
+		}
+	}
+
+	if s.Replicas != nil {
+		replicas := *s.Replicas
+		to.Replicas = &replicas
+	}
+	if s.Scale != nil {
+		scale := s.Scale.DeepCopy()
+		to.Scale = &v1beta1.RabbitmqSourceScaleSpec{
+			MinReplicas:        scale.MinReplicas,
+			MaxReplicas:        scale.MaxReplicas,
+			MessagesPerReplica: scale.MessagesPerReplica,
+			PollingInterval:    scale.PollingInterval,
+			CooldownPeriod:     scale.CooldownPeriod,
+		}
+	}
+	to.ServiceAccountName = s.ServiceAccountName
+	to.PodTemplate = s.PodTemplate.DeepCopy()
+
+	to.ContentType = s.ContentType
+	to.ForceWrap = s.ForceWrap
+	if s.Extensions != nil {
+		e := s.Extensions.DeepCopy()
+		to.Extensions = &v1beta1.RabbitmqSourceExtensionsSpec{
+			Properties: e.Properties,
+			Names:      e.Names,
+		}
+	}
+	if s.Attributes != nil {
+		a := s.Attributes.DeepCopy()
+		to.Attributes = &v1beta1.RabbitmqSourceAttributesSpec{
+			Type:    a.Type,
+			Source:  a.Source,
+			Subject: a.Subject,
+			ID:      a.ID,
+			Types:   a.Types,
+		}
+	}
+}
+
+func (s *RabbitmqSourceSpec) convertFrom(from *v1beta1.RabbitmqSourceSpec) {
+	if from.Sink != (duckv1.Destination{}) {
+		s.Sink = from.Sink.DeepCopy()
+	}
+	s.CloudEventOverrides = from.CloudEventOverrides.DeepCopy()
+
+	c := from.Connection
+	s.Brokers = c.URI
+	s.ConnectionRef = c.Ref.DeepCopy()
+	if c.Credentials != nil {
+		s.User = SecretValueFromSource{SecretKeyRef: c.Credentials.User.DeepCopy()}
+		s.Password = SecretValueFromSource{SecretKeyRef: c.Credentials.Password.DeepCopy()}
+	}
+	s.Vhost = c.Vhost
+	if c.TLS != nil {
+		s.TLS = &RabbitmqSourceTLSSpec{
+			CACert:           c.TLS.CACert.DeepCopy(),
+			ClientCertSecret: c.TLS.ClientCertSecret.DeepCopy(),
+			ServerName:       c.TLS.ServerName,
+		}
+	}
+	s.SASLMechanism = c.SASLMechanism
+	if len(c.Nodes) > 0 || c.NodeOrder != "" || c.Heartbeat != nil || c.Timeout != nil || c.ChannelMax != 0 || c.Name != "" {
+		s.Connection = &RabbitmqSourceConnectionSpec{
+			Nodes:      append([]string(nil), c.Nodes...),
+			NodeOrder:  c.NodeOrder,
+			Heartbeat:  c.Heartbeat.DeepCopy(),
+			Timeout:    c.Timeout.DeepCopy(),
+			ChannelMax: c.ChannelMax,
+			Name:       c.Name,
+		}
+	}
+
+	// The topic names topic exchanges and is the fragment of the from of
+	// the events, which v1beta1 sources take from their exchange.
+	s.Topic = from.Exchange.Name
+	s.ExchangeConfig = RabbitmqSourceExchangeConfigSpec{
+		Name:        from.Exchange.Name,
+		TypeOf:      from.Exchange.Type,
+		Durable:     from.Exchange.Durable,
+		AutoDeleted: from.Exchange.AutoDelete,
+		Internal:    from.Exchange.Internal,
+		NoWait:      from.Exchange.NoWait,
+	}
+
+	q := from.Queue.DeepCopy()
+	s.QueueConfig = RabbitmqSourceQueueConfigSpec{
+		Name:                 q.Name,
+		Type:                 q.Type,
+		Durable:              q.Durable,
+		DeleteWhenUnused:     q.AutoDelete,
+		Exclusive:            q.Exclusive,
+		NoWait:               q.NoWait,
+		StreamOffset:         q.StreamOffset,
+		MessageTTL:           q.MessageTTL,
+		MaxLength:            q.MaxLength,
+		MaxLengthBytes:       q.MaxLengthBytes,
+		Overflow:             q.Overflow,
+		DeadLetterExchange:   q.DeadLetterExchange,
+		DeadLetterRoutingKey: q.DeadLetterRoutingKey,
+		SingleActiveConsumer: q.SingleActiveConsumer,
+		DeliveryLimit:        q.DeliveryLimit,
+		Arguments:            q.Arguments,
+	}
+
+	if keys, ok := routingKeys(from.Bindings); ok {
+		s.QueueConfig.RoutingKey = strings.Join(keys, ",")
+	} else {
+		s.Bindings = convertBindingsFrom(from.Bindings)
+	}
+
+	s.TopologyMode = from.TopologyMode
+	s.ChannelConfig = RabbitmqChannelConfigSpec{
+		PrefetchCount: int(from.Channel.PrefetchCount),
+		GlobalQos:     from.Channel.GlobalQos,
+	}
+	if from.Consumer != nil {
+		s.Consumer = &RabbitmqSourceConsumerSpec{}
+		s.Consumer.convertFrom(from.Consumer)
+	}
+	s.Delivery = from.Delivery.DeepCopy()
+	if from.Reply != nil {
+		s.Reply = &RabbitmqSourceReplySpec{
+			Exchange:   from.Reply.Exchange,
+			RoutingKey: from.Reply.RoutingKey,
+		}
+	}
+
+	if from.Replicas != nil {
+		replicas := *from.Replicas
+		s.Replicas = &replicas
+	}
+	if from.Scale != nil {
+		scale := from.Scale.DeepCopy()
+		s.Scale = &RabbitmqSourceScaleSpec{
+			MinReplicas:        scale.MinReplicas,
+			MaxReplicas:        scale.MaxReplicas,
+			MessagesPerReplica: scale.MessagesPerReplica,
+			PollingInterval:    scale.PollingInterval,
+			CooldownPeriod:     scale.CooldownPeriod,
+		}
+	}
+	s.ServiceAccountName = from.ServiceAccountName
+	s.PodTemplate = from.PodTemplate.DeepCopy()
+
+	s.ContentType = from.ContentType
+	s.ForceWrap = from.ForceWrap
+	if from.Extensions != nil {
+		e := from.Extensions.DeepCopy()
+		s.Extensions = &RabbitmqSourceExtensionsSpec{
+			Properties: e.Properties,
+			Names:      e.Names,
+		}
+	}
+	if from.Attributes != nil {
+		a := from.Attributes.DeepCopy()
+		s.Attributes = &RabbitmqSourceAttributesSpec{
+			Type:    a.Type,
+			Source:  a.Source,
+			Subject: a.Subject,
+			ID:      a.ID,
+			Types:   a.Types,
+		}
+	}
+}
+
+// routingKeys returns the routing keys of bindings that can be given as the
+// routing key of the queue: bindings to the exchange of the source without
+// arguments, whose routing keys are neither empty nor contain commas.
+func routingKeys(bindings []v1beta1.RabbitmqSourceBinding) ([]string, bool) {
+	if len(bindings) == 0 {
+		return nil, true
+	}
+	keys := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if b.Exchange != "" || b.Arguments != nil || b.RoutingKey == "" || strings.Contains(b.RoutingKey, ",") {
+			return nil, false
+		}
+		keys = append(keys, b.RoutingKey)
+	}
+	return keys, true
+}
+
+func convertBindingsFrom(from []v1beta1.RabbitmqSourceBinding) []RabbitmqSourceBinding {
+	bindings := make([]RabbitmqSourceBinding, 0, len(from))
+	for _, b := range from {
+		bindings = append(bindings, RabbitmqSourceBinding{
+			Exchange:   b.Exchange,
+			RoutingKey: b.RoutingKey,
+			Arguments:  b.Arguments.DeepCopy(),
+		})
+	}
+	return bindings
+}
+
+// toV1beta1 returns a copy of the consumer options as those of a v1beta1
+// source, nil if c is.
+func (c *RabbitmqSourceConsumerSpec) toV1beta1() *v1beta1.RabbitmqSourceConsumerSpec {
+	if c == nil {
+		return nil
+	}
+	c = c.DeepCopy()
+	return &v1beta1.RabbitmqSourceConsumerSpec{
+		AckMode:          c.AckMode,
+		AckBatchSize:     c.AckBatchSize,
+		AckBatchInterval: c.AckBatchInterval,
+		Tag:              c.Tag,
+		Priority:         c.Priority,
+		Exclusive:        c.Exclusive,
+	}
+}
+
+func (c *RabbitmqSourceConsumerSpec) convertFrom(from *v1beta1.RabbitmqSourceConsumerSpec) {
+	from = from.DeepCopy()
+	*c = RabbitmqSourceConsumerSpec{
+		AckMode:          from.AckMode,
+		AckBatchSize:     from.AckBatchSize,
+		AckBatchInterval: from.AckBatchInterval,
+		Tag:              from.Tag,
+		Priority:         from.Priority,
+		Exclusive:        from.Exclusive,
+	}
+}
+
+func (s *RabbitmqSourceStatus) convertTo(to *v1beta1.RabbitmqSourceStatus) {
+	s.SourceStatus.DeepCopyInto(&to.SourceStatus)
+	to.DeadLetterSinkURI = s.DeadLetterSinkURI.DeepCopy()
+	if s.Queue != nil {
+		to.Queue = &v1beta1.RabbitmqSourceQueueStatus{
+			Name:                   s.Queue.Name,
+			MessagesReady:          s.Queue.MessagesReady,
+			MessagesUnacknowledged: s.Queue.MessagesUnacknowledged,
+			Consumers:              s.Queue.Consumers,
+			LastActiveTime:         s.Queue.LastActiveTime.DeepCopy(),
+		}
+	}
+}
+
+func (s *RabbitmqSourceStatus) convertFrom(from *v1beta1.RabbitmqSourceStatus) {
+	from.SourceStatus.DeepCopyInto(&s.SourceStatus)
+	s.DeadLetterSinkURI = from.DeadLetterSinkURI.DeepCopy()
+	if from.Queue != nil {
+		s.Queue = &RabbitmqSourceQueueStatus{
+			Name:                   from.Queue.Name,
+			MessagesReady:          from.Queue.MessagesReady,
+			MessagesUnacknowledged: from.Queue.MessagesUnacknowledged,
+			Consumers:              from.Queue.Consumers,
+			LastActiveTime:         from.Queue.LastActiveTime.DeepCopy(),
+		}
+	}
+}
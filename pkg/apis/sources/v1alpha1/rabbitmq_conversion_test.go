@@ -0,0 +1,382 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+)
+
+type testConvertible struct{}
+
+func (*testConvertible) ConvertTo(context.Context, apis.Convertible) error   { return nil }
+func (*testConvertible) ConvertFrom(context.Context, apis.Convertible) error { return nil }
+
+func TestRabbitmqSourceConversionBadType(t *testing.T) {
+	good, bad := &RabbitmqSource{}, &testConvertible{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestRabbitmqSourceConversionRoundTripV1alpha1(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "ns", Name: "source", Generation: 2}
+	sink := &duckv1.Destination{
+		Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink"},
+	}
+	secretKeyRef := func(key string) SecretValueFromSource {
+		return SecretValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+			Key:                  key,
+		}}
+	}
+
+	tests := map[string]*RabbitmqSource{
+		"empty": {},
+		"routing keys": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				Brokers:        "amqp://rabbitmq:5672",
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "logs", TypeOf: "topic"},
+				QueueConfig:    RabbitmqSourceQueueConfigSpec{Name: "queue", RoutingKey: "*.critical,audit.#"},
+				Sink:           sink,
+			},
+		},
+		"full": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				Brokers:  "amqps://rabbitmq:5671",
+				Topic:    "events",
+				User:     secretKeyRef("user"),
+				Password: secretKeyRef("password"),
+				Vhost:    "vhost",
+				TLS: &RabbitmqSourceTLSSpec{
+					CACert: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+						Key:                  "ca.crt",
+					},
+					ClientCertSecret: &corev1.LocalObjectReference{Name: "client"},
+					ServerName:       "rabbitmq.example.com",
+				},
+				SASLMechanism: "EXTERNAL",
+				Connection: &RabbitmqSourceConnectionSpec{
+					Nodes:      []string{"rabbitmq-0:5671", "rabbitmq-1:5671"},
+					NodeOrder:  NodeOrderRandom,
+					Heartbeat:  &metav1.Duration{Duration: 5 * time.Second},
+					Timeout:    &metav1.Duration{Duration: 10 * time.Second},
+					ChannelMax: 16,
+					Name:       "events-source",
+				},
+				ChannelConfig: RabbitmqChannelConfigSpec{PrefetchCount: 10, GlobalQos: true},
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{
+					Name:        "events",
+					TypeOf:      "headers",
+					Durable:     true,
+					AutoDeleted: true,
+					Internal:    true,
+					NoWait:      true,
+				},
+				QueueConfig: RabbitmqSourceQueueConfigSpec{
+					Name:                 "queue",
+					Durable:              true,
+					Type:                 QueueTypeQuorum,
+					MessageTTL:           &metav1.Duration{Duration: time.Minute},
+					MaxLength:            ptr.Int64(100),
+					MaxLengthBytes:       ptr.Int64(1024),
+					Overflow:             "reject-publish",
+					DeadLetterExchange:   "dlx",
+					DeadLetterRoutingKey: "dead",
+					SingleActiveConsumer: true,
+					DeliveryLimit:        ptr.Int32(5),
+					Arguments:            &runtime.RawExtension{Raw: []byte(`{"x-queue-leader-locator":"balanced"}`)},
+				},
+				Consumer: &RabbitmqSourceConsumerSpec{
+					AckMode:          AckModeBatch,
+					AckBatchSize:     ptr.Int32(20),
+					AckBatchInterval: &metav1.Duration{Duration: time.Second},
+					Tag:              "adapter",
+					Priority:         ptr.Int32(10),
+				},
+				TopologyMode: TopologyModeDeclare,
+				Bindings: []RabbitmqSourceBinding{{
+					Arguments: &runtime.RawExtension{Raw: []byte(`{"x-match":"any","region":"eu"}`)},
+				}, {
+					Exchange:   "audit",
+					RoutingKey: "#",
+				}},
+				Sink:               sink,
+				Scale:              &RabbitmqSourceScaleSpec{MinReplicas: ptr.Int32(1), MaxReplicas: 5},
+				ServiceAccountName: "adapter",
+				PodTemplate: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{NodeSelector: map[string]string{"disk": "ssd"}},
+				},
+				ContentType: "application/json",
+				ForceWrap:   true,
+				Extensions: &RabbitmqSourceExtensionsSpec{
+					Properties: []string{"routingKey", "headers"},
+					Names:      map[string]string{"routingKey": "key"},
+				},
+				Attributes: &RabbitmqSourceAttributesSpec{
+					Type:  "com.example.{{ .routingKey }}",
+					Types: []string{"com.example.a", "com.example.b"},
+				},
+				CloudEventOverrides: &duckv1.CloudEventOverrides{
+					Extensions: map[string]string{"team": "payments"},
+				},
+				Delivery: &eventingduckv1.DeliverySpec{Retry: ptr.Int32(3)},
+				Reply:    &RabbitmqSourceReplySpec{Exchange: "replies", RoutingKey: "key"},
+			},
+			Status: RabbitmqSourceStatus{
+				SourceStatus: duckv1.SourceStatus{
+					Status: duckv1.Status{
+						ObservedGeneration: 2,
+						Conditions: duckv1.Conditions{{
+							Type:   apis.ConditionReady,
+							Status: corev1.ConditionTrue,
+						}},
+					},
+					SinkURI: apis.HTTP("sink.ns.svc.cluster.local"),
+				},
+				DeadLetterSinkURI: apis.HTTP("dls.ns.svc.cluster.local"),
+				Queue:             &RabbitmqSourceQueueStatus{Name: "queue", MessagesReady: 10, MessagesUnacknowledged: 3, Consumers: 2},
+			},
+		},
+		"topic other than the exchange name": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				Brokers:        "amqp://rabbitmq:5672",
+				Topic:          "t",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "ex", TypeOf: "direct"},
+				Sink:           sink,
+			},
+		},
+		"topic exchange without name": {
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        "source",
+				Annotations: map[string]string{"team": "payments"},
+			},
+			Spec: RabbitmqSourceSpec{
+				Brokers:        "amqp://rabbitmq:5672",
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{TypeOf: "topic"},
+				Sink:           sink,
+			},
+		},
+		"topic exchange named apart from the topic": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				Brokers:        "amqp://rabbitmq:5672",
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "events", TypeOf: "topic"},
+				Sink:           sink,
+			},
+		},
+		"bindings to the exchange of the source": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				Brokers:        "amqp://rabbitmq:5672",
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "logs", TypeOf: "topic"},
+				QueueConfig:    RabbitmqSourceQueueConfigSpec{Name: "queue"},
+				Bindings:       []RabbitmqSourceBinding{{RoutingKey: "k"}, {RoutingKey: "audit.#"}},
+				Sink:           sink,
+			},
+		},
+		"connection reference": {
+			ObjectMeta: meta,
+			Spec: RabbitmqSourceSpec{
+				ConnectionRef:  &duckv1.KReference{APIVersion: "rabbitmq.com/v1beta1", Kind: "RabbitmqCluster", Name: "rabbitmq"},
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "logs", TypeOf: "fanout"},
+				Topic:          "logs",
+				Replicas:       ptr.Int32(3),
+				Sink:           sink,
+			},
+		},
+	}
+	for n, want := range tests {
+		t.Run(n, func(t *testing.T) {
+			ver := &v1beta1.RabbitmqSource{}
+			if err := want.ConvertTo(context.Background(), ver); err != nil {
+				t.Fatal("ConvertTo() =", err)
+			}
+			got := &RabbitmqSource{}
+			if err := got.ConvertFrom(context.Background(), ver); err != nil {
+				t.Fatal("ConvertFrom() =", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Error("roundtrip (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceConversionRoundTripV1beta1(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "ns", Name: "source", Generation: 2}
+	sink := duckv1.Destination{
+		Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink"},
+	}
+
+	tests := map[string]*v1beta1.RabbitmqSource{
+		"empty": {},
+		"routing keys": {
+			ObjectMeta: meta,
+			Spec: v1beta1.RabbitmqSourceSpec{
+				SourceSpec: duckv1.SourceSpec{Sink: sink},
+				Connection: v1beta1.RabbitmqSourceConnectionSpec{
+					URI: "amqp://rabbitmq:5672",
+					Credentials: &v1beta1.RabbitmqSourceCredentialsSpec{
+						User: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+							Key:                  "user",
+						},
+						Password: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+							Key:                  "password",
+						},
+					},
+				},
+				Exchange: v1beta1.RabbitmqSourceExchangeSpec{Name: "logs", Type: "topic"},
+				Queue:    v1beta1.RabbitmqSourceQueueSpec{Name: "queue", AutoDelete: true},
+				Bindings: []v1beta1.RabbitmqSourceBinding{{RoutingKey: "*.critical"}, {RoutingKey: "audit.#"}},
+				Channel:  v1beta1.RabbitmqSourceChannelSpec{PrefetchCount: 1},
+			},
+		},
+		"bindings": {
+			ObjectMeta: meta,
+			Spec: v1beta1.RabbitmqSourceSpec{
+				SourceSpec: duckv1.SourceSpec{
+					Sink:                sink,
+					CloudEventOverrides: &duckv1.CloudEventOverrides{Extensions: map[string]string{"team": "payments"}},
+				},
+				Connection: v1beta1.RabbitmqSourceConnectionSpec{URI: "amqp://rabbitmq:5672"},
+				Exchange:   v1beta1.RabbitmqSourceExchangeSpec{Name: "events", Type: "fanout"},
+				Bindings:   []v1beta1.RabbitmqSourceBinding{{}},
+				Attributes: &v1beta1.RabbitmqSourceAttributesSpec{Source: "/rabbitmq/events"},
+			},
+			Status: v1beta1.RabbitmqSourceStatus{
+				SourceStatus: duckv1.SourceStatus{SinkURI: apis.HTTP("sink.ns.svc.cluster.local")},
+				Queue:        &v1beta1.RabbitmqSourceQueueStatus{Consumers: 1},
+			},
+		},
+	}
+	for n, want := range tests {
+		t.Run(n, func(t *testing.T) {
+			ver := &RabbitmqSource{}
+			if err := ver.ConvertFrom(context.Background(), want); err != nil {
+				t.Fatal("ConvertFrom() =", err)
+			}
+			got := &v1beta1.RabbitmqSource{}
+			if err := ver.ConvertTo(context.Background(), got); err != nil {
+				t.Fatal("ConvertTo() =", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Error("roundtrip (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceConvertTo(t *testing.T) {
+	meta := metav1.ObjectMeta{Namespace: "ns", Name: "source"}
+
+	tests := map[string]struct {
+		spec            RabbitmqSourceSpec
+		want            v1beta1.RabbitmqSourceSpec
+		wantAnnotations map[string]string
+	}{
+		"routing keys become bindings": {
+			spec: RabbitmqSourceSpec{
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{TypeOf: "topic"},
+				QueueConfig:    RabbitmqSourceQueueConfigSpec{RoutingKey: "a,b"},
+			},
+			want: v1beta1.RabbitmqSourceSpec{
+				Exchange: v1beta1.RabbitmqSourceExchangeSpec{Name: "logs", Type: "topic"},
+				Bindings: []v1beta1.RabbitmqSourceBinding{{RoutingKey: "a"}, {RoutingKey: "b"}},
+			},
+			wantAnnotations: map[string]string{exchangeNameAnnotation: ""},
+		},
+		"bindings to the exchange of the source are annotated": {
+			spec: RabbitmqSourceSpec{
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "logs", TypeOf: "topic"},
+				Bindings:       []RabbitmqSourceBinding{{RoutingKey: "k"}},
+			},
+			want: v1beta1.RabbitmqSourceSpec{
+				Exchange: v1beta1.RabbitmqSourceExchangeSpec{Name: "logs", Type: "topic"},
+				Bindings: []v1beta1.RabbitmqSourceBinding{{RoutingKey: "k"}},
+			},
+			wantAnnotations: map[string]string{bindingsAnnotation: "true"},
+		},
+		"topic of another exchange is annotated": {
+			spec: RabbitmqSourceSpec{
+				Topic:          "orders",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{Name: "logs", TypeOf: "fanout"},
+			},
+			want: v1beta1.RabbitmqSourceSpec{
+				Exchange: v1beta1.RabbitmqSourceExchangeSpec{Name: "logs", Type: "fanout"},
+			},
+			wantAnnotations: map[string]string{topicAnnotation: "orders"},
+		},
+		"topic exchange without name is annotated": {
+			spec: RabbitmqSourceSpec{
+				Topic:          "logs",
+				ExchangeConfig: RabbitmqSourceExchangeConfigSpec{TypeOf: "topic"},
+			},
+			want: v1beta1.RabbitmqSourceSpec{
+				Exchange: v1beta1.RabbitmqSourceExchangeSpec{Name: "logs", Type: "topic"},
+			},
+			wantAnnotations: map[string]string{exchangeNameAnnotation: ""},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{ObjectMeta: meta, Spec: tc.spec}
+			got := &v1beta1.RabbitmqSource{}
+			if err := src.ConvertTo(context.Background(), got); err != nil {
+				t.Fatal("ConvertTo() =", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Spec); diff != "" {
+				t.Error("ConvertTo (-want, +got) =", diff)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, got.Annotations); diff != "" {
+				t.Error("ConvertTo annotations (-want, +got) =", diff)
+			}
+			if src.Annotations != nil {
+				t.Error("ConvertTo modified the annotations of the source:", src.Annotations)
+			}
+		})
+	}
+}
@@ -16,8 +16,70 @@ limitations under the License.
 
 package v1alpha1
 
-import "context"
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// DefaultExchangeType is the type of the exchange of sources that don't
+	// set one.
+	DefaultExchangeType = "topic"
+	// DefaultPrefetchCount is the number of unacknowledged messages delivered
+	// to the receive adapter, which sends them to the sink one at a time.
+	DefaultPrefetchCount = 1
+	// DefaultStreamPrefetchCount is the prefetch count of stream queues,
+	// which require one to be set.
+	DefaultStreamPrefetchCount = 100
+	// DefaultServiceAccountName is the ServiceAccount running the receive
+	// adapter.
+	DefaultServiceAccountName = "default"
+)
 
 func (r *RabbitmqSource) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, r.ObjectMeta)
+	r.Spec.SetDefaults(ctx)
+}
+
+func (s *RabbitmqSourceSpec) SetDefaults(ctx context.Context) {
+	if s.ExchangeConfig.TypeOf == "" {
+		s.ExchangeConfig.TypeOf = DefaultExchangeType
+	}
+	// Quorum queues and streams can only be durable.
+	if s.QueueConfig.Type == QueueTypeQuorum || s.QueueConfig.Type == QueueTypeStream {
+		s.QueueConfig.Durable = true
+	}
+	if s.Consumer != nil {
+		s.Consumer.SetDefaults(ctx)
+	}
+	if s.ChannelConfig.PrefetchCount == 0 {
+		s.ChannelConfig.PrefetchCount = DefaultPrefetchCount
+		if s.QueueConfig.Type == QueueTypeStream {
+			s.ChannelConfig.PrefetchCount = DefaultStreamPrefetchCount
+		}
+		// Batches are only acknowledged once they are all delivered.
+		if s.Consumer.GetAckMode() == AckModeBatch && int(s.Consumer.GetAckBatchSize()) > s.ChannelConfig.PrefetchCount {
+			s.ChannelConfig.PrefetchCount = int(s.Consumer.GetAckBatchSize())
+		}
+	}
+	if s.TopologyMode == "" {
+		s.TopologyMode = TopologyModeDeclare
+	}
+	if s.ServiceAccountName == "" {
+		s.ServiceAccountName = DefaultServiceAccountName
+	}
+
+	if s.Sink != nil {
+		s.Sink.SetDefaults(ctx)
+	}
+	if s.Delivery != nil && s.Delivery.DeadLetterSink != nil {
+		s.Delivery.DeadLetterSink.SetDefaults(ctx)
+	}
+}
 
+func (c *RabbitmqSourceConsumerSpec) SetDefaults(ctx context.Context) {
+	defaulted := c.toV1beta1()
+	defaulted.SetDefaults(ctx)
+	c.convertFrom(defaulted)
 }
@@ -31,11 +31,21 @@ const (
 	RabbitmqConditionDeployed apis.ConditionType = "Deployed"
 
 	RabbitmqConditionResources apis.ConditionType = "ResourcesReady"
+
+	// RabbitmqConditionTopologyReady is true when the exchange, queue and
+	// bindings are declared by the source or exist in RabbitMQ.
+	RabbitmqConditionTopologyReady apis.ConditionType = "TopologyReady"
+
+	// RabbitmqConditionConnected is true when the receive adapter, or the
+	// controller until the adapter reports, is connected to RabbitMQ.
+	RabbitmqConditionConnected apis.ConditionType = "ConnectedToRabbitMQ"
 )
 
 var RabbitmqSourceCondSet = apis.NewLivingConditionSet(
 	RabbitmqConditionSinkProvided,
-	RabbitmqConditionDeployed)
+	RabbitmqConditionDeployed,
+	RabbitmqConditionTopologyReady,
+	RabbitmqConditionConnected)
 
 func (s *RabbitmqSourceStatus) GetCondition(t apis.ConditionType) *apis.Condition {
 	return RabbitmqSourceCondSet.Manage(s).GetCondition(t)
@@ -74,6 +84,17 @@ func (s *RabbitmqSourceStatus) MarkNoSink(reason, messageFormat string, messageA
 	RabbitmqSourceCondSet.Manage(s).MarkFalse(RabbitmqConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+func (s *RabbitmqSourceStatus) MarkDeadLetterSink(uri *apis.URL) {
+	s.DeadLetterSinkURI = uri
+}
+
+// MarkNoDeadLetterSink marks the sink as not provided, as the delivery of
+// events can't be set up without the dead letter sink.
+func (s *RabbitmqSourceStatus) MarkNoDeadLetterSink(reason, messageFormat string, messageA ...interface{}) {
+	s.DeadLetterSinkURI = nil
+	RabbitmqSourceCondSet.Manage(s).MarkFalse(RabbitmqConditionSinkProvided, reason, messageFormat, messageA...)
+}
+
 func DeploymentIsAvailable(d *appsv1.DeploymentStatus, def bool) bool {
 	for _, cond := range d.Conditions {
 		if cond.Type == appsv1.DeploymentAvailable {
@@ -106,3 +127,19 @@ func (s *RabbitmqSourceStatus) MarkResourcesCorrect() {
 func (s *RabbitmqSourceStatus) MarkResourcesIncorrect(reason, messageFormat string, messageA ...interface{}) {
 	RabbitmqSourceCondSet.Manage(s).MarkFalse(RabbitmqConditionResources, reason, messageFormat, messageA...)
 }
+
+func (s *RabbitmqSourceStatus) MarkTopologyReady() {
+	RabbitmqSourceCondSet.Manage(s).MarkTrue(RabbitmqConditionTopologyReady)
+}
+
+func (s *RabbitmqSourceStatus) MarkTopologyNotReady(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqSourceCondSet.Manage(s).MarkFalse(RabbitmqConditionTopologyReady, reason, messageFormat, messageA...)
+}
+
+func (s *RabbitmqSourceStatus) MarkConnected() {
+	RabbitmqSourceCondSet.Manage(s).MarkTrue(RabbitmqConditionConnected)
+}
+
+func (s *RabbitmqSourceStatus) MarkNotConnected(reason, messageFormat string, messageA ...interface{}) {
+	RabbitmqSourceCondSet.Manage(s).MarkFalse(RabbitmqConditionConnected, reason, messageFormat, messageA...)
+}
@@ -72,6 +72,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s := &RabbitmqSourceStatus{}
 			s.InitializeConditions()
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			return s
 		}(),
 		condQuery: RabbitmqConditionReady,
@@ -111,6 +113,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			return s
 		}(),
 		condQuery: RabbitmqConditionReady,
@@ -125,6 +129,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			s.MarkNoSink("Testing", "hi%s", "")
 			return s
 		}(),
@@ -135,6 +141,26 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			Reason:  "Testing",
 			Message: "hi",
 		},
+	}, {
+		name: "mark sink and deployed then no dead letter sink",
+		s: func() *RabbitmqSourceStatus {
+			s := &RabbitmqSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("uri://example"))
+			s.MarkDeadLetterSink(apis.HTTP("uri://dls"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
+			s.MarkNoDeadLetterSink("Testing", "hi%s", "")
+			return s
+		}(),
+		condQuery: RabbitmqConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Testing",
+			Message: "hi",
+		},
 	}, {
 		name: "mark sink and deployed and event types then deploying",
 		s: func() *RabbitmqSourceStatus {
@@ -142,6 +168,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			s.MarkDeploying("Testing", "hi%s", "")
 			return s
 		}(),
@@ -159,6 +187,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			s.MarkNotDeployed("Testing", "hi%s", "")
 			return s
 		}(),
@@ -176,6 +206,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(apis.HTTP("uri://example"))
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			return s
 		}(),
 		condQuery: RabbitmqConditionReady,
@@ -192,6 +224,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.MarkNotDeployed("MarkNotDeployed", "%s", "")
 			s.MarkDeploying("MarkDeploying", "%s", "")
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			return s
 		}(),
 		condQuery: RabbitmqConditionReady,
@@ -206,6 +240,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(nil)
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			return s
 		}(),
 		condQuery: RabbitmqConditionReady,
@@ -222,6 +258,8 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			s.InitializeConditions()
 			s.MarkSink(nil)
 			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkConnected()
 			s.MarkSink(apis.HTTP("uri://example"))
 			return s
 		}(),
@@ -230,6 +268,41 @@ func TestRabbitmqSourceStatusGetCondition(t *testing.T) {
 			Type:   RabbitmqConditionReady,
 			Status: corev1.ConditionTrue,
 		},
+	}, {
+		name: "mark sink and deployed then topology not ready",
+		s: func() *RabbitmqSourceStatus {
+			s := &RabbitmqSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("uri://example"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyNotReady("TopologyNotFound", "queue %q not found", "q")
+			return s
+		}(),
+		condQuery: RabbitmqConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "TopologyNotFound",
+			Message: `queue "q" not found`,
+		},
+	}, {
+		name: "mark sink, deployed and topology ready then not connected",
+		s: func() *RabbitmqSourceStatus {
+			s := &RabbitmqSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(apis.HTTP("uri://example"))
+			s.MarkDeployed(availableDeployment)
+			s.MarkTopologyReady()
+			s.MarkNotConnected("ConnectionFailed", "failed to connect to RabbitMQ")
+			return s
+		}(),
+		condQuery: RabbitmqConditionReady,
+		want: &apis.Condition{
+			Type:    RabbitmqConditionReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  "ConnectionFailed",
+			Message: "failed to connect to RabbitMQ",
+		},
 	}}
 
 	for _, test := range tests {
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeclareArguments returns the arguments of the queue declaration, other
+// than its type: the raw arguments overridden by the typed fields.
+func (q *RabbitmqSourceQueueConfigSpec) DeclareArguments() (map[string]interface{}, error) {
+	args, err := rawArguments(q.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.MessageTTL != nil {
+		args["x-message-ttl"] = q.MessageTTL.Milliseconds()
+	}
+	if q.MaxLength != nil {
+		args["x-max-length"] = *q.MaxLength
+	}
+	if q.MaxLengthBytes != nil {
+		args["x-max-length-bytes"] = *q.MaxLengthBytes
+	}
+	if q.Overflow != "" {
+		args["x-overflow"] = q.Overflow
+	}
+	if q.DeadLetterExchange != "" {
+		args["x-dead-letter-exchange"] = q.DeadLetterExchange
+	}
+	if q.DeadLetterRoutingKey != "" {
+		args["x-dead-letter-routing-key"] = q.DeadLetterRoutingKey
+	}
+	if q.SingleActiveConsumer {
+		args["x-single-active-consumer"] = true
+	}
+	if q.DeliveryLimit != nil {
+		args["x-delivery-limit"] = int64(*q.DeliveryLimit)
+	}
+	return args, nil
+}
+
+// DeclareArguments returns the arguments of the binding.
+func (b *RabbitmqSourceBinding) DeclareArguments() (map[string]interface{}, error) {
+	return rawArguments(b.Arguments)
+}
+
+// rawArguments decodes arguments given as a JSON object.
+func rawArguments(raw *runtime.RawExtension) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	if raw != nil && len(raw.Raw) > 0 {
+		if err := json.Unmarshal(raw.Raw, &args); err != nil {
+			return nil, fmt.Errorf("arguments must be a JSON object: %w", err)
+		}
+		if args == nil {
+			args = map[string]interface{}{}
+		}
+	}
+	return args, nil
+}
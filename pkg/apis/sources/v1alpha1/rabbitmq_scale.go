@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "time"
+
+func (s *RabbitmqSourceScaleSpec) GetMinReplicas() int32 {
+	if s.MinReplicas == nil {
+		return 0
+	}
+	return *s.MinReplicas
+}
+
+func (s *RabbitmqSourceScaleSpec) GetMessagesPerReplica() int32 {
+	if s.MessagesPerReplica == nil {
+		return DefaultMessagesPerReplica
+	}
+	return *s.MessagesPerReplica
+}
+
+func (s *RabbitmqSourceScaleSpec) GetPollingInterval() time.Duration {
+	if s.PollingInterval == nil {
+		return DefaultScalePollingInterval
+	}
+	return s.PollingInterval.Duration
+}
+
+func (s *RabbitmqSourceScaleSpec) GetCooldownPeriod() time.Duration {
+	if s.CooldownPeriod == nil {
+		return DefaultScaleCooldownPeriod
+	}
+	return s.CooldownPeriod.Duration
+}
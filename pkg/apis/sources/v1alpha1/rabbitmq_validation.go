@@ -18,28 +18,150 @@ package v1alpha1
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
 	"knative.dev/pkg/apis"
-	"knative.dev/pkg/kmp"
+
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
 )
 
+// alphaFields are the v1alpha1 fields of the v1beta1 fields, or of their
+// parents, whose names differ. More specific fields come first.
+var alphaFields = []struct{ beta, alpha string }{
+	{"connection.uri", "brokers"},
+	{"connection.ref", "connectionRef"},
+	{"connection.credentials.user", "user.secretKeyRef"},
+	{"connection.credentials.password", "password.secretKeyRef"},
+	{"connection.vhost", "vhost"},
+	{"connection.tls", "tls"},
+	{"connection.saslMechanism", "saslMechanism"},
+	{"exchange.autoDelete", "exchange_config.auto_deleted"},
+	{"exchange.noWait", "exchange_config.nowait"},
+	{"exchange", "exchange_config"},
+	{"queue.autoDelete", "queue_config.delete_when_unused"},
+	{"queue.noWait", "queue_config.nowait"},
+	{"queue.streamOffset", "queue_config.stream_offset"},
+	{"queue.messageTTL", "queue_config.message_ttl"},
+	{"queue.maxLength", "queue_config.max_length"},
+	{"queue.maxLengthBytes", "queue_config.max_length_bytes"},
+	{"queue.deadLetterExchange", "queue_config.dead_letter_exchange"},
+	{"queue.deadLetterRoutingKey", "queue_config.dead_letter_routing_key"},
+	{"queue.singleActiveConsumer", "queue_config.single_active_consumer"},
+	{"queue.deliveryLimit", "queue_config.delivery_limit"},
+	{"queue", "queue_config"},
+	{"channel.prefetchCount", "channel_config.prefetch_count"},
+	{"channel.globalQos", "channel_config.global_qos"},
+	{"channel", "channel_config"},
+}
+
 func (current *RabbitmqSource) Validate(ctx context.Context) *apis.FieldError {
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*RabbitmqSource)
-		if diff, err := kmp.ShortDiff(original.Spec, current.Spec); err != nil {
-			return &apis.FieldError{
-				Message: "Failed to diff RabbitmqSource",
-				Paths:   []string{"spec"},
-				Details: err.Error(),
-			}
-		} else if diff != "" {
-			return &apis.FieldError{
-				Message: "Immutable fields changed (-old +new)",
-				Paths:   []string{"spec"},
-				Details: diff,
+		if errs := current.CheckImmutableFields(original); errs != nil {
+			return errs.ViaField("spec")
+		}
+	}
+	return current.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks the spec as the v1beta1 spec it converts to, with the
+// paths of the v1alpha1 fields, and the routing keys of the queue that only
+// v1alpha1 sources have.
+func (s *RabbitmqSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	to := &v1beta1.RabbitmqSourceSpec{}
+	s.convertTo(to)
+	errs := s.alphaErrors(to.Validate(ctx))
+
+	if key := s.QueueConfig.RoutingKey; key != "" {
+		if len(s.Bindings) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("bindings", "queue_config.routing_key"))
+		} else {
+			for _, k := range strings.Split(key, ",") {
+				if k == "" {
+					errs = errs.Also(invalidValue(key, "queue_config.routing_key", "empty routing key in the list"))
+					break
+				}
 			}
 		}
 	}
+	return errs
+}
+
+// CheckImmutableFields rejects the changes to the fields identifying the
+// exchange, queue and bindings declared by the source, as the ones of the
+// v1beta1 sources they convert to: the topic names topic exchanges and the
+// routing keys of the queue are its bindings.
+func (current *RabbitmqSource) CheckImmutableFields(original *RabbitmqSource) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+	cur, orig := &v1beta1.RabbitmqSource{}, &v1beta1.RabbitmqSource{}
+	current.Spec.convertTo(&cur.Spec)
+	original.Spec.convertTo(&orig.Spec)
+	return current.Spec.alphaErrors(cur.CheckImmutableFields(orig))
+}
+
+func (c *RabbitmqSourceConsumerSpec) Validate(ctx context.Context) *apis.FieldError {
+	return c.toV1beta1().Validate(ctx)
+}
+
+// alphaErrors returns errs, the errors of the v1beta1 spec that s converts
+// to, with the paths of the v1alpha1 fields.
+func (s *RabbitmqSourceSpec) alphaErrors(errs *apis.FieldError) *apis.FieldError {
+	if errs == nil {
+		return nil
+	}
+	return s.alphaError(reflect.ValueOf(errs).Elem())
+}
+
+// alphaError rebuilds the FieldError fe, whose collected errors aren't
+// exported, with the paths of the v1alpha1 fields.
+func (s *RabbitmqSourceSpec) alphaError(fe reflect.Value) *apis.FieldError {
+	var errs *apis.FieldError
+	if message := fe.FieldByName("Message").String(); message != "" {
+		err := &apis.FieldError{
+			Message: message,
+			Details: fe.FieldByName("Details").String(),
+		}
+		paths := fe.FieldByName("Paths")
+		for i := 0; i < paths.Len(); i++ {
+			err.Paths = append(err.Paths, s.alphaPaths(paths.Index(i).String())...)
+		}
+		errs = err
+	}
+	collected := fe.FieldByName("errors")
+	for i := 0; i < collected.Len(); i++ {
+		errs = errs.Also(s.alphaError(collected.Index(i)))
+	}
+	return errs
+}
+
+// alphaPaths returns the paths of the v1alpha1 fields converted into the
+// v1beta1 field path.
+func (s *RabbitmqSourceSpec) alphaPaths(path string) []string {
+	switch {
+	case path == "connection.credentials":
+		return []string{"user", "password"}
+	case path == "exchange.name" && s.ExchangeConfig.TypeOf == "topic":
+		return []string{"topic"}
+	case len(s.Bindings) == 0 && path == "bindings":
+		return []string{"queue_config.routing_key", "bindings"}
+	case len(s.Bindings) == 0 && strings.HasPrefix(path, "bindings["):
+		// The bindings converted from the routing keys of the queue.
+		return []string{"queue_config.routing_key"}
+	}
+	for _, f := range alphaFields {
+		if path == f.beta || strings.HasPrefix(path, f.beta+".") || strings.HasPrefix(path, f.beta+"[") {
+			return []string{f.alpha + strings.TrimPrefix(path, f.beta)}
+		}
+	}
+	return []string{path}
+}
 
-	return nil
+// invalidValue returns an invalid value error explaining what's expected.
+func invalidValue(value interface{}, field, details string) *apis.FieldError {
+	err := apis.ErrInvalidValue(value, field)
+	err.Details = details
+	return err
 }
@@ -18,8 +18,14 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -55,117 +61,104 @@ var (
 	}
 )
 
+// withRequiredFields completes the spec with the brokers, sink and exchange
+// type that are required by validation.
+func withRequiredFields(spec RabbitmqSourceSpec) RabbitmqSourceSpec {
+	if spec.Brokers == "" && spec.ConnectionRef == nil {
+		spec.Brokers = fullSpec.Brokers
+	}
+	if spec.Sink == nil {
+		spec.Sink = fullSpec.Sink
+	}
+	if spec.ExchangeConfig.TypeOf == "" {
+		spec.ExchangeConfig.TypeOf = "fanout"
+	}
+	return spec
+}
+
 func TestRabbitmqSourceCheckImmutableFields(t *testing.T) {
 	testCases := map[string]struct {
 		orig    *RabbitmqSourceSpec
-		updated RabbitmqSourceSpec
+		update  func(*RabbitmqSourceSpec)
 		allowed bool
 	}{
 		"nil orig": {
-			updated: fullSpec,
+			update:  func(*RabbitmqSourceSpec) {},
 			allowed: true,
 		},
-		"Topic changed": {
-			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic:              "some-other-topic",
-				Sink:               fullSpec.Sink,
-				ServiceAccountName: fullSpec.ServiceAccountName,
-			},
-			allowed: false,
+		"no change": {
+			orig:    &fullSpec,
+			update:  func(*RabbitmqSourceSpec) {},
+			allowed: true,
 		},
 		"Brokers changed": {
-			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Brokers:            "broker1",
-				Sink:               fullSpec.Sink,
-				ServiceAccountName: fullSpec.ServiceAccountName,
-			},
-			allowed: false,
-		},
-		"Sink.APIVersion changed": {
-			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic: fullSpec.Topic,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: "some-other-api-version",
-						Kind:       fullSpec.Sink.Ref.APIVersion,
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
-			},
-			allowed: false,
-		},
-		"Sink.Kind changed": {
-			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic: fullSpec.Topic,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       "some-other-kind",
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
-			},
-			allowed: false,
-		},
-		"Sink.Namespace changed": {
-			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic: fullSpec.Topic,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       fullSpec.Sink.Ref.Kind,
-						Namespace:  "some-other-namespace",
-						Name:       fullSpec.Sink.Ref.Name,
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
-			},
-			allowed: false,
+			orig:    &fullSpec,
+			update:  func(s *RabbitmqSourceSpec) { s.Brokers = "amqp://broker1" },
+			allowed: true,
 		},
-		"Sink.Name changed": {
+		"Sink changed": {
 			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic: fullSpec.Topic,
-				Sink: &duckv1.Destination{
+			update: func(s *RabbitmqSourceSpec) {
+				s.Sink = &duckv1.Destination{
 					Ref: &duckv1.KReference{
 						APIVersion: fullSpec.Sink.Ref.APIVersion,
 						Kind:       fullSpec.Sink.Ref.Kind,
 						Namespace:  fullSpec.Sink.Ref.Namespace,
 						Name:       "some-other-name",
 					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+				}
 			},
-			allowed: false,
+			allowed: true,
 		},
 		"ServiceAccountName changed": {
+			orig:    &fullSpec,
+			update:  func(s *RabbitmqSourceSpec) { s.ServiceAccountName = "some-other-name" },
+			allowed: true,
+		},
+		"ChannelConfig changed": {
+			orig:    &fullSpec,
+			update:  func(s *RabbitmqSourceSpec) { s.ChannelConfig.PrefetchCount = 10 },
+			allowed: true,
+		},
+		"QueueConfig.NoWait changed": {
+			orig:    &fullSpec,
+			update:  func(s *RabbitmqSourceSpec) { s.QueueConfig.NoWait = true },
+			allowed: true,
+		},
+		"Topic of a topic exchange changed": {
+			orig:   &fullSpec,
+			update: func(s *RabbitmqSourceSpec) { s.Topic = "some-other-topic" },
+		},
+		"ExchangeConfig changed": {
+			orig:   &fullSpec,
+			update: func(s *RabbitmqSourceSpec) { s.ExchangeConfig.Durable = false },
+		},
+		"ExchangeConfig.Name of topic exchange changed": {
+			orig:    &fullSpec,
+			update:  func(s *RabbitmqSourceSpec) { s.ExchangeConfig.Name = fullSpec.Topic },
+			allowed: true,
+		},
+		"QueueConfig changed": {
+			orig:   &fullSpec,
+			update: func(s *RabbitmqSourceSpec) { s.QueueConfig.Name = "some-other-name" },
+		},
+		"QueueConfig.RoutingKey changed": {
+			orig:   &fullSpec,
+			update: func(s *RabbitmqSourceSpec) { s.QueueConfig.RoutingKey = "*.info" },
+		},
+		"Bindings changed": {
 			orig: &fullSpec,
-			updated: RabbitmqSourceSpec{
-				Topic: fullSpec.Topic,
-				Sink: &duckv1.Destination{
-					Ref: &duckv1.KReference{
-						APIVersion: fullSpec.Sink.Ref.APIVersion,
-						Kind:       fullSpec.Sink.Ref.Kind,
-						Namespace:  fullSpec.Sink.Ref.Namespace,
-						Name:       "some-other-name",
-					},
-				},
-				ServiceAccountName: fullSpec.ServiceAccountName,
+			update: func(s *RabbitmqSourceSpec) {
+				s.QueueConfig.RoutingKey = ""
+				s.Bindings = []RabbitmqSourceBinding{{RoutingKey: "*.info"}}
 			},
-			allowed: false,
 		},
-		"no change": {
-			orig:    &fullSpec,
-			updated: fullSpec,
+		"QueueConfig.RoutingKey moved to the same bindings": {
+			orig: &fullSpec,
+			update: func(s *RabbitmqSourceSpec) {
+				s.QueueConfig.RoutingKey = ""
+				s.Bindings = []RabbitmqSourceBinding{{RoutingKey: "*.critical"}}
+			},
 			allowed: true,
 		},
 	}
@@ -180,8 +173,9 @@ func TestRabbitmqSourceCheckImmutableFields(t *testing.T) {
 				ctx = apis.WithinUpdate(ctx, orig)
 			}
 			updated := &RabbitmqSource{
-				Spec: tc.updated,
+				Spec: *fullSpec.DeepCopy(),
 			}
+			tc.update(&updated.Spec)
 			err := updated.Validate(ctx)
 			if tc.allowed != (err == nil) {
 				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
@@ -189,3 +183,751 @@ func TestRabbitmqSourceCheckImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func TestRabbitmqSourceQueueConfigValidation(t *testing.T) {
+	negative := int64(-1)
+	limit := int32(3)
+	testCases := map[string]struct {
+		queue   RabbitmqSourceQueueConfigSpec
+		allowed bool
+	}{
+		"classic queue": {
+			queue:   RabbitmqSourceQueueConfigSpec{Exclusive: true, MaxLength: &[]int64{10}[0], Overflow: "reject-publish-dlx"},
+			allowed: true,
+		},
+		"quorum queue": {
+			queue:   RabbitmqSourceQueueConfigSpec{Type: "quorum", Durable: true, DeliveryLimit: &limit},
+			allowed: true,
+		},
+		"stream queue": {
+			queue:   RabbitmqSourceQueueConfigSpec{Type: "stream", Durable: true, StreamOffset: "first"},
+			allowed: true,
+		},
+		"unknown type": {
+			queue: RabbitmqSourceQueueConfigSpec{Type: "lazy"},
+		},
+		"transient quorum queue": {
+			queue: RabbitmqSourceQueueConfigSpec{Type: "quorum"},
+		},
+		"exclusive stream queue": {
+			queue: RabbitmqSourceQueueConfigSpec{Type: "stream", Durable: true, Exclusive: true},
+		},
+		"stream offset of classic queue": {
+			queue: RabbitmqSourceQueueConfigSpec{StreamOffset: "first"},
+		},
+		"negative max length": {
+			queue: RabbitmqSourceQueueConfigSpec{MaxLength: &negative},
+		},
+		"negative message TTL": {
+			queue: RabbitmqSourceQueueConfigSpec{MessageTTL: &metav1.Duration{Duration: -time.Second}},
+		},
+		"unknown overflow": {
+			queue: RabbitmqSourceQueueConfigSpec{Overflow: "drop-tail"},
+		},
+		"reject-publish-dlx quorum queue": {
+			queue: RabbitmqSourceQueueConfigSpec{Type: "quorum", Durable: true, Overflow: "reject-publish-dlx"},
+		},
+		"dead letter routing key without exchange": {
+			queue: RabbitmqSourceQueueConfigSpec{DeadLetterRoutingKey: "key"},
+		},
+		"delivery limit of classic queue": {
+			queue: RabbitmqSourceQueueConfigSpec{DeliveryLimit: &limit},
+		},
+		"arguments": {
+			queue:   RabbitmqSourceQueueConfigSpec{Arguments: &runtime.RawExtension{Raw: []byte(`{"x-queue-mode": "lazy"}`)}},
+			allowed: true,
+		},
+		"arguments not an object": {
+			queue: RabbitmqSourceQueueConfigSpec{Arguments: &runtime.RawExtension{Raw: []byte(`["x-queue-mode"]`)}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(RabbitmqSourceSpec{QueueConfig: tc.queue})}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceQueueConfigDeclareArguments(t *testing.T) {
+	maxLength := int64(100)
+	limit := int32(5)
+	queue := RabbitmqSourceQueueConfigSpec{
+		Type:                 "quorum",
+		MessageTTL:           &metav1.Duration{Duration: time.Minute},
+		MaxLength:            &maxLength,
+		Overflow:             "reject-publish",
+		DeadLetterExchange:   "dlx",
+		DeadLetterRoutingKey: "dead",
+		SingleActiveConsumer: true,
+		DeliveryLimit:        &limit,
+		Arguments:            &runtime.RawExtension{Raw: []byte(`{"x-max-length": 1, "x-queue-leader-locator": "balanced"}`)},
+	}
+
+	got, err := queue.DeclareArguments()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"x-message-ttl":             int64(60000),
+		"x-max-length":              int64(100),
+		"x-overflow":                "reject-publish",
+		"x-dead-letter-exchange":    "dlx",
+		"x-dead-letter-routing-key": "dead",
+		"x-single-active-consumer":  true,
+		"x-delivery-limit":          int64(5),
+		"x-queue-leader-locator":    "balanced",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected arguments (-want, +got) = %v", diff)
+	}
+}
+
+func TestRabbitmqSourceBindingsValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec    RabbitmqSourceSpec
+		allowed bool
+	}{
+		"bindings": {
+			spec: RabbitmqSourceSpec{Bindings: []RabbitmqSourceBinding{{
+				Exchange:  "headers",
+				Arguments: &runtime.RawExtension{Raw: []byte(`{"x-match": "any", "region": "eu"}`)},
+			}, {
+				RoutingKey: "key",
+			}}},
+			allowed: true,
+		},
+		"bindings and routing key": {
+			spec: RabbitmqSourceSpec{
+				QueueConfig: RabbitmqSourceQueueConfigSpec{RoutingKey: "key"},
+				Bindings:    []RabbitmqSourceBinding{{RoutingKey: "key"}},
+			},
+		},
+		"invalid x-match": {
+			spec: RabbitmqSourceSpec{Bindings: []RabbitmqSourceBinding{{
+				Arguments: &runtime.RawExtension{Raw: []byte(`{"x-match": "some"}`)},
+			}}},
+		},
+		"arguments not an object": {
+			spec: RabbitmqSourceSpec{Bindings: []RabbitmqSourceBinding{{
+				Arguments: &runtime.RawExtension{Raw: []byte(`"x-match"`)},
+			}}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(tc.spec)}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceTopologyModeValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec    RabbitmqSourceSpec
+		allowed bool
+	}{
+		"default": {
+			spec:    RabbitmqSourceSpec{},
+			allowed: true,
+		},
+		"declare": {
+			spec:    RabbitmqSourceSpec{TopologyMode: TopologyModeDeclare},
+			allowed: true,
+		},
+		"passive": {
+			spec: RabbitmqSourceSpec{
+				TopologyMode: TopologyModePassive,
+				QueueConfig:  RabbitmqSourceQueueConfigSpec{Name: "queue"},
+			},
+			allowed: true,
+		},
+		"none": {
+			spec: RabbitmqSourceSpec{
+				TopologyMode: TopologyModeNone,
+				QueueConfig:  RabbitmqSourceQueueConfigSpec{Name: "queue"},
+			},
+			allowed: true,
+		},
+		"passive without queue name": {
+			spec: RabbitmqSourceSpec{TopologyMode: TopologyModePassive},
+		},
+		"none without queue name": {
+			spec: RabbitmqSourceSpec{TopologyMode: TopologyModeNone},
+		},
+		"unknown mode": {
+			spec: RabbitmqSourceSpec{
+				TopologyMode: "lazy",
+				QueueConfig:  RabbitmqSourceQueueConfigSpec{Name: "queue"},
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(tc.spec)}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceAttributesValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec    RabbitmqSourceSpec
+		allowed bool
+	}{
+		"attributes": {
+			spec: RabbitmqSourceSpec{Attributes: &RabbitmqSourceAttributesSpec{
+				Type:    "com.example.{{ .routingKey }}",
+				Source:  "/orders",
+				Subject: "{{ .headers.region }}",
+				ID:      "{{ .messageId }}",
+				Types:   []string{"com.example.created"},
+			}},
+			allowed: true,
+		},
+		"invalid template": {
+			spec: RabbitmqSourceSpec{Attributes: &RabbitmqSourceAttributesSpec{
+				Subject: "{{ .headers.region",
+			}},
+		},
+		"types without templated type": {
+			spec: RabbitmqSourceSpec{Attributes: &RabbitmqSourceAttributesSpec{
+				Type:  "com.example",
+				Types: []string{"com.example"},
+			}},
+		},
+		"empty type": {
+			spec: RabbitmqSourceSpec{Attributes: &RabbitmqSourceAttributesSpec{
+				Type:  "{{ .type }}",
+				Types: []string{""},
+			}},
+		},
+		"overrides": {
+			spec: RabbitmqSourceSpec{CloudEventOverrides: &duckv1.CloudEventOverrides{
+				Extensions: map[string]string{"team": "billing"},
+			}},
+			allowed: true,
+		},
+		"invalid extension name": {
+			spec: RabbitmqSourceSpec{CloudEventOverrides: &duckv1.CloudEventOverrides{
+				Extensions: map[string]string{"Team-Name": "billing"},
+			}},
+		},
+		"context attribute override": {
+			spec: RabbitmqSourceSpec{CloudEventOverrides: &duckv1.CloudEventOverrides{
+				Extensions: map[string]string{"type": "com.example"},
+			}},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(tc.spec)}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceReplicasValidation(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	namedQueue := RabbitmqSourceQueueConfigSpec{Name: "queue", Durable: true}
+
+	testCases := map[string]struct {
+		spec    RabbitmqSourceSpec
+		allowed bool
+	}{
+		"single replica of an unnamed queue": {
+			spec:    RabbitmqSourceSpec{Replicas: int32Ptr(1)},
+			allowed: true,
+		},
+		"replicas": {
+			spec:    RabbitmqSourceSpec{Replicas: int32Ptr(3), QueueConfig: namedQueue},
+			allowed: true,
+		},
+		"negative replicas": {
+			spec: RabbitmqSourceSpec{Replicas: int32Ptr(-1)},
+		},
+		"replicas of an unnamed queue": {
+			spec: RabbitmqSourceSpec{Replicas: int32Ptr(2)},
+		},
+		"replicas of an exclusive queue": {
+			spec: RabbitmqSourceSpec{
+				Replicas:    int32Ptr(2),
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "queue", Exclusive: true},
+			},
+		},
+		"replicas of a stream": {
+			spec: RabbitmqSourceSpec{
+				Replicas:    int32Ptr(2),
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "queue", Type: QueueTypeStream, Durable: true},
+			},
+		},
+		"replicas of an exclusive consumer": {
+			spec: RabbitmqSourceSpec{
+				Replicas:    int32Ptr(2),
+				QueueConfig: namedQueue,
+				Consumer:    &RabbitmqSourceConsumerSpec{Exclusive: true},
+			},
+		},
+		"replicas of a single active consumer queue": {
+			spec: RabbitmqSourceSpec{
+				Replicas:    int32Ptr(2),
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "queue", SingleActiveConsumer: true},
+			},
+			allowed: true,
+		},
+		"scale": {
+			spec: RabbitmqSourceSpec{
+				Scale: &RabbitmqSourceScaleSpec{
+					MinReplicas:        int32Ptr(0),
+					MaxReplicas:        5,
+					MessagesPerReplica: int32Ptr(10),
+					PollingInterval:    &metav1.Duration{Duration: 10 * time.Second},
+					CooldownPeriod:     &metav1.Duration{Duration: time.Minute},
+				},
+				QueueConfig: namedQueue,
+			},
+			allowed: true,
+		},
+		"scale and replicas": {
+			spec: RabbitmqSourceSpec{
+				Replicas:    int32Ptr(1),
+				Scale:       &RabbitmqSourceScaleSpec{MaxReplicas: 5},
+				QueueConfig: namedQueue,
+			},
+		},
+		"scale without max replicas": {
+			spec: RabbitmqSourceSpec{
+				Scale:       &RabbitmqSourceScaleSpec{},
+				QueueConfig: namedQueue,
+			},
+		},
+		"scale with min above max replicas": {
+			spec: RabbitmqSourceSpec{
+				Scale:       &RabbitmqSourceScaleSpec{MinReplicas: int32Ptr(3), MaxReplicas: 2},
+				QueueConfig: namedQueue,
+			},
+		},
+		"scale without messages per replica": {
+			spec: RabbitmqSourceSpec{
+				Scale:       &RabbitmqSourceScaleSpec{MaxReplicas: 2, MessagesPerReplica: int32Ptr(0)},
+				QueueConfig: namedQueue,
+			},
+		},
+		"scale of an unnamed queue": {
+			spec: RabbitmqSourceSpec{Scale: &RabbitmqSourceScaleSpec{MaxReplicas: 2}},
+		},
+		"scale to zero of an auto-deleted queue": {
+			spec: RabbitmqSourceSpec{
+				Scale:       &RabbitmqSourceScaleSpec{MinReplicas: int32Ptr(0), MaxReplicas: 2},
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "queue", DeleteWhenUnused: true},
+			},
+		},
+		"scale of an auto-deleted queue": {
+			spec: RabbitmqSourceSpec{
+				Scale:       &RabbitmqSourceScaleSpec{MinReplicas: int32Ptr(1), MaxReplicas: 2},
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "queue", DeleteWhenUnused: true},
+			},
+			allowed: true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(tc.spec)}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceConsumerValidation(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	testCases := map[string]struct {
+		spec    RabbitmqSourceSpec
+		allowed bool
+	}{
+		"consumer options": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{
+					AckMode:   AckModeAtLeastOnce,
+					Tag:       "adapter",
+					Priority:  int32Ptr(-5),
+					Exclusive: true,
+				},
+			},
+			allowed: true,
+		},
+		"at-most-once": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{AckMode: AckModeAtMostOnce},
+			},
+			allowed: true,
+		},
+		"at-most-once stream": {
+			spec: RabbitmqSourceSpec{
+				QueueConfig: RabbitmqSourceQueueConfigSpec{Name: "stream", Type: QueueTypeStream, Durable: true},
+				Consumer:    &RabbitmqSourceConsumerSpec{AckMode: AckModeAtMostOnce},
+			},
+		},
+		"unknown ack mode": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{AckMode: "at-least-twice"},
+			},
+		},
+		"batch": {
+			spec: RabbitmqSourceSpec{
+				ChannelConfig: RabbitmqChannelConfigSpec{PrefetchCount: 20},
+				Consumer: &RabbitmqSourceConsumerSpec{
+					AckMode:          AckModeBatch,
+					AckBatchSize:     int32Ptr(20),
+					AckBatchInterval: &metav1.Duration{Duration: 500 * time.Millisecond},
+				},
+			},
+			allowed: true,
+		},
+		"batch larger than the prefetch count": {
+			spec: RabbitmqSourceSpec{
+				ChannelConfig: RabbitmqChannelConfigSpec{PrefetchCount: 5},
+				Consumer:      &RabbitmqSourceConsumerSpec{AckMode: AckModeBatch, AckBatchSize: int32Ptr(10)},
+			},
+		},
+		"empty batch": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{AckMode: AckModeBatch, AckBatchSize: int32Ptr(0)},
+			},
+		},
+		"batch without interval": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{
+					AckMode:          AckModeBatch,
+					AckBatchInterval: &metav1.Duration{},
+				},
+			},
+		},
+		"batch size without batch mode": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{AckBatchSize: int32Ptr(10)},
+			},
+		},
+		"tag too long": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{Tag: strings.Repeat("t", 256)},
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: withRequiredFields(tc.spec)}
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceSpecValidation(t *testing.T) {
+	secretRef := func(name, key string) SecretValueFromSource {
+		return SecretValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}}
+	}
+
+	testCases := map[string]struct {
+		update  func(*RabbitmqSourceSpec)
+		allowed bool
+	}{
+		"full spec": {
+			update:  func(*RabbitmqSourceSpec) {},
+			allowed: true,
+		},
+		"missing sink": {
+			update: func(s *RabbitmqSourceSpec) { s.Sink = nil },
+		},
+		"invalid sink": {
+			update: func(s *RabbitmqSourceSpec) { s.Sink = &duckv1.Destination{} },
+		},
+		"missing brokers": {
+			update: func(s *RabbitmqSourceSpec) { s.Brokers = "" },
+		},
+		"brokers without scheme": {
+			update:  func(s *RabbitmqSourceSpec) { s.Brokers = "rabbitmq:5672" },
+			allowed: true,
+		},
+		"brokers with another scheme": {
+			update: func(s *RabbitmqSourceSpec) { s.Brokers = "http://rabbitmq:5672" },
+		},
+		"brokers without host": {
+			update: func(s *RabbitmqSourceSpec) { s.Brokers = "amqp:///vhost" },
+		},
+		"connection nodes": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Brokers = "amqp:///vhost"
+				s.Connection = &RabbitmqSourceConnectionSpec{
+					Nodes:      []string{"rabbitmq-0:5672", "rabbitmq-1"},
+					NodeOrder:  NodeOrderRandom,
+					Heartbeat:  &metav1.Duration{Duration: 5 * time.Second},
+					Timeout:    &metav1.Duration{Duration: 10 * time.Second},
+					ChannelMax: 16,
+					Name:       "source",
+				}
+			},
+			allowed: true,
+		},
+		"connection nodes without brokers": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Brokers = ""
+				s.Connection = &RabbitmqSourceConnectionSpec{Nodes: []string{"rabbitmq-0:5672"}}
+			},
+			allowed: true,
+		},
+		"connection node with scheme": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{Nodes: []string{"amqp://rabbitmq-0:5672"}}
+			},
+		},
+		"connection node without host": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{Nodes: []string{":5672"}}
+			},
+		},
+		"unknown connection node order": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{NodeOrder: "round-robin"}
+			},
+		},
+		"non positive connection heartbeat": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{Heartbeat: &metav1.Duration{}}
+			},
+		},
+		"negative connection timeout": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{Timeout: &metav1.Duration{Duration: -time.Second}}
+			},
+		},
+		"too many channels": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = &RabbitmqSourceConnectionSpec{ChannelMax: 65536}
+			},
+		},
+		"connectionRef": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Brokers = ""
+				s.ConnectionRef = &duckv1.KReference{Kind: "RabbitmqCluster", APIVersion: "rabbitmq.com/v1beta1", Name: "rabbit"}
+			},
+			allowed: true,
+		},
+		"brokers and connectionRef": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.ConnectionRef = &duckv1.KReference{Kind: "Secret", APIVersion: "v1", Name: "rabbit"}
+			},
+		},
+		"unsupported connectionRef": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Brokers = ""
+				s.ConnectionRef = &duckv1.KReference{Kind: "ConfigMap", APIVersion: "v1", Name: "rabbit"}
+			},
+		},
+		"credentials": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.User = secretRef("credentials", "user")
+				s.Password = secretRef("credentials", "password")
+			},
+			allowed: true,
+		},
+		"user without password": {
+			update: func(s *RabbitmqSourceSpec) { s.User = secretRef("credentials", "user") },
+		},
+		"secret ref without key": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.User = secretRef("credentials", "")
+				s.Password = secretRef("credentials", "password")
+			},
+		},
+		"EXTERNAL without client certificate": {
+			update: func(s *RabbitmqSourceSpec) { s.SASLMechanism = "EXTERNAL" },
+		},
+		"unknown SASL mechanism": {
+			update: func(s *RabbitmqSourceSpec) { s.SASLMechanism = "AMQPLAIN" },
+		},
+		"negative prefetch": {
+			update: func(s *RabbitmqSourceSpec) { s.ChannelConfig.PrefetchCount = -1 },
+		},
+		"pod template": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.PodTemplate = &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						NodeSelector: map[string]string{"disk": "ssd"},
+						Containers:   []corev1.Container{{Name: "receive-adapter"}},
+					},
+				}
+			},
+			allowed: true,
+		},
+		"pod template container without name": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.PodTemplate = &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{}}},
+				}
+			},
+		},
+		"invalid exchange type": {
+			update: func(s *RabbitmqSourceSpec) { s.ExchangeConfig.TypeOf = "broadcast" },
+		},
+		"plugin exchange type": {
+			update:  func(s *RabbitmqSourceSpec) { s.ExchangeConfig.TypeOf = "x-delayed-message" },
+			allowed: true,
+		},
+		"topic exchange without topic": {
+			update: func(s *RabbitmqSourceSpec) { s.Topic = "" },
+		},
+		"direct exchange without routing key": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.ExchangeConfig.TypeOf = "direct"
+				s.QueueConfig.RoutingKey = ""
+			},
+		},
+		"fanout exchange without routing key": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.ExchangeConfig.TypeOf = "fanout"
+				s.QueueConfig.RoutingKey = ""
+			},
+			allowed: true,
+		},
+		"routing keys": {
+			update:  func(s *RabbitmqSourceSpec) { s.QueueConfig.RoutingKey = "*.critical,audit.#" },
+			allowed: true,
+		},
+		"empty routing key in list": {
+			update: func(s *RabbitmqSourceSpec) { s.QueueConfig.RoutingKey = "*.critical," },
+		},
+		"malformed topic pattern": {
+			update: func(s *RabbitmqSourceSpec) { s.QueueConfig.RoutingKey = "logs.crit*" },
+		},
+		"malformed binding pattern": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.QueueConfig.RoutingKey = ""
+				s.Bindings = []RabbitmqSourceBinding{{RoutingKey: "logs#"}}
+			},
+		},
+		"invalid content type": {
+			update: func(s *RabbitmqSourceSpec) { s.ContentType = "text/" },
+		},
+		"unknown extension property": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Extensions = &RabbitmqSourceExtensionsSpec{Properties: []string{"userId"}}
+			},
+		},
+		"invalid extension name": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Extensions = &RabbitmqSourceExtensionsSpec{
+					Properties: []string{"routingKey"},
+					Names:      map[string]string{"routingKey": "routing-key"},
+				}
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: *fullSpec.DeepCopy()}
+			tc.update(&src.Spec)
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceValidationPaths(t *testing.T) {
+	testCases := map[string]struct {
+		update    func(*RabbitmqSourceSpec)
+		wantPaths []string
+	}{
+		"brokers": {
+			update:    func(s *RabbitmqSourceSpec) { s.Brokers = "http://rabbitmq:5672" },
+			wantPaths: []string{"spec.brokers"},
+		},
+		"user without key": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.User = SecretValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+				}}
+				s.Password = SecretValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+					Key:                  "password",
+				}}
+			},
+			wantPaths: []string{"spec.user.secretKeyRef.key"},
+		},
+		"topic": {
+			update:    func(s *RabbitmqSourceSpec) { s.Topic = "" },
+			wantPaths: []string{"spec.topic"},
+		},
+		"queue": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.QueueConfig = RabbitmqSourceQueueConfigSpec{
+					Type:             "quorum",
+					Durable:          true,
+					DeleteWhenUnused: true,
+					RoutingKey:       "*.critical",
+				}
+			},
+			wantPaths: []string{"spec.queue_config.delete_when_unused"},
+		},
+		"routing key": {
+			update:    func(s *RabbitmqSourceSpec) { s.QueueConfig.RoutingKey = "logs.crit*" },
+			wantPaths: []string{"spec.queue_config.routing_key"},
+		},
+		"missing routing key": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.ExchangeConfig.TypeOf = "direct"
+				s.QueueConfig.RoutingKey = ""
+			},
+			wantPaths: []string{"spec.bindings", "spec.queue_config.routing_key"},
+		},
+		"prefetch count": {
+			update:    func(s *RabbitmqSourceSpec) { s.ChannelConfig.PrefetchCount = -1 },
+			wantPaths: []string{"spec.channel_config.prefetch_count"},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: *fullSpec.DeepCopy()}
+			tc.update(&src.Spec)
+			err := src.Validate(context.TODO())
+			if err == nil {
+				t.Fatal("Expected the validation to fail")
+			}
+			for _, path := range tc.wantPaths {
+				if !strings.Contains(err.Error(), path) {
+					t.Errorf("Expected the error to report %s, got %v", path, err)
+				}
+			}
+		})
+	}
+}
@@ -21,8 +21,11 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	v1 "knative.dev/pkg/apis/duck/v1"
+	apisduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	apis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -69,6 +72,110 @@ func (in *RabbitmqSource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceAttributesSpec) DeepCopyInto(out *RabbitmqSourceAttributesSpec) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceAttributesSpec.
+func (in *RabbitmqSourceAttributesSpec) DeepCopy() *RabbitmqSourceAttributesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceAttributesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceBinding) DeepCopyInto(out *RabbitmqSourceBinding) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceBinding.
+func (in *RabbitmqSourceBinding) DeepCopy() *RabbitmqSourceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceConnectionSpec) DeepCopyInto(out *RabbitmqSourceConnectionSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Heartbeat != nil {
+		in, out := &in.Heartbeat, &out.Heartbeat
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceConnectionSpec.
+func (in *RabbitmqSourceConnectionSpec) DeepCopy() *RabbitmqSourceConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceConsumerSpec) DeepCopyInto(out *RabbitmqSourceConsumerSpec) {
+	*out = *in
+	if in.AckBatchSize != nil {
+		in, out := &in.AckBatchSize, &out.AckBatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AckBatchInterval != nil {
+		in, out := &in.AckBatchInterval, &out.AckBatchInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceConsumerSpec.
+func (in *RabbitmqSourceConsumerSpec) DeepCopy() *RabbitmqSourceConsumerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceConsumerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitmqSourceExchangeConfigSpec) DeepCopyInto(out *RabbitmqSourceExchangeConfigSpec) {
 	*out = *in
@@ -85,6 +192,34 @@ func (in *RabbitmqSourceExchangeConfigSpec) DeepCopy() *RabbitmqSourceExchangeCo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceExtensionsSpec) DeepCopyInto(out *RabbitmqSourceExtensionsSpec) {
+	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceExtensionsSpec.
+func (in *RabbitmqSourceExtensionsSpec) DeepCopy() *RabbitmqSourceExtensionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceExtensionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitmqSourceList) DeepCopyInto(out *RabbitmqSourceList) {
 	*out = *in
@@ -121,6 +256,31 @@ func (in *RabbitmqSourceList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitmqSourceQueueConfigSpec) DeepCopyInto(out *RabbitmqSourceQueueConfigSpec) {
 	*out = *in
+	if in.MessageTTL != nil {
+		in, out := &in.MessageTTL, &out.MessageTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxLength != nil {
+		in, out := &in.MaxLength, &out.MaxLength
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxLengthBytes != nil {
+		in, out := &in.MaxLengthBytes, &out.MaxLengthBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeliveryLimit != nil {
+		in, out := &in.DeliveryLimit, &out.DeliveryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -134,19 +294,158 @@ func (in *RabbitmqSourceQueueConfigSpec) DeepCopy() *RabbitmqSourceQueueConfigSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceQueueStatus) DeepCopyInto(out *RabbitmqSourceQueueStatus) {
+	*out = *in
+	if in.LastActiveTime != nil {
+		in, out := &in.LastActiveTime, &out.LastActiveTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceQueueStatus.
+func (in *RabbitmqSourceQueueStatus) DeepCopy() *RabbitmqSourceQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceReplySpec) DeepCopyInto(out *RabbitmqSourceReplySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceReplySpec.
+func (in *RabbitmqSourceReplySpec) DeepCopy() *RabbitmqSourceReplySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceReplySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceScaleSpec) DeepCopyInto(out *RabbitmqSourceScaleSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MessagesPerReplica != nil {
+		in, out := &in.MessagesPerReplica, &out.MessagesPerReplica
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CooldownPeriod != nil {
+		in, out := &in.CooldownPeriod, &out.CooldownPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceScaleSpec.
+func (in *RabbitmqSourceScaleSpec) DeepCopy() *RabbitmqSourceScaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceScaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RabbitmqSourceSpec) DeepCopyInto(out *RabbitmqSourceSpec) {
 	*out = *in
+	if in.ConnectionRef != nil {
+		in, out := &in.ConnectionRef, &out.ConnectionRef
+		*out = new(duckv1.KReference)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(RabbitmqSourceConnectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.User.DeepCopyInto(&out.User)
 	in.Password.DeepCopyInto(&out.Password)
 	out.ChannelConfig = in.ChannelConfig
 	out.ExchangeConfig = in.ExchangeConfig
-	out.QueueConfig = in.QueueConfig
+	in.QueueConfig.DeepCopyInto(&out.QueueConfig)
+	if in.Consumer != nil {
+		in, out := &in.Consumer, &out.Consumer
+		*out = new(RabbitmqSourceConsumerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]RabbitmqSourceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Sink != nil {
 		in, out := &in.Sink, &out.Sink
-		*out = new(v1.Destination)
+		*out = new(duckv1.Destination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(RabbitmqSourceScaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RabbitmqSourceTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = new(RabbitmqSourceExtensionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(RabbitmqSourceAttributesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEventOverrides != nil {
+		in, out := &in.CloudEventOverrides, &out.CloudEventOverrides
+		*out = new(duckv1.CloudEventOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(apisduckv1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reply != nil {
+		in, out := &in.Reply, &out.Reply
+		*out = new(RabbitmqSourceReplySpec)
+		**out = **in
+	}
 	return
 }
 
@@ -164,6 +463,16 @@ func (in *RabbitmqSourceSpec) DeepCopy() *RabbitmqSourceSpec {
 func (in *RabbitmqSourceStatus) DeepCopyInto(out *RabbitmqSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	if in.DeadLetterSinkURI != nil {
+		in, out := &in.DeadLetterSinkURI, &out.DeadLetterSinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = new(RabbitmqSourceQueueStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -177,6 +486,32 @@ func (in *RabbitmqSourceStatus) DeepCopy() *RabbitmqSourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitmqSourceTLSSpec) DeepCopyInto(out *RabbitmqSourceTLSSpec) {
+	*out = *in
+	if in.CACert != nil {
+		in, out := &in.CACert, &out.CACert
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertSecret != nil {
+		in, out := &in.ClientCertSecret, &out.ClientCertSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitmqSourceTLSSpec.
+func (in *RabbitmqSourceTLSSpec) DeepCopy() *RabbitmqSourceTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitmqSourceTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretValueFromSource) DeepCopyInto(out *SecretValueFromSource) {
 	*out = *in
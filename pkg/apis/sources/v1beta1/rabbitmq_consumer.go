@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "time"
+
+func (c *RabbitmqSourceConsumerSpec) GetAckMode() string {
+	if c == nil || c.AckMode == "" {
+		return AckModeAtLeastOnce
+	}
+	return c.AckMode
+}
+
+func (c *RabbitmqSourceConsumerSpec) GetAckBatchSize() int32 {
+	if c == nil || c.AckBatchSize == nil {
+		return DefaultAckBatchSize
+	}
+	return *c.AckBatchSize
+}
+
+func (c *RabbitmqSourceConsumerSpec) GetAckBatchInterval() time.Duration {
+	if c == nil || c.AckBatchInterval == nil {
+		return DefaultAckBatchInterval
+	}
+	return c.AckBatchInterval.Duration
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// DefaultExchangeType is the type of the exchange of sources that don't
+	// set one.
+	DefaultExchangeType = "topic"
+	// DefaultPrefetchCount is the number of unacknowledged messages delivered
+	// to the receive adapter, which sends them to the sink one at a time.
+	DefaultPrefetchCount = 1
+	// DefaultStreamPrefetchCount is the prefetch count of stream queues,
+	// which require one to be set.
+	DefaultStreamPrefetchCount = 100
+	// DefaultServiceAccountName is the ServiceAccount running the receive
+	// adapter.
+	DefaultServiceAccountName = "default"
+)
+
+func (r *RabbitmqSource) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, r.ObjectMeta)
+	r.Spec.SetDefaults(ctx)
+}
+
+func (s *RabbitmqSourceSpec) SetDefaults(ctx context.Context) {
+	if s.Exchange.Type == "" {
+		s.Exchange.Type = DefaultExchangeType
+	}
+	// Quorum queues and streams can only be durable.
+	if s.Queue.Type == QueueTypeQuorum || s.Queue.Type == QueueTypeStream {
+		s.Queue.Durable = true
+	}
+	if s.Consumer != nil {
+		s.Consumer.SetDefaults(ctx)
+	}
+	if s.Channel.PrefetchCount == 0 {
+		s.Channel.PrefetchCount = DefaultPrefetchCount
+		if s.Queue.Type == QueueTypeStream {
+			s.Channel.PrefetchCount = DefaultStreamPrefetchCount
+		}
+		// Batches are only acknowledged once they are all delivered.
+		if s.Consumer.GetAckMode() == AckModeBatch && s.Consumer.GetAckBatchSize() > s.Channel.PrefetchCount {
+			s.Channel.PrefetchCount = s.Consumer.GetAckBatchSize()
+		}
+	}
+	if s.TopologyMode == "" {
+		s.TopologyMode = TopologyModeDeclare
+	}
+	if s.ServiceAccountName == "" {
+		s.ServiceAccountName = DefaultServiceAccountName
+	}
+
+	s.Sink.SetDefaults(ctx)
+	if s.Delivery != nil && s.Delivery.DeadLetterSink != nil {
+		s.Delivery.DeadLetterSink.SetDefaults(ctx)
+	}
+}
+
+func (c *RabbitmqSourceConsumerSpec) SetDefaults(ctx context.Context) {
+	if c.AckMode == "" {
+		c.AckMode = AckModeAtLeastOnce
+	}
+	if c.AckMode == AckModeBatch {
+		if c.AckBatchSize == nil {
+			size := int32(DefaultAckBatchSize)
+			c.AckBatchSize = &size
+		}
+		if c.AckBatchInterval == nil {
+			c.AckBatchInterval = &metav1.Duration{Duration: DefaultAckBatchInterval}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestRabbitmqSourceSetDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		spec RabbitmqSourceSpec
+		want RabbitmqSourceSpec
+	}{
+		"empty": {
+			want: RabbitmqSourceSpec{
+				Exchange:           RabbitmqSourceExchangeSpec{Type: "topic"},
+				Channel:            RabbitmqSourceChannelSpec{PrefetchCount: 1},
+				TopologyMode:       TopologyModeDeclare,
+				ServiceAccountName: "default",
+			},
+		},
+		"stream": {
+			spec: RabbitmqSourceSpec{
+				Queue: RabbitmqSourceQueueSpec{Name: "stream", Type: QueueTypeStream},
+			},
+			want: RabbitmqSourceSpec{
+				Exchange:           RabbitmqSourceExchangeSpec{Type: "topic"},
+				Queue:              RabbitmqSourceQueueSpec{Name: "stream", Type: QueueTypeStream, Durable: true},
+				Channel:            RabbitmqSourceChannelSpec{PrefetchCount: 100},
+				TopologyMode:       TopologyModeDeclare,
+				ServiceAccountName: "default",
+			},
+		},
+		"batch acknowledgements": {
+			spec: RabbitmqSourceSpec{
+				Consumer: &RabbitmqSourceConsumerSpec{AckMode: AckModeBatch},
+			},
+			want: RabbitmqSourceSpec{
+				Exchange: RabbitmqSourceExchangeSpec{Type: "topic"},
+				Channel:  RabbitmqSourceChannelSpec{PrefetchCount: 10},
+				Consumer: &RabbitmqSourceConsumerSpec{
+					AckMode:          AckModeBatch,
+					AckBatchSize:     ptr.Int32(10),
+					AckBatchInterval: &metav1.Duration{Duration: time.Second},
+				},
+				TopologyMode:       TopologyModeDeclare,
+				ServiceAccountName: "default",
+			},
+		},
+		"set": {
+			spec: RabbitmqSourceSpec{
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "sink"},
+					},
+				},
+				Exchange:           RabbitmqSourceExchangeSpec{Type: "fanout"},
+				Channel:            RabbitmqSourceChannelSpec{PrefetchCount: 10},
+				TopologyMode:       TopologyModePassive,
+				ServiceAccountName: "adapter",
+			},
+			want: RabbitmqSourceSpec{
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Name: "sink", Namespace: "ns"},
+					},
+				},
+				Exchange:           RabbitmqSourceExchangeSpec{Type: "fanout"},
+				Channel:            RabbitmqSourceChannelSpec{PrefetchCount: 10},
+				TopologyMode:       TopologyModePassive,
+				ServiceAccountName: "adapter",
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source"},
+				Spec:       tc.spec,
+			}
+			src.SetDefaults(context.TODO())
+			if diff := cmp.Diff(tc.want, src.Spec); diff != "" {
+				t.Errorf("Unexpected spec (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
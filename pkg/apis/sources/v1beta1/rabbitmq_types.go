@@ -0,0 +1,527 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqSource is the Schema for the rabbitmqsources API.
+// +k8s:openapi-gen=true
+type RabbitmqSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitmqSourceSpec   `json:"spec,omitempty"`
+	Status RabbitmqSourceStatus `json:"status,omitempty"`
+}
+
+var _ runtime.Object = (*RabbitmqSource)(nil)
+var _ resourcesemantics.GenericCRD = (*RabbitmqSource)(nil)
+var _ kmeta.OwnerRefable = (*RabbitmqSource)(nil)
+var _ apis.Defaultable = (*RabbitmqSource)(nil)
+var _ apis.Validatable = (*RabbitmqSource)(nil)
+var _ apis.Convertible = (*RabbitmqSource)(nil)
+var _ duckv1.KRShaped = (*RabbitmqSource)(nil)
+
+type RabbitmqSourceSpec struct {
+	// inherits duck/v1 SourceSpec, which currently provides:
+	// * Sink - a reference to an object that will resolve to a domain name or
+	//   a URI directly to use as the sink.
+	// * CloudEventOverrides - defines overrides to control the output format
+	//   and modifications of the event sent to the sink.
+	duckv1.SourceSpec `json:",inline"`
+
+	// Connection is how the source connects to RabbitMQ.
+	Connection RabbitmqSourceConnectionSpec `json:"connection"`
+	// Exchange the queue is bound to.
+	// +optional
+	Exchange RabbitmqSourceExchangeSpec `json:"exchange,omitempty"`
+	// Queue the source consumes from.
+	// +optional
+	Queue RabbitmqSourceQueueSpec `json:"queue,omitempty"`
+	// Bindings of the queue. Without bindings, the queue is bound to Exchange
+	// with an empty routing key.
+	// +optional
+	Bindings []RabbitmqSourceBinding `json:"bindings,omitempty"`
+	// TopologyMode is how the source handles the exchange, queue and
+	// bindings: declare, the default, declares them; passive only checks that
+	// the queue and the named exchanges exist; none consumes from the queue
+	// without checking anything. Both passive and none require the name of an
+	// existing queue and ignore the bindings.
+	// +optional
+	TopologyMode string `json:"topologyMode,omitempty"`
+	// Channel configures the channel the messages are consumed on.
+	// +optional
+	Channel RabbitmqSourceChannelSpec `json:"channel,omitempty"`
+	// Consumer configures how messages are consumed and acknowledged.
+	// +optional
+	Consumer *RabbitmqSourceConsumerSpec `json:"consumer,omitempty"`
+	// Delivery is the retry and dead letter configuration used when the sink
+	// fails to accept an event. Without it, failed messages are requeued
+	// after a delay growing while they keep failing. With it, messages that
+	// exhaust their retries are sent to the dead letter sink or, if there's
+	// none, rejected so that RabbitMQ routes them to the dead letter
+	// exchange of the queue, if any. Messages that aren't valid events are
+	// never requeued.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+	// Reply publishes the events replied by the sink back to RabbitMQ.
+	// Without it, replies are dropped. Replies that fail to be published are
+	// sent to the dead letter sink, if any, the message being acknowledged
+	// as the sink accepted its event.
+	// +optional
+	Reply *RabbitmqSourceReplySpec `json:"reply,omitempty"`
+	// Replicas is the number of receive adapter pods competing for the
+	// messages of the queue. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Scale autoscales the receive adapter on the number of messages ready
+	// in the queue instead of running a fixed number of replicas.
+	// +optional
+	Scale *RabbitmqSourceScaleSpec `json:"scale,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount that will be used
+	// to run the receive adapter Deployment.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// PodTemplate is merged into the pod template of the receive adapter
+	// Deployment, after the one of the config-rabbitmq-pod-templates
+	// ConfigMap, to set resources, node selectors, tolerations, affinity and
+	// the like. Containers are merged by name, the receive adapter container
+	// being named receive-adapter.
+	// +optional
+	PodTemplate *corev1.PodTemplateSpec `json:"podTemplate,omitempty"`
+	// ContentType is the content type of events built from messages that
+	// have no content-type property. Defaults to application/octet-stream.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+	// ForceWrap wraps every message into a new event, including messages
+	// that already are CloudEvents, which are otherwise forwarded unchanged.
+	// +optional
+	ForceWrap bool `json:"forceWrap,omitempty"`
+	// Extensions configures the message properties mapped into CloudEvent
+	// extensions of the events wrapping messages.
+	// +optional
+	Extensions *RabbitmqSourceExtensionsSpec `json:"extensions,omitempty"`
+	// Attributes maps message properties into the attributes of the events
+	// wrapping messages.
+	// +optional
+	Attributes *RabbitmqSourceAttributesSpec `json:"attributes,omitempty"`
+}
+
+type RabbitmqSourceConnectionSpec struct {
+	// URI of the brokers, e.g. amqp://rabbitmq:5672. Required unless Ref or
+	// Nodes is set.
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// Ref references the RabbitMQ to connect to instead of URI and
+	// Credentials. It is either a v1 Secret holding the URI of the brokers in
+	// its brokerURL key or a rabbitmq.com/v1beta1 RabbitmqCluster, whose
+	// host, TLS and default user credentials are used. The namespace defaults
+	// to the namespace of the source.
+	// +optional
+	Ref *duckv1.KReference `json:"ref,omitempty"`
+	// Credentials used to connect to URI, unless it holds them.
+	// +optional
+	Credentials *RabbitmqSourceCredentialsSpec `json:"credentials,omitempty"`
+	// Vhost is appended to the path of URI, separated by a slash unless
+	// either has one, and overrides the vhost of the URI resolved from Ref:
+	// foo and /foo are both the vhost foo, //foo the vhost /foo.
+	// +optional
+	Vhost string `json:"vhost,omitempty"`
+	// TLS configures the TLS connection to the brokers, which are then
+	// reached with the amqps scheme.
+	// +optional
+	TLS *RabbitmqSourceTLSSpec `json:"tls,omitempty"`
+	// SASLMechanism used to authenticate: PLAIN, the default, uses the
+	// credentials while EXTERNAL uses the TLS client certificate.
+	// +optional
+	SASLMechanism string `json:"saslMechanism,omitempty"`
+	// Nodes are the addresses, host:port, of the nodes of the RabbitMQ
+	// cluster, replacing the host of URI, which is then optional, or of the
+	// URI resolved from Ref. The receive adapter connects to the first node
+	// accepting the connection, in NodeOrder, and fails over to the other
+	// nodes when reconnecting.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+	// NodeOrder is the order the nodes are tried in: in-order, the default,
+	// or random, which spreads the connections of several replicas.
+	// +optional
+	NodeOrder string `json:"nodeOrder,omitempty"`
+	// Heartbeat is the interval of the heartbeats detecting dead
+	// connections. Defaults to 10s.
+	// +optional
+	Heartbeat *metav1.Duration `json:"heartbeat,omitempty"`
+	// Timeout of connecting to a node, up to the AMQP handshake. Defaults to
+	// 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// ChannelMax is the maximum number of channels of the connection,
+	// negotiated with the server. Defaults to the limit of the server.
+	// +optional
+	ChannelMax int32 `json:"channelMax,omitempty"`
+	// Name of the connection, shown by the management UI.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+type RabbitmqSourceCredentialsSpec struct {
+	// User selects the Secret key holding the user name.
+	User corev1.SecretKeySelector `json:"user"`
+	// Password selects the Secret key holding the password.
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+type RabbitmqSourceTLSSpec struct {
+	// CACert selects the Secret key holding the PEM encoded CA bundle used to
+	// verify the server certificate. The system CAs are used by default.
+	// +optional
+	CACert *corev1.SecretKeySelector `json:"caCert,omitempty"`
+	// ClientCertSecret is a kubernetes.io/tls Secret holding the client
+	// certificate and key in its tls.crt and tls.key keys.
+	// +optional
+	ClientCertSecret *corev1.LocalObjectReference `json:"clientCertSecret,omitempty"`
+	// ServerName is verified against the server certificate instead of the
+	// host of the brokers.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+type RabbitmqSourceExchangeSpec struct {
+	// Name of the exchange. Required for topic exchanges, other exchanges
+	// default to logs.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Type of the exchange: direct, fanout, topic, the default, headers or
+	// the type of a plugin, starting with x-.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Durable exchanges survive broker restarts.
+	// +optional
+	Durable bool `json:"durable,omitempty"`
+	// AutoDelete deletes the exchange when its last queue is unbound.
+	// +optional
+	AutoDelete bool `json:"autoDelete,omitempty"`
+	// Internal exchanges don't accept publishings.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+	// NoWait declares the exchange without waiting for a confirmation from
+	// the server.
+	// +optional
+	NoWait bool `json:"noWait,omitempty"`
+}
+
+type RabbitmqSourceQueueSpec struct {
+	// Name of the queue. The server generates a unique name when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Type of the queue: classic, the default, quorum or stream. Quorum and
+	// stream queues must be durable and can be neither exclusive nor deleted
+	// automatically.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Durable queues survive broker restarts.
+	// +optional
+	Durable bool `json:"durable,omitempty"`
+	// AutoDelete deletes the queue when its last consumer goes away.
+	// +optional
+	AutoDelete bool `json:"autoDelete,omitempty"`
+	// Exclusive queues are only used by the connection declaring them.
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
+	// NoWait declares the queue without waiting for a confirmation from the
+	// server.
+	// +optional
+	NoWait bool `json:"noWait,omitempty"`
+	// StreamOffset is where consuming a stream queue starts when no offset
+	// was stored yet: first, last, next (the default), an offset or an RFC
+	// 3339 timestamp.
+	// +optional
+	StreamOffset string `json:"streamOffset,omitempty"`
+	// MessageTTL is how long messages stay in the queue before expiring.
+	// +optional
+	MessageTTL *metav1.Duration `json:"messageTTL,omitempty"`
+	// MaxLength is the maximum number of ready messages in the queue.
+	// +optional
+	MaxLength *int64 `json:"maxLength,omitempty"`
+	// MaxLengthBytes is the maximum total size of the ready messages in the
+	// queue.
+	// +optional
+	MaxLengthBytes *int64 `json:"maxLengthBytes,omitempty"`
+	// Overflow is what happens to messages published to a full queue:
+	// drop-head, the default, reject-publish or reject-publish-dlx.
+	// +optional
+	Overflow string `json:"overflow,omitempty"`
+	// DeadLetterExchange is the exchange rejected and expired messages are
+	// published to.
+	// +optional
+	DeadLetterExchange string `json:"deadLetterExchange,omitempty"`
+	// DeadLetterRoutingKey replaces the routing key of dead-lettered
+	// messages.
+	// +optional
+	DeadLetterRoutingKey string `json:"deadLetterRoutingKey,omitempty"`
+	// SingleActiveConsumer makes only one consumer of the queue receive
+	// messages at a time, the others taking over if it goes away.
+	// +optional
+	SingleActiveConsumer bool `json:"singleActiveConsumer,omitempty"`
+	// DeliveryLimit is the number of times a message of a quorum queue is
+	// delivered before being dead-lettered.
+	// +optional
+	DeliveryLimit *int32 `json:"deliveryLimit,omitempty"`
+	// Arguments are additional arguments of the queue declaration, as a JSON
+	// object. The typed fields take precedence over the same arguments.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Arguments *runtime.RawExtension `json:"arguments,omitempty"`
+}
+
+type RabbitmqSourceBinding struct {
+	// Exchange the queue is bound to. Defaults to the name of Exchange.
+	// +optional
+	Exchange string `json:"exchange,omitempty"`
+	// RoutingKey of the binding, ignored by fanout and headers exchanges.
+	// +optional
+	RoutingKey string `json:"routingKey,omitempty"`
+	// Arguments of the binding as a JSON object, such as x-match and the
+	// header values matched by headers exchanges.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Arguments *runtime.RawExtension `json:"arguments,omitempty"`
+}
+
+type RabbitmqSourceChannelSpec struct {
+	// PrefetchCount is the number of unacknowledged messages delivered to
+	// the receive adapter. Defaults to 1, or 100 for stream queues.
+	// +optional
+	PrefetchCount int32 `json:"prefetchCount,omitempty"`
+	// GlobalQos applies the prefetch count to the whole channel rather than
+	// to each consumer.
+	// +optional
+	GlobalQos bool `json:"globalQos,omitempty"`
+}
+
+type RabbitmqSourceConsumerSpec struct {
+	// AckMode is when messages are acknowledged: at-least-once, the default,
+	// acknowledges each message once its event is accepted by the sink;
+	// at-most-once lets RabbitMQ acknowledge messages as they are delivered,
+	// failed events being lost unless sent to the dead letter sink; batch
+	// acknowledges the accepted messages AckBatchSize at a time, which
+	// requires a prefetch count of at least AckBatchSize.
+	// +optional
+	AckMode string `json:"ackMode,omitempty"`
+	// AckBatchSize is the number of accepted messages acknowledged at once
+	// in batch mode. Defaults to 10.
+	// +optional
+	AckBatchSize *int32 `json:"ackBatchSize,omitempty"`
+	// AckBatchInterval is how long accepted messages wait for their batch to
+	// fill up before being acknowledged in batch mode. Defaults to 1s.
+	// +optional
+	AckBatchInterval *metav1.Duration `json:"ackBatchInterval,omitempty"`
+	// Tag identifies the consumer in RabbitMQ. The server generates one when
+	// empty.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// Priority of the consumer. RabbitMQ delivers messages to the consumers
+	// of the queue with the highest priority first, as long as they can
+	// accept them.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// Exclusive makes the consumer the only one of the queue, which can then
+	// only have a single replica. Queues declared with single active
+	// consumer instead fail over between the consumers of several replicas.
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
+}
+
+type RabbitmqSourceExtensionsSpec struct {
+	// Properties lists the message properties mapped into extensions, any of
+	// routingKey, exchange, correlationId, replyTo, appId, priority,
+	// redelivered and headers. The headers table is flattened into one
+	// extension per header, nested tables joining their keys with ".".
+	// +optional
+	Properties []string `json:"properties,omitempty"`
+	// Names maps properties, or headers given as "headers.<key>", to the name
+	// of their extension. Extensions are otherwise named after the property or
+	// header, lowercased and stripped of any character other than a-z and 0-9.
+	// +optional
+	Names map[string]string `json:"names,omitempty"`
+}
+
+type RabbitmqSourceReplySpec struct {
+	// Exchange the replies are published to. Defaults to the default
+	// exchange, which routes them to the queue named after the routing key.
+	// +optional
+	Exchange string `json:"exchange,omitempty"`
+	// RoutingKey of the replies. Replies to messages with a reply-to
+	// property are instead published to the default exchange with it as
+	// routing key, and with the correlation-id of the message, as RabbitMQ
+	// RPC clients expect.
+	// +optional
+	RoutingKey string `json:"routingKey,omitempty"`
+}
+
+type RabbitmqSourceAttributesSpec struct {
+	// Type, Source, Subject and ID are text/template templates of the
+	// attributes of events wrapping messages, executed on the message
+	// properties messageId, correlationId, replyTo, appId, type, routingKey,
+	// exchange and headers, e.g. "com.example.{{ .routingKey }}" or
+	// "{{ .headers.region }}". The default attribute is used when a template
+	// refers to a missing header or produces an empty value. The source
+	// defaults to /apis/v1/namespaces/<namespace>/rabbitmqsources/<name>#<exchange>.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// +optional
+	Source string `json:"source,omitempty"`
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Types lists the types produced by a Type template, which are published
+	// in the status of the source as they can't be known in advance.
+	// +optional
+	Types []string `json:"types,omitempty"`
+}
+
+type RabbitmqSourceScaleSpec struct {
+	// MinReplicas is the minimum number of receive adapter pods. Defaults to
+	// 0, stopping the source until messages are ready in the queue again.
+	// Sources of auto-deleted queues must keep at least 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the maximum number of receive adapter pods.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// MessagesPerReplica is the number of messages ready in the queue each
+	// pod is expected to keep up with. Defaults to 100.
+	// +optional
+	MessagesPerReplica *int32 `json:"messagesPerReplica,omitempty"`
+	// PollingInterval is how often the queue is checked. Defaults to 30s.
+	// +optional
+	PollingInterval *metav1.Duration `json:"pollingInterval,omitempty"`
+	// CooldownPeriod is how long the queue must have no messages ready
+	// before scaling to MinReplicas. Defaults to 5m.
+	// +optional
+	CooldownPeriod *metav1.Duration `json:"cooldownPeriod,omitempty"`
+}
+
+const (
+	// TopologyModeDeclare declares the exchange, queue and bindings.
+	TopologyModeDeclare = "declare"
+	// TopologyModePassive checks that the queue and exchanges exist.
+	TopologyModePassive = "passive"
+	// TopologyModeNone consumes from an existing queue.
+	TopologyModeNone = "none"
+)
+
+const (
+	// NodeOrderInOrder tries the nodes in the order they are listed.
+	NodeOrderInOrder = "in-order"
+	// NodeOrderRandom tries the nodes in a random order.
+	NodeOrderRandom = "random"
+)
+
+const (
+	// AckModeAtLeastOnce acknowledges each message once its event is
+	// accepted by the sink.
+	AckModeAtLeastOnce = "at-least-once"
+	// AckModeAtMostOnce lets RabbitMQ acknowledge messages on delivery.
+	AckModeAtMostOnce = "at-most-once"
+	// AckModeBatch acknowledges accepted messages by batches.
+	AckModeBatch = "batch"
+)
+
+const (
+	// QueueTypeClassic is the default type of queue.
+	QueueTypeClassic = "classic"
+	// QueueTypeQuorum is the type of replicated queues.
+	QueueTypeQuorum = "quorum"
+	// QueueTypeStream is the type of stream queues, whose messages are kept
+	// after being consumed and can be replayed from an offset.
+	QueueTypeStream = "stream"
+)
+
+const (
+	// DefaultMessagesPerReplica is the default MessagesPerReplica of Scale.
+	DefaultMessagesPerReplica = 100
+	// DefaultScalePollingInterval is the default PollingInterval of Scale.
+	DefaultScalePollingInterval = 30 * time.Second
+	// DefaultScaleCooldownPeriod is the default CooldownPeriod of Scale.
+	DefaultScaleCooldownPeriod = 5 * time.Minute
+	// DefaultAckBatchSize is the default AckBatchSize of Consumer.
+	DefaultAckBatchSize = 10
+	// DefaultAckBatchInterval is the default AckBatchInterval of Consumer.
+	DefaultAckBatchInterval = time.Second
+)
+
+type RabbitmqSourceStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.SourceStatus `json:",inline"`
+
+	// DeadLetterSinkURI is the resolved URI of the dead letter sink, if any.
+	// +optional
+	DeadLetterSinkURI *apis.URL `json:"deadLetterSinkUri,omitempty"`
+
+	// Queue is the state of the queue consumed by the source, as last
+	// observed by the controller.
+	// +optional
+	Queue *RabbitmqSourceQueueStatus `json:"queue,omitempty"`
+}
+
+type RabbitmqSourceQueueStatus struct {
+	// Name of the queue, which is generated by the server if the spec
+	// leaves it empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// MessagesReady is the number of messages ready to be delivered.
+	MessagesReady int32 `json:"messagesReady"`
+	// MessagesUnacknowledged is the number of messages delivered to the
+	// receive adapter and not yet acknowledged.
+	MessagesUnacknowledged int32 `json:"messagesUnacknowledged"`
+	// Consumers is the number of active consumers of the queue.
+	Consumers int32 `json:"consumers"`
+	// LastActiveTime is the last time messages were ready in the queue.
+	// +optional
+	LastActiveTime *metav1.Time `json:"lastActiveTime,omitempty"`
+}
+
+func (s *RabbitmqSource) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("RabbitmqSource")
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RabbitmqSourceList contains a list of RabbitmqSources.
+type RabbitmqSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitmqSource `json:"items"`
+}
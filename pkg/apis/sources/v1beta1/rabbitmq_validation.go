@@ -0,0 +1,611 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"mime"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmp"
+)
+
+func (current *RabbitmqSource) Validate(ctx context.Context) *apis.FieldError {
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*RabbitmqSource)
+		if errs := current.CheckImmutableFields(original); errs != nil {
+			return errs.ViaField("spec")
+		}
+	}
+	return current.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (s *RabbitmqSourceSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := s.Connection.Validate(ctx).ViaField("connection")
+
+	errs = errs.Also(s.Sink.Validate(ctx).ViaField("sink"))
+	if s.CloudEventOverrides != nil {
+		errs = errs.Also(validateCloudEventOverrides(s.CloudEventOverrides).ViaField("ceOverrides"))
+	}
+	if s.Delivery != nil {
+		errs = errs.Also(s.Delivery.Validate(ctx).ViaField("delivery"))
+	}
+
+	if s.Channel.PrefetchCount < 0 {
+		errs = errs.Also(invalidValue(s.Channel.PrefetchCount, "channel.prefetchCount", "must not be negative"))
+	}
+	errs = errs.Also(s.Exchange.Validate(ctx).ViaField("exchange"))
+	errs = errs.Also(s.Queue.Validate(ctx).ViaField("queue"))
+
+	switch s.TopologyMode {
+	case "", TopologyModeDeclare:
+		errs = errs.Also(s.validateRoutingKeys())
+	case TopologyModePassive, TopologyModeNone:
+		if s.Queue.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("queue.name"))
+		}
+	default:
+		errs = errs.Also(invalidValue(s.TopologyMode, "topologyMode", "expected declare, passive or none"))
+	}
+	for i := range s.Bindings {
+		errs = errs.Also(s.Bindings[i].Validate(ctx).ViaFieldIndex("bindings", i))
+	}
+	errs = errs.Also(s.validateConsumer(ctx))
+	errs = errs.Also(s.validateReplicas())
+	if s.PodTemplate != nil {
+		if err := podtemplate.Validate(s.PodTemplate); err != nil {
+			errs = errs.Also(invalidValue("", "podTemplate", err.Error()))
+		}
+	}
+
+	if s.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(s.ContentType); err != nil {
+			errs = errs.Also(invalidValue(s.ContentType, "contentType", err.Error()))
+		}
+	}
+	if s.Extensions != nil {
+		errs = errs.Also(s.Extensions.Validate(ctx).ViaField("extensions"))
+	}
+	if s.Attributes != nil {
+		errs = errs.Also(s.Attributes.Validate(ctx).ViaField("attributes"))
+	}
+	return errs
+}
+
+// Validate checks the URI, credentials and TLS configuration of the
+// connection, or the reference replacing them.
+func (c *RabbitmqSourceConnectionSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := c.validateOptions(ctx)
+	if c.Ref != nil {
+		if c.URI != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("uri", "ref"))
+		}
+		if c.Credentials != nil {
+			errs = errs.Also(apis.ErrGeneric("the credentials of ref are used", "credentials"))
+		}
+		errs = errs.Also(validateConnectionRef(c.Ref).ViaField("ref"))
+	} else {
+		if c.URI == "" {
+			if len(c.Nodes) == 0 {
+				errs = errs.Also(apis.ErrMissingOneOf("uri", "ref", "nodes"))
+			}
+		} else {
+			errs = errs.Also(validateURI(c.URI, len(c.Nodes) == 0).ViaField("uri"))
+		}
+		if c.Credentials != nil {
+			errs = errs.Also(validateSecretKeySelector(&c.Credentials.User).ViaField("credentials", "user"))
+			errs = errs.Also(validateSecretKeySelector(&c.Credentials.Password).ViaField("credentials", "password"))
+		}
+	}
+
+	if c.TLS != nil {
+		errs = errs.Also(validateSecretKeySelector(c.TLS.CACert).ViaField("tls", "caCert"))
+		if c.TLS.ClientCertSecret != nil && c.TLS.ClientCertSecret.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("tls.clientCertSecret.name"))
+		}
+	}
+	switch strings.ToUpper(c.SASLMechanism) {
+	case "", "PLAIN":
+	case "EXTERNAL":
+		if c.TLS == nil || c.TLS.ClientCertSecret == nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"tls.clientCertSecret"},
+				Details: "EXTERNAL authentication uses the TLS client certificate",
+			})
+		}
+	default:
+		errs = errs.Also(invalidValue(c.SASLMechanism, "saslMechanism", "expected PLAIN or EXTERNAL"))
+	}
+	return errs
+}
+
+// validateURI checks that the URI of the brokers is an AMQP URL, whose
+// scheme is optional when the credentials are set apart and whose host is
+// only required without nodes.
+func validateURI(uri string, requireHost bool) *apis.FieldError {
+	raw := uri
+	if !strings.Contains(raw, "://") {
+		raw = "amqp://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return invalidValue(uri, apis.CurrentField, err.Error())
+	}
+	if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		return invalidValue(uri, apis.CurrentField, "expected an amqp or amqps URL")
+	}
+	if u.Host == "" && requireHost {
+		return invalidValue(uri, apis.CurrentField, "missing host")
+	}
+	return nil
+}
+
+// validateOptions checks the nodes and options of the connections.
+func (c *RabbitmqSourceConnectionSpec) validateOptions(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, node := range c.Nodes {
+		// Nodes replace the host of URLs.
+		if u, err := url.Parse("amqp://" + node); err != nil || u.Host != node || u.Hostname() == "" {
+			err := apis.ErrInvalidArrayValue(node, "nodes", i)
+			err.Details = "expected host or host:port"
+			errs = errs.Also(err)
+		}
+	}
+	switch c.NodeOrder {
+	case "", NodeOrderInOrder, NodeOrderRandom:
+	default:
+		errs = errs.Also(invalidValue(c.NodeOrder, "nodeOrder", "expected in-order or random"))
+	}
+	if c.Heartbeat != nil && c.Heartbeat.Duration <= 0 {
+		errs = errs.Also(invalidValue(c.Heartbeat.Duration.String(), "heartbeat", "must be positive"))
+	}
+	if c.Timeout != nil && c.Timeout.Duration <= 0 {
+		errs = errs.Also(invalidValue(c.Timeout.Duration.String(), "timeout", "must be positive"))
+	}
+	if c.ChannelMax < 0 || c.ChannelMax > 65535 {
+		errs = errs.Also(invalidValue(c.ChannelMax, "channelMax", "must be between 0 and 65535"))
+	}
+	return errs
+}
+
+func validateConnectionRef(ref *duckv1.KReference) *apis.FieldError {
+	var errs *apis.FieldError
+	if ref.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	switch {
+	case ref.Kind == "Secret" && ref.APIVersion == "v1":
+	case ref.Kind == "RabbitmqCluster" && ref.APIVersion == "rabbitmq.com/v1beta1":
+	default:
+		errs = errs.Also(&apis.FieldError{
+			Message: "unsupported connection reference",
+			Paths:   []string{"kind", "apiVersion"},
+			Details: "expected a v1 Secret or a rabbitmq.com/v1beta1 RabbitmqCluster",
+		})
+	}
+	return errs
+}
+
+func validateSecretKeySelector(selector *corev1.SecretKeySelector) *apis.FieldError {
+	if selector == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if selector.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if selector.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	return errs
+}
+
+func (e *RabbitmqSourceExchangeSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	switch e.Type {
+	case "direct", "fanout", "headers":
+	case "topic":
+		if e.Name == "" {
+			errs = errs.Also(&apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"name"},
+				Details: "exchanges of type topic have no default name",
+			})
+		}
+	case "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	default:
+		// Exchange types of plugins, such as x-delayed-message.
+		if !strings.HasPrefix(e.Type, "x-") {
+			errs = errs.Also(invalidValue(e.Type, "type", "expected direct, fanout, topic, headers or a plugin type starting with x-"))
+		}
+	}
+	return errs
+}
+
+// validateRoutingKeys checks the routing keys the queue is bound with, which
+// direct and topic exchanges require.
+func (s *RabbitmqSourceSpec) validateRoutingKeys() *apis.FieldError {
+	exchangeType := s.Exchange.Type
+	if len(s.Bindings) == 0 {
+		if exchangeType == "direct" || exchangeType == "topic" {
+			return &apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{"bindings"},
+				Details: "exchanges of type " + exchangeType + " route messages by routing key",
+			}
+		}
+		return nil
+	}
+
+	var errs *apis.FieldError
+	for i, b := range s.Bindings {
+		bindingType := exchangeType
+		if b.Exchange != "" && b.Exchange != s.Exchange.Name {
+			bindingType = ""
+		}
+		errs = errs.Also(validateRoutingKey(b.RoutingKey, bindingType).ViaField("routingKey").ViaFieldIndex("bindings", i))
+	}
+	return errs
+}
+
+// validateRoutingKey checks a routing key, and that the wildcards of the
+// binding patterns of topic exchanges are whole words.
+func validateRoutingKey(key, exchangeType string) *apis.FieldError {
+	if len(key) > 255 {
+		return invalidValue(key, apis.CurrentField, "routing keys are at most 255 bytes long")
+	}
+	if exchangeType == "topic" {
+		for _, word := range strings.Split(key, ".") {
+			if strings.ContainsAny(word, "*#") && word != "*" && word != "#" {
+				return invalidValue(key, apis.CurrentField, "the * and # wildcards must be whole words")
+			}
+		}
+	}
+	return nil
+}
+
+func (q *RabbitmqSourceQueueSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	switch q.Type {
+	case "", QueueTypeClassic:
+	case QueueTypeQuorum, QueueTypeStream:
+		if !q.Durable {
+			errs = errs.Also(invalidValue(false, "durable", q.Type+" queues must be durable"))
+		}
+		if q.Exclusive {
+			errs = errs.Also(invalidValue(true, "exclusive", q.Type+" queues can't be exclusive"))
+		}
+		if q.AutoDelete {
+			errs = errs.Also(invalidValue(true, "autoDelete", q.Type+" queues can't be deleted automatically"))
+		}
+	default:
+		errs = errs.Also(invalidValue(q.Type, "type", "expected classic, quorum or stream"))
+	}
+
+	if q.StreamOffset != "" && q.Type != QueueTypeStream {
+		errs = errs.Also(apis.ErrGeneric("only valid for stream queues", "streamOffset"))
+	}
+	if q.MessageTTL != nil && q.MessageTTL.Duration < 0 {
+		errs = errs.Also(invalidValue(q.MessageTTL.Duration.String(), "messageTTL", "must not be negative"))
+	}
+	if q.MaxLength != nil && *q.MaxLength < 0 {
+		errs = errs.Also(invalidValue(*q.MaxLength, "maxLength", "must not be negative"))
+	}
+	if q.MaxLengthBytes != nil && *q.MaxLengthBytes < 0 {
+		errs = errs.Also(invalidValue(*q.MaxLengthBytes, "maxLengthBytes", "must not be negative"))
+	}
+
+	switch q.Overflow {
+	case "", "drop-head", "reject-publish":
+	case "reject-publish-dlx":
+		if q.Type == QueueTypeQuorum {
+			errs = errs.Also(invalidValue(q.Overflow, "overflow", "not supported by quorum queues"))
+		}
+	default:
+		errs = errs.Also(invalidValue(q.Overflow, "overflow", "expected drop-head, reject-publish or reject-publish-dlx"))
+	}
+
+	if q.DeadLetterRoutingKey != "" && q.DeadLetterExchange == "" {
+		errs = errs.Also(apis.ErrGeneric("requires deadLetterExchange", "deadLetterRoutingKey"))
+	}
+	if q.DeliveryLimit != nil {
+		if q.Type != QueueTypeQuorum {
+			errs = errs.Also(apis.ErrGeneric("only valid for quorum queues", "deliveryLimit"))
+		} else if *q.DeliveryLimit < 0 {
+			errs = errs.Also(invalidValue(*q.DeliveryLimit, "deliveryLimit", "must not be negative"))
+		}
+	}
+
+	if _, err := q.DeclareArguments(); err != nil {
+		errs = errs.Also(invalidValue(string(q.Arguments.Raw), "arguments", err.Error()))
+	}
+	return errs
+}
+
+func (b *RabbitmqSourceBinding) Validate(ctx context.Context) *apis.FieldError {
+	args, err := b.DeclareArguments()
+	if err != nil {
+		return invalidValue(string(b.Arguments.Raw), "arguments", err.Error())
+	}
+	if match, ok := args["x-match"]; ok {
+		switch match {
+		case "all", "any", "all-with-x", "any-with-x":
+		default:
+			return invalidValue(match, "arguments.x-match", "expected all, any, all-with-x or any-with-x")
+		}
+	}
+	return nil
+}
+
+func (e *RabbitmqSourceExtensionsSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, p := range e.Properties {
+		switch p {
+		case "routingKey", "exchange", "correlationId", "replyTo", "appId", "priority", "redelivered", "headers":
+		default:
+			errs = errs.Also(apis.ErrInvalidArrayValue(p, "properties", i))
+		}
+	}
+	for key, name := range e.Names {
+		if !isExtensionName(name) {
+			errs = errs.Also(invalidValue(name, "names."+key, "extension names must be 1 to 20 lowercase letters or digits"))
+		}
+	}
+	return errs
+}
+
+func (a *RabbitmqSourceAttributesSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, attr := range []struct{ field, text string }{
+		{"type", a.Type},
+		{"source", a.Source},
+		{"subject", a.Subject},
+		{"id", a.ID},
+	} {
+		if _, err := ParseAttributeTemplate(attr.field, attr.text); err != nil {
+			errs = errs.Also(invalidValue(attr.text, attr.field, err.Error()))
+		}
+	}
+	if len(a.Types) > 0 && !isTemplate(a.Type) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "types requires a templated type",
+			Paths:   []string{"types"},
+		})
+	}
+	for i, t := range a.Types {
+		if t == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(t, "types", i))
+		}
+	}
+	return errs
+}
+
+// validateCloudEventOverrides checks that the extensions of the overrides
+// are valid CloudEvents attribute names that aren't context attributes.
+func validateCloudEventOverrides(o *duckv1.CloudEventOverrides) *apis.FieldError {
+	var errs *apis.FieldError
+	for name := range o.Extensions {
+		if !isExtensionName(name) {
+			errs = errs.Also(invalidValue(name, "extensions", "extension names must be 1 to 20 lowercase letters or digits"))
+		}
+		switch name {
+		case "specversion", "id", "source", "type", "subject", "time", "datacontenttype", "dataschema":
+			errs = errs.Also(invalidValue(name, "extensions", "context attributes can't be overridden"))
+		}
+	}
+	return errs
+}
+
+func isExtensionName(name string) bool {
+	if name == "" || len(name) > 20 {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckImmutableFields rejects the changes to the fields identifying the
+// exchange, queue and bindings declared by the source, which RabbitMQ can't
+// redeclare with different properties and whose stale bindings would keep
+// routing messages to the queue. Every other field can change, the receive
+// adapter being rolled out with the new spec.
+func (current *RabbitmqSource) CheckImmutableFields(original *RabbitmqSource) *apis.FieldError {
+	if original == nil {
+		return nil
+	}
+	cur, orig := current.Spec, original.Spec
+
+	var errs *apis.FieldError
+	immutable := func(field, reason string, o, c interface{}) {
+		if diff, err := kmp.ShortDiff(o, c); err != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Failed to diff RabbitmqSource",
+				Paths:   []string{field},
+				Details: err.Error(),
+			})
+		} else if diff != "" {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Immutable fields changed (-old +new), " + reason,
+				Paths:   []string{field},
+				Details: diff,
+			})
+		}
+	}
+
+	// NoWait only affects how the queue is declared and StreamOffset only
+	// where consuming starts when no offset was stored yet.
+	origQueue, curQueue := orig.Queue, cur.Queue
+	origQueue.NoWait, curQueue.NoWait = false, false
+	origQueue.StreamOffset, curQueue.StreamOffset = "", ""
+	immutable("queue", "the queue must be deleted to be declared with other properties", origQueue, curQueue)
+
+	origExchange, curExchange := orig.Exchange, cur.Exchange
+	origExchange.NoWait, curExchange.NoWait = false, false
+	immutable("exchange", "the exchange must be deleted to be declared with other properties", origExchange, curExchange)
+
+	immutable("bindings", "the existing bindings of the queue would not be removed", orig.Bindings, cur.Bindings)
+	return errs
+}
+
+// validateConsumer checks the consumer options and that the acknowledgement
+// mode suits the queue and channel of the source.
+func (s *RabbitmqSourceSpec) validateConsumer(ctx context.Context) *apis.FieldError {
+	c := s.Consumer
+	if c == nil {
+		return nil
+	}
+	errs := c.Validate(ctx).ViaField("consumer")
+	switch c.AckMode {
+	case AckModeAtMostOnce:
+		if s.Queue.Type == QueueTypeStream {
+			errs = errs.Also(&apis.FieldError{
+				Message: "the messages of stream queues must be acknowledged by the consumer",
+				Paths:   []string{"consumer.ackMode", "queue.type"},
+			})
+		}
+	case AckModeBatch:
+		if prefetch := s.Channel.PrefetchCount; prefetch > 0 && prefetch < c.GetAckBatchSize() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "the prefetch count must be at least the size of the batches",
+				Paths:   []string{"consumer.ackBatchSize", "channel.prefetchCount"},
+				Details: "RabbitMQ stops delivering messages once prefetch count messages are unacknowledged",
+			})
+		}
+	}
+	return errs
+}
+
+func (c *RabbitmqSourceConsumerSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	switch c.AckMode {
+	case "", AckModeAtLeastOnce, AckModeAtMostOnce:
+		if c.AckBatchSize != nil {
+			errs = errs.Also(apis.ErrGeneric("only valid in batch mode", "ackBatchSize"))
+		}
+		if c.AckBatchInterval != nil {
+			errs = errs.Also(apis.ErrGeneric("only valid in batch mode", "ackBatchInterval"))
+		}
+	case AckModeBatch:
+		if size := c.GetAckBatchSize(); size < 1 {
+			errs = errs.Also(invalidValue(size, "ackBatchSize", "must be at least 1"))
+		}
+		if interval := c.GetAckBatchInterval(); interval <= 0 {
+			errs = errs.Also(invalidValue(interval.String(), "ackBatchInterval", "must be positive"))
+		}
+	default:
+		errs = errs.Also(invalidValue(c.AckMode, "ackMode", "expected at-least-once, at-most-once or batch"))
+	}
+	if len(c.Tag) > 255 {
+		errs = errs.Also(invalidValue(c.Tag, "tag", "consumer tags are at most 255 bytes long"))
+	}
+	return errs
+}
+
+// validateReplicas checks that the receive adapter can run several pods
+// competing for the messages of a shared queue.
+func (s *RabbitmqSourceSpec) validateReplicas() *apis.FieldError {
+	var errs *apis.FieldError
+	if s.Replicas != nil && s.Scale != nil {
+		return apis.ErrMultipleOneOf("replicas", "scale")
+	}
+	if s.Replicas != nil && *s.Replicas < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*s.Replicas, "replicas"))
+	}
+	if s.Scale != nil {
+		errs = errs.Also(s.Scale.Validate().ViaField("scale"))
+		if s.Scale.GetMinReplicas() == 0 && s.Queue.AutoDelete {
+			errs = errs.Also(&apis.FieldError{
+				Message: "auto-deleted queues can't be scaled to zero replicas",
+				Paths:   []string{"scale.minReplicas", "queue.autoDelete"},
+				Details: "the queue and its messages would be deleted once the last replica stops consuming",
+			})
+		}
+	}
+
+	field := "scale"
+	if s.Scale == nil {
+		if s.Replicas == nil || *s.Replicas <= 1 {
+			return errs
+		}
+		field = "replicas"
+	}
+	if s.Queue.Name == "" {
+		errs = errs.Also(&apis.FieldError{
+			Message: "a named queue is required to consume with several replicas",
+			Paths:   []string{field, "queue.name"},
+		})
+	}
+	if s.Queue.Exclusive {
+		errs = errs.Also(&apis.FieldError{
+			Message: "exclusive queues can't be consumed by several replicas",
+			Paths:   []string{field, "queue.exclusive"},
+		})
+	}
+	if s.Consumer != nil && s.Consumer.Exclusive {
+		errs = errs.Also(&apis.FieldError{
+			Message: "exclusive consumers can't run with several replicas",
+			Paths:   []string{field, "consumer.exclusive"},
+			Details: "declare the queue with single active consumer to fail over between replicas",
+		})
+	}
+	if s.Queue.Type == QueueTypeStream {
+		errs = errs.Also(&apis.FieldError{
+			Message: "stream queues can't be consumed by several replicas",
+			Paths:   []string{field, "queue.type"},
+		})
+	}
+	return errs
+}
+
+func (s *RabbitmqSourceScaleSpec) Validate() *apis.FieldError {
+	var errs *apis.FieldError
+	if s.MaxReplicas < 1 {
+		errs = errs.Also(invalidValue(s.MaxReplicas, "maxReplicas", "must be at least 1"))
+	}
+	if min := s.GetMinReplicas(); min < 0 || min > s.MaxReplicas {
+		errs = errs.Also(invalidValue(min, "minReplicas", "must be between 0 and maxReplicas"))
+	}
+	if s.GetMessagesPerReplica() < 1 {
+		errs = errs.Also(invalidValue(*s.MessagesPerReplica, "messagesPerReplica", "must be at least 1"))
+	}
+	if s.GetPollingInterval() <= 0 {
+		errs = errs.Also(invalidValue(s.PollingInterval.Duration, "pollingInterval", "must be positive"))
+	}
+	if s.GetCooldownPeriod() < 0 {
+		errs = errs.Also(invalidValue(s.CooldownPeriod.Duration, "cooldownPeriod", "must not be negative"))
+	}
+	return errs
+}
+
+// invalidValue returns an invalid value error explaining what's expected.
+func invalidValue(value interface{}, field, details string) *apis.FieldError {
+	err := apis.ErrInvalidValue(value, field)
+	err.Details = details
+	return err
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+var fullSpec = RabbitmqSourceSpec{
+	SourceSpec: duckv1.SourceSpec{
+		Sink: duckv1.Destination{
+			Ref: &duckv1.KReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink"},
+		},
+	},
+	Connection: RabbitmqSourceConnectionSpec{
+		URI: "amqp://rabbitmq:5672",
+		Credentials: &RabbitmqSourceCredentialsSpec{
+			User:     secretKeySelector("credentials", "user"),
+			Password: secretKeySelector("credentials", "password"),
+		},
+	},
+	Exchange: RabbitmqSourceExchangeSpec{
+		Name:    "logs",
+		Type:    "topic",
+		Durable: true,
+	},
+	Queue: RabbitmqSourceQueueSpec{
+		Name:    "queue",
+		Durable: true,
+	},
+	Bindings:     []RabbitmqSourceBinding{{RoutingKey: "*.critical"}},
+	TopologyMode: TopologyModeDeclare,
+	Channel:      RabbitmqSourceChannelSpec{PrefetchCount: 1},
+}
+
+func secretKeySelector(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+func TestRabbitmqSourceSpecValidation(t *testing.T) {
+	testCases := map[string]struct {
+		update  func(*RabbitmqSourceSpec)
+		allowed bool
+	}{
+		"full spec": {
+			update:  func(*RabbitmqSourceSpec) {},
+			allowed: true,
+		},
+		"missing sink": {
+			update: func(s *RabbitmqSourceSpec) { s.Sink = duckv1.Destination{} },
+		},
+		"missing uri": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.URI = "" },
+		},
+		"uri with another scheme": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.URI = "http://rabbitmq:5672" },
+		},
+		"nodes": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection.URI = "amqp:///vhost"
+				s.Connection.Nodes = []string{"rabbitmq-0:5672", "rabbitmq-1"}
+				s.Connection.NodeOrder = NodeOrderInOrder
+				s.Connection.Heartbeat = &metav1.Duration{Duration: 5 * time.Second}
+				s.Connection.Timeout = &metav1.Duration{Duration: 10 * time.Second}
+				s.Connection.ChannelMax = 16
+				s.Connection.Name = "source"
+			},
+			allowed: true,
+		},
+		"nodes without uri": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection.URI = ""
+				s.Connection.Nodes = []string{"rabbitmq-0:5672"}
+			},
+			allowed: true,
+		},
+		"node with scheme": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.Nodes = []string{"amqp://rabbitmq-0:5672"} },
+		},
+		"unknown node order": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.NodeOrder = "round-robin" },
+		},
+		"non positive heartbeat": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.Heartbeat = &metav1.Duration{} },
+		},
+		"negative channel max": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.ChannelMax = -1 },
+		},
+		"connection reference": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection = RabbitmqSourceConnectionSpec{
+					Ref: &duckv1.KReference{Kind: "RabbitmqCluster", APIVersion: "rabbitmq.com/v1beta1", Name: "rabbit"},
+				}
+			},
+			allowed: true,
+		},
+		"connection reference with credentials": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Connection.URI = ""
+				s.Connection.Ref = &duckv1.KReference{Kind: "Secret", APIVersion: "v1", Name: "rabbit"}
+			},
+		},
+		"credentials without key": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.Credentials.Password.Key = "" },
+		},
+		"EXTERNAL without client certificate": {
+			update: func(s *RabbitmqSourceSpec) { s.Connection.SASLMechanism = "EXTERNAL" },
+		},
+		"negative prefetch": {
+			update: func(s *RabbitmqSourceSpec) { s.Channel.PrefetchCount = -1 },
+		},
+		"topic exchange without name": {
+			update: func(s *RabbitmqSourceSpec) { s.Exchange.Name = "" },
+		},
+		"fanout exchange without name or bindings": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Exchange = RabbitmqSourceExchangeSpec{Type: "fanout"}
+				s.Bindings = nil
+			},
+			allowed: true,
+		},
+		"topic exchange without bindings": {
+			update: func(s *RabbitmqSourceSpec) { s.Bindings = nil },
+		},
+		"malformed topic pattern": {
+			update: func(s *RabbitmqSourceSpec) { s.Bindings[0].RoutingKey = "logs.crit*" },
+		},
+		"binding of another exchange": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Bindings = append(s.Bindings, RabbitmqSourceBinding{
+					Exchange:  "headers",
+					Arguments: &runtime.RawExtension{Raw: []byte(`{"x-match":"any","region":"eu"}`)},
+				})
+			},
+			allowed: true,
+		},
+		"invalid binding match": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Bindings[0].Arguments = &runtime.RawExtension{Raw: []byte(`{"x-match":"some"}`)}
+			},
+		},
+		"quorum queue not durable": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Queue.Type = QueueTypeQuorum
+				s.Queue.Durable = false
+			},
+		},
+		"passive topology without queue name": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.TopologyMode = TopologyModePassive
+				s.Queue.Name = ""
+			},
+		},
+		"replicas of a server-named queue": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Queue.Name = ""
+				s.Replicas = ptr.Int32(2)
+			},
+		},
+		"scale": {
+			update:  func(s *RabbitmqSourceSpec) { s.Scale = &RabbitmqSourceScaleSpec{MaxReplicas: 3} },
+			allowed: true,
+		},
+		"scale to zero of an auto-deleted queue": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Scale = &RabbitmqSourceScaleSpec{MaxReplicas: 3}
+				s.Queue.AutoDelete = true
+			},
+		},
+		"scale of an auto-deleted queue": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Scale = &RabbitmqSourceScaleSpec{MinReplicas: ptr.Int32(1), MaxReplicas: 3}
+				s.Queue.AutoDelete = true
+			},
+			allowed: true,
+		},
+		"exclusive consumer with replicas": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Consumer = &RabbitmqSourceConsumerSpec{Exclusive: true}
+				s.Replicas = ptr.Int32(2)
+			},
+		},
+		"batch acknowledgements": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Channel.PrefetchCount = 10
+				s.Consumer = &RabbitmqSourceConsumerSpec{AckMode: AckModeBatch, AckBatchSize: ptr.Int32(10)}
+			},
+			allowed: true,
+		},
+		"batches larger than the prefetch count": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Channel.PrefetchCount = 10
+				s.Consumer = &RabbitmqSourceConsumerSpec{AckMode: AckModeBatch, AckBatchSize: ptr.Int32(20)}
+			},
+		},
+		"at-most-once stream": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Queue.Type = QueueTypeStream
+				s.Consumer = &RabbitmqSourceConsumerSpec{AckMode: AckModeAtMostOnce}
+			},
+		},
+		"context attribute override": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.CloudEventOverrides = &duckv1.CloudEventOverrides{Extensions: map[string]string{"source": "x"}}
+			},
+		},
+		"invalid attribute template": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Attributes = &RabbitmqSourceAttributesSpec{Type: "{{ .routingKey"}
+			},
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &RabbitmqSource{Spec: *fullSpec.DeepCopy()}
+			tc.update(&src.Spec)
+			err := src.Validate(context.TODO())
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected validation result. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
+
+func TestRabbitmqSourceCheckImmutableFields(t *testing.T) {
+	testCases := map[string]struct {
+		update  func(*RabbitmqSourceSpec)
+		allowed bool
+	}{
+		"no change": {
+			update:  func(*RabbitmqSourceSpec) {},
+			allowed: true,
+		},
+		"sink changed": {
+			update: func(s *RabbitmqSourceSpec) {
+				s.Sink.Ref.Name = "other"
+			},
+			allowed: true,
+		},
+		"prefetch changed": {
+			update:  func(s *RabbitmqSourceSpec) { s.Channel.PrefetchCount = 10 },
+			allowed: true,
+		},
+		"queue nowait changed": {
+			update:  func(s *RabbitmqSourceSpec) { s.Queue.NoWait = true },
+			allowed: true,
+		},
+		"queue name changed": {
+			update: func(s *RabbitmqSourceSpec) { s.Queue.Name = "other" },
+		},
+		"exchange durability changed": {
+			update: func(s *RabbitmqSourceSpec) { s.Exchange.Durable = false },
+		},
+		"bindings changed": {
+			update: func(s *RabbitmqSourceSpec) { s.Bindings[0].RoutingKey = "#" },
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			orig := &RabbitmqSource{Spec: *fullSpec.DeepCopy()}
+			updated := &RabbitmqSource{Spec: *fullSpec.DeepCopy()}
+			tc.update(&updated.Spec)
+
+			ctx := apis.WithinUpdate(context.TODO(), orig)
+			err := updated.Validate(ctx)
+			if tc.allowed != (err == nil) {
+				t.Fatalf("Unexpected immutable field check. Expected %v. Actual %v", tc.allowed, err)
+			}
+		})
+	}
+}
@@ -27,7 +27,9 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	versioned "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned"
+	eventing "knative.dev/eventing-rabbitmq/pkg/client/informers/externalversions/eventing"
 	internalinterfaces "knative.dev/eventing-rabbitmq/pkg/client/informers/externalversions/internalinterfaces"
+	messaging "knative.dev/eventing-rabbitmq/pkg/client/informers/externalversions/messaging"
 	sources "knative.dev/eventing-rabbitmq/pkg/client/informers/externalversions/sources"
 )
 
@@ -171,9 +173,19 @@ type SharedInformerFactory interface {
 	ForResource(resource schema.GroupVersionResource) (GenericInformer, error)
 	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
 
+	Eventing() eventing.Interface
+	Messaging() messaging.Interface
 	Sources() sources.Interface
 }
 
+func (f *sharedInformerFactory) Eventing() eventing.Interface {
+	return eventing.New(f, f.namespace, f.tweakListOptions)
+}
+
+func (f *sharedInformerFactory) Messaging() messaging.Interface {
+	return messaging.New(f, f.namespace, f.tweakListOptions)
+}
+
 func (f *sharedInformerFactory) Sources() sources.Interface {
 	return sources.New(f, f.namespace, f.tweakListOptions)
 }
@@ -22,7 +22,10 @@ import (
 
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
-	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	messagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	v1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -51,10 +54,22 @@ func (f *genericInformer) Lister() cache.GenericLister {
 // TODO extend this to unknown resources with a client pool
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
-	// Group=sources.knative.dev, Version=v1alpha1
-	case v1alpha1.SchemeGroupVersion.WithResource("rabbitmqsources"):
+	// Group=eventing.knative.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("rabbitmqsinks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().RabbitmqSinks().Informer()}, nil
+
+		// Group=messaging.knative.dev, Version=v1alpha1
+	case messagingv1alpha1.SchemeGroupVersion.WithResource("rabbitmqchannels"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Messaging().V1alpha1().RabbitmqChannels().Informer()}, nil
+
+		// Group=sources.knative.dev, Version=v1alpha1
+	case sourcesv1alpha1.SchemeGroupVersion.WithResource("rabbitmqsources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1alpha1().RabbitmqSources().Informer()}, nil
 
+		// Group=sources.knative.dev, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("rabbitmqsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sources().V1beta1().RabbitmqSources().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -32,9 +32,15 @@ import (
 	discovery "k8s.io/client-go/discovery"
 	dynamic "k8s.io/client-go/dynamic"
 	rest "k8s.io/client-go/rest"
-	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	messagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	v1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
 	versioned "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned"
+	typedeventingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned/typed/eventing/v1alpha1"
+	typedmessagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned/typed/messaging/v1alpha1"
 	typedsourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned/typed/sources/v1alpha1"
+	typedsourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned/typed/sources/v1beta1"
 	injection "knative.dev/pkg/injection"
 	dynamicclient "knative.dev/pkg/injection/clients/dynamicclient"
 	logging "knative.dev/pkg/logging"
@@ -95,6 +101,298 @@ func convert(from interface{}, to runtime.Object) error {
 	return nil
 }
 
+// EventingV1alpha1 retrieves the EventingV1alpha1Client
+func (w *wrapClient) EventingV1alpha1() typedeventingv1alpha1.EventingV1alpha1Interface {
+	return &wrapEventingV1alpha1{
+		dyn: w.dyn,
+	}
+}
+
+type wrapEventingV1alpha1 struct {
+	dyn dynamic.Interface
+}
+
+func (w *wrapEventingV1alpha1) RESTClient() rest.Interface {
+	panic("RESTClient called on dynamic client!")
+}
+
+func (w *wrapEventingV1alpha1) RabbitmqSinks(namespace string) typedeventingv1alpha1.RabbitmqSinkInterface {
+	return &wrapEventingV1alpha1RabbitmqSinkImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "eventing.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "rabbitmqsinks",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapEventingV1alpha1RabbitmqSinkImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedeventingv1alpha1.RabbitmqSinkInterface = (*wrapEventingV1alpha1RabbitmqSinkImpl)(nil)
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Create(ctx context.Context, in *v1alpha1.RabbitmqSink, opts v1.CreateOptions) (*v1alpha1.RabbitmqSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.RabbitmqSink, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RabbitmqSinkList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSinkList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RabbitmqSink, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Update(ctx context.Context, in *v1alpha1.RabbitmqSink, opts v1.UpdateOptions) (*v1alpha1.RabbitmqSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) UpdateStatus(ctx context.Context, in *v1alpha1.RabbitmqSink, opts v1.UpdateOptions) (*v1alpha1.RabbitmqSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "eventing.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RabbitmqSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapEventingV1alpha1RabbitmqSinkImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+// MessagingV1alpha1 retrieves the MessagingV1alpha1Client
+func (w *wrapClient) MessagingV1alpha1() typedmessagingv1alpha1.MessagingV1alpha1Interface {
+	return &wrapMessagingV1alpha1{
+		dyn: w.dyn,
+	}
+}
+
+type wrapMessagingV1alpha1 struct {
+	dyn dynamic.Interface
+}
+
+func (w *wrapMessagingV1alpha1) RESTClient() rest.Interface {
+	panic("RESTClient called on dynamic client!")
+}
+
+func (w *wrapMessagingV1alpha1) RabbitmqChannels(namespace string) typedmessagingv1alpha1.RabbitmqChannelInterface {
+	return &wrapMessagingV1alpha1RabbitmqChannelImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "messaging.knative.dev",
+			Version:  "v1alpha1",
+			Resource: "rabbitmqchannels",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapMessagingV1alpha1RabbitmqChannelImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedmessagingv1alpha1.RabbitmqChannelInterface = (*wrapMessagingV1alpha1RabbitmqChannelImpl)(nil)
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Create(ctx context.Context, in *messagingv1alpha1.RabbitmqChannel, opts v1.CreateOptions) (*messagingv1alpha1.RabbitmqChannel, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqChannel",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannel{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*messagingv1alpha1.RabbitmqChannel, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannel{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) List(ctx context.Context, opts v1.ListOptions) (*messagingv1alpha1.RabbitmqChannelList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannelList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *messagingv1alpha1.RabbitmqChannel, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannel{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Update(ctx context.Context, in *messagingv1alpha1.RabbitmqChannel, opts v1.UpdateOptions) (*messagingv1alpha1.RabbitmqChannel, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqChannel",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannel{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) UpdateStatus(ctx context.Context, in *messagingv1alpha1.RabbitmqChannel, opts v1.UpdateOptions) (*messagingv1alpha1.RabbitmqChannel, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1alpha1",
+		Kind:    "RabbitmqChannel",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &messagingv1alpha1.RabbitmqChannel{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapMessagingV1alpha1RabbitmqChannelImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 // SourcesV1alpha1 retrieves the SourcesV1alpha1Client
 func (w *wrapClient) SourcesV1alpha1() typedsourcesv1alpha1.SourcesV1alpha1Interface {
 	return &wrapSourcesV1alpha1{
@@ -130,7 +428,7 @@ type wrapSourcesV1alpha1RabbitmqSourceImpl struct {
 
 var _ typedsourcesv1alpha1.RabbitmqSourceInterface = (*wrapSourcesV1alpha1RabbitmqSourceImpl)(nil)
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Create(ctx context.Context, in *v1alpha1.RabbitmqSource, opts v1.CreateOptions) (*v1alpha1.RabbitmqSource, error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Create(ctx context.Context, in *sourcesv1alpha1.RabbitmqSource, opts v1.CreateOptions) (*sourcesv1alpha1.RabbitmqSource, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "sources.knative.dev",
 		Version: "v1alpha1",
@@ -144,7 +442,7 @@ func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Create(ctx context.Context, in *
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSource{}
+	out := &sourcesv1alpha1.RabbitmqSource{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
@@ -159,43 +457,43 @@ func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) DeleteCollection(ctx context.Con
 	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
 }
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.RabbitmqSource, error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*sourcesv1alpha1.RabbitmqSource, error) {
 	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSource{}
+	out := &sourcesv1alpha1.RabbitmqSource{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RabbitmqSourceList, error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) List(ctx context.Context, opts v1.ListOptions) (*sourcesv1alpha1.RabbitmqSourceList, error) {
 	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSourceList{}
+	out := &sourcesv1alpha1.RabbitmqSourceList{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RabbitmqSource, err error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *sourcesv1alpha1.RabbitmqSource, err error) {
 	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSource{}
+	out := &sourcesv1alpha1.RabbitmqSource{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Update(ctx context.Context, in *v1alpha1.RabbitmqSource, opts v1.UpdateOptions) (*v1alpha1.RabbitmqSource, error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Update(ctx context.Context, in *sourcesv1alpha1.RabbitmqSource, opts v1.UpdateOptions) (*sourcesv1alpha1.RabbitmqSource, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "sources.knative.dev",
 		Version: "v1alpha1",
@@ -209,14 +507,14 @@ func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Update(ctx context.Context, in *
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSource{}
+	out := &sourcesv1alpha1.RabbitmqSource{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) UpdateStatus(ctx context.Context, in *v1alpha1.RabbitmqSource, opts v1.UpdateOptions) (*v1alpha1.RabbitmqSource, error) {
+func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) UpdateStatus(ctx context.Context, in *sourcesv1alpha1.RabbitmqSource, opts v1.UpdateOptions) (*sourcesv1alpha1.RabbitmqSource, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "sources.knative.dev",
 		Version: "v1alpha1",
@@ -230,7 +528,7 @@ func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) UpdateStatus(ctx context.Context
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RabbitmqSource{}
+	out := &sourcesv1alpha1.RabbitmqSource{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
@@ -240,3 +538,149 @@ func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) UpdateStatus(ctx context.Context
 func (w *wrapSourcesV1alpha1RabbitmqSourceImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
+
+// SourcesV1beta1 retrieves the SourcesV1beta1Client
+func (w *wrapClient) SourcesV1beta1() typedsourcesv1beta1.SourcesV1beta1Interface {
+	return &wrapSourcesV1beta1{
+		dyn: w.dyn,
+	}
+}
+
+type wrapSourcesV1beta1 struct {
+	dyn dynamic.Interface
+}
+
+func (w *wrapSourcesV1beta1) RESTClient() rest.Interface {
+	panic("RESTClient called on dynamic client!")
+}
+
+func (w *wrapSourcesV1beta1) RabbitmqSources(namespace string) typedsourcesv1beta1.RabbitmqSourceInterface {
+	return &wrapSourcesV1beta1RabbitmqSourceImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "sources.knative.dev",
+			Version:  "v1beta1",
+			Resource: "rabbitmqsources",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapSourcesV1beta1RabbitmqSourceImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedsourcesv1beta1.RabbitmqSourceInterface = (*wrapSourcesV1beta1RabbitmqSourceImpl)(nil)
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Create(ctx context.Context, in *v1beta1.RabbitmqSource, opts v1.CreateOptions) (*v1beta1.RabbitmqSource, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "sources.knative.dev",
+		Version: "v1beta1",
+		Kind:    "RabbitmqSource",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSource{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.RabbitmqSource, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSource{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) List(ctx context.Context, opts v1.ListOptions) (*v1beta1.RabbitmqSourceList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSourceList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.RabbitmqSource, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSource{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Update(ctx context.Context, in *v1beta1.RabbitmqSource, opts v1.UpdateOptions) (*v1beta1.RabbitmqSource, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "sources.knative.dev",
+		Version: "v1beta1",
+		Kind:    "RabbitmqSource",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSource{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) UpdateStatus(ctx context.Context, in *v1beta1.RabbitmqSource, opts v1.UpdateOptions) (*v1beta1.RabbitmqSource, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "sources.knative.dev",
+		Version: "v1beta1",
+		Kind:    "RabbitmqSource",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.RabbitmqSource{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSourcesV1beta1RabbitmqSourceImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
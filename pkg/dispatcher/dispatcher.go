@@ -18,16 +18,17 @@ package dispatcher
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
 	"github.com/NeowayLabs/wabbit"
+	wabbitamqp "github.com/NeowayLabs/wabbit/amqp"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/pkg/errors"
 	amqperr "github.com/streadway/amqp"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 )
@@ -37,6 +38,8 @@ const (
 )
 
 type Dispatcher struct {
+	// Where responses of the subscriber are sent. If empty, they are
+	// dropped, as for the subscribers of channels without a reply.
 	brokerIngressURL string
 	subscriberURL    string
 
@@ -47,9 +50,16 @@ type Dispatcher struct {
 	maxRetries    int
 	backoffDelay  time.Duration
 	backoffPolicy eventingduckv1.BackoffPolicyType
+
+	// How long in-flight messages are given to complete once asked to stop.
+	shutdownTimeout time.Duration
+
+	// Exchange that messages which cannot be converted into events are routed
+	// to. If empty, they are NACK-ed without requeueing instead.
+	dlxName string
 }
 
-func NewDispatcher(brokerIngressURL, subscriberURL string, requeue bool, maxRetries int, backoffDelay time.Duration, backoffPolicy eventingduckv1.BackoffPolicyType) *Dispatcher {
+func NewDispatcher(brokerIngressURL, subscriberURL string, requeue bool, maxRetries int, backoffDelay time.Duration, backoffPolicy eventingduckv1.BackoffPolicyType, shutdownTimeout time.Duration, dlxName string) *Dispatcher {
 	return &Dispatcher{
 		brokerIngressURL: brokerIngressURL,
 		subscriberURL:    subscriberURL,
@@ -57,15 +67,21 @@ func NewDispatcher(brokerIngressURL, subscriberURL string, requeue bool, maxRetr
 		maxRetries:       maxRetries,
 		backoffDelay:     backoffDelay,
 		backoffPolicy:    backoffPolicy,
+		shutdownTimeout:  shutdownTimeout,
+		dlxName:          dlxName,
 	}
 }
 
 // ConsumeFromQueue consumes messages from the given message channel and queue.
-// When the context is cancelled a context.Canceled error is returned.
+// When the context is cancelled the consumer is cancelled first, the message
+// being dispatched is given up to the shutdown timeout to complete and any
+// messages that were delivered but not yet dispatched are requeued. A
+// context.Canceled error is then returned.
 func (d *Dispatcher) ConsumeFromQueue(ctx context.Context, channel wabbit.Channel, queueName string) error {
+	consumerTag := string(uuid.NewUUID())
 	msgs, err := channel.Consume(
-		queueName, // queue
-		"",        // consumer
+		queueName,   // queue
+		consumerTag, // consumer
 		wabbit.Option{
 			"autoAck":   false,
 			"exclusive": false,
@@ -82,78 +98,215 @@ func (d *Dispatcher) ConsumeFromQueue(ctx context.Context, channel wabbit.Channe
 		return errors.Wrap(err, "create http client")
 	}
 
+	// Messages are dispatched with a context that outlives ctx, so that in-flight
+	// deliveries and their retries are not abandoned as soon as we are asked to
+	// stop. It is only cancelled once the shutdown timeout has elapsed.
+	dispatchCtx, cancelDispatch := context.WithCancel(logging.WithLogger(context.Background(), logging.FromContext(ctx)))
+	defer cancelDispatch()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-dispatchCtx.Done():
+			return
+		}
+		timer := time.NewTimer(d.shutdownTimeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			logging.FromContext(ctx).Warn("shutdown timeout elapsed, abandoning in-flight message")
+			cancelDispatch()
+		case <-dispatchCtx.Done():
+		}
+	}()
+
+	source := rawMessageSource(queueName)
+
 	logging.FromContext(ctx).Info("rabbitmq receiver started, exit with CTRL+C")
 	logging.FromContext(ctx).Infow("Starting to process messages", zap.String("queue", queueName))
 
 	for {
+		// Check for shutdown before picking up another message, select does not
+		// prioritize between ready cases.
+		if ctx.Err() != nil {
+			return d.shutdown(ctx, channel, consumerTag, msgs)
+		}
+
 		select {
 		case <-ctx.Done():
-			logging.FromContext(ctx).Info("context done, stopping message consumer")
-			return ctx.Err()
+			return d.shutdown(ctx, channel, consumerTag, msgs)
 
 		case msg, ok := <-msgs:
 			if !ok {
 				logging.FromContext(ctx).Warn("message channel closed, stopping message consumer")
 				return amqperr.ErrClosed
 			}
+			d.dispatch(dispatchCtx, ceClient, channel, source, msg)
+		}
+	}
+}
+
+// shutdown cancels the consumer so that no new messages are delivered and
+// returns the messages that were already delivered, but not dispatched, back
+// to the queue.
+func (d *Dispatcher) shutdown(ctx context.Context, channel wabbit.Channel, consumerTag string, msgs <-chan wabbit.Delivery) error {
+	logging.FromContext(ctx).Info("context done, stopping message consumer")
+	if err := channel.Cancel(consumerTag, false); err != nil {
+		logging.FromContext(ctx).Warn("failed to cancel consumer: ", err)
+	}
 
-			event := cloudevents.NewEvent()
-			err := json.Unmarshal(msg.Body(), &event)
-			if err != nil {
-				logging.FromContext(ctx).Warn("failed to unmarshal event (NACK-ing and not re-queueing): ", err)
-				err = msg.Nack(ackMultiple, false) // do not requeue
-				if err != nil {
-					logging.FromContext(ctx).Warn("failed to NACK event: ", err)
-				}
-				continue
+	timer := time.NewTimer(d.shutdownTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return ctx.Err()
 			}
+			if err := msg.Nack(ackMultiple, true); err != nil {
+				logging.FromContext(ctx).Warn("failed to NACK event: ", err)
+			}
+		case <-timer.C:
+			logging.FromContext(ctx).Warn("timed out waiting for the consumer to be cancelled")
+			return ctx.Err()
+		}
+	}
+}
 
-			logging.FromContext(ctx).Debugf("Got event as: %+v", event)
-			ctx = cloudevents.ContextWithTarget(ctx, d.subscriberURL)
+// dispatch sends the message to the subscriber and any response to the broker
+// ingress, ACK-ing or NACK-ing the message accordingly.
+func (d *Dispatcher) dispatch(ctx context.Context, ceClient cloudevents.Client, channel wabbit.Channel, source string, msg wabbit.Delivery) {
+	event, err := toEvent(msg, source)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to convert message to event (dead-lettering): ", err)
+		d.deadLetter(ctx, channel, msg, err.Error())
+		return
+	}
 
-			// Our dispatcher uses Retries, but cloudevents is the max total tries. So we need
-			// to adjust to initial + retries.
-			// TODO: What happens if I specify 0 to cloudevents. Does it not even retry.
-			retryCount := d.maxRetries
-			if d.backoffPolicy == eventingduckv1.BackoffPolicyLinear {
-				ctx = cloudevents.ContextWithRetriesLinearBackoff(ctx, d.backoffDelay, retryCount)
-			} else {
-				ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, d.backoffDelay, retryCount)
-			}
+	logging.FromContext(ctx).Debugf("Got event as: %+v", event)
+	subscriberCtx := cloudevents.ContextWithTarget(ctx, d.subscriberURL)
 
-			response, result := ceClient.Request(ctx, event)
-			if !isSuccess(ctx, result) {
-				logging.FromContext(ctx).Warnf("Failed to deliver to %q requeue: %v", d.subscriberURL, d.requeue)
-				err = msg.Nack(ackMultiple, d.requeue)
-				if err != nil {
-					logging.FromContext(ctx).Warn("failed to NACK event: ", err)
-				}
-				continue
-			}
+	// Our dispatcher uses Retries, but cloudevents is the max total tries. So we need
+	// to adjust to initial + retries.
+	// TODO: What happens if I specify 0 to cloudevents. Does it not even retry.
+	retryCount := d.maxRetries
+	if d.backoffPolicy == eventingduckv1.BackoffPolicyLinear {
+		subscriberCtx = cloudevents.ContextWithRetriesLinearBackoff(subscriberCtx, d.backoffDelay, retryCount)
+	} else {
+		subscriberCtx = cloudevents.ContextWithRetriesExponentialBackoff(subscriberCtx, d.backoffDelay, retryCount)
+	}
 
-			logging.FromContext(ctx).Debugf("Got Response: %+v", response)
-			if response != nil {
-				logging.FromContext(ctx).Infof("Sending an event: %+v", response)
-				ctx = cloudevents.ContextWithTarget(ctx, d.brokerIngressURL)
-				backoffDelay := 50 * time.Millisecond
-				// Use the retries so we can just parse out the results in a common way.
-				cloudevents.ContextWithRetriesExponentialBackoff(ctx, backoffDelay, 1)
-				result := ceClient.Send(ctx, *response)
-				if !isSuccess(ctx, result) {
-					logging.FromContext(ctx).Warnf("Failed to deliver to %q requeue: %v", d.brokerIngressURL, d.requeue)
-					err = msg.Nack(ackMultiple, d.requeue) // not multiple
-					if err != nil {
-						logging.FromContext(ctx).Warn("failed to NACK event: ", err)
-					}
-					continue
-				}
-			}
+	response, result := ceClient.Request(subscriberCtx, *event)
+	if !isSuccess(ctx, result) {
+		d.nack(ctx, msg, d.subscriberURL)
+		return
+	}
+
+	logging.FromContext(ctx).Debugf("Got Response: %+v", response)
+	if response != nil && d.brokerIngressURL == "" {
+		logging.FromContext(ctx).Debug("Dropping the response, no reply URL")
+	} else if response != nil {
+		logging.FromContext(ctx).Infof("Sending an event: %+v", response)
+		brokerCtx := cloudevents.ContextWithTarget(subscriberCtx, d.brokerIngressURL)
+		backoffDelay := 50 * time.Millisecond
+		// Use the retries so we can just parse out the results in a common way.
+		cloudevents.ContextWithRetriesExponentialBackoff(brokerCtx, backoffDelay, 1)
+		result := ceClient.Send(brokerCtx, *response)
+		if !isSuccess(ctx, result) {
+			d.nack(ctx, msg, d.brokerIngressURL)
+			return
+		}
+	}
 
-			err = msg.Ack(ackMultiple)
-			if err != nil {
+	err = msg.Ack(ackMultiple)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to ACK event: ", err)
+	}
+}
+
+// deadLetter routes a message that cannot be dispatched to the dead letter
+// exchange, recording why in the DeadLetterReasonHeader. Without a dead letter
+// exchange configured, or if publishing to it fails, the message is NACK-ed
+// without requeueing so that the queue's own dead-lettering applies.
+func (d *Dispatcher) deadLetter(ctx context.Context, channel wabbit.Channel, msg wabbit.Delivery, reason string) {
+	if d.dlxName != "" {
+		err := d.publishDeadLetter(channel, msg, reason)
+		if err == nil {
+			if err = msg.Ack(ackMultiple); err != nil {
 				logging.FromContext(ctx).Warn("failed to ACK event: ", err)
 			}
+			return
 		}
+		logging.FromContext(ctx).Warnf("failed to publish to dead letter exchange %q: %v", d.dlxName, err)
+	}
+	if err := msg.Nack(ackMultiple, false); err != nil { // do not requeue
+		logging.FromContext(ctx).Warn("failed to NACK event: ", err)
+	}
+}
+
+// publishDeadLetter republishes msg to the dead letter exchange with the
+// routing key it was published with.
+func (d *Dispatcher) publishDeadLetter(channel wabbit.Channel, msg wabbit.Delivery, reason string) error {
+	publishing := deadLetterMessage(msg, reason)
+	routingKey := deliveryProperties(msg).routingKey
+	if c, ok := channel.(*wabbitamqp.Channel); ok {
+		return c.Channel.Publish(d.dlxName, routingKey, false, false, publishing)
+	}
+	// Other channels don't support every message property.
+	return channel.Publish(d.dlxName, routingKey, publishing.Body, wabbit.Option{
+		"headers":      publishing.Headers,
+		"contentType":  publishing.ContentType,
+		"messageId":    publishing.MessageId,
+		"deliveryMode": publishing.DeliveryMode,
+	})
+}
+
+// deadLetterMessage returns the message republishing msg to the dead letter
+// exchange, with the properties of msg and reason in the
+// DeadLetterReasonHeader. As when RabbitMQ dead-letters messages, the
+// expiration is dropped so that the dead letter is not expired in turn.
+func deadLetterMessage(msg wabbit.Delivery, reason string) amqperr.Publishing {
+	headers := amqperr.Table{}
+	for key, val := range msg.Headers() {
+		headers[key] = val
+	}
+	headers[DeadLetterReasonHeader] = reason
+
+	d, ok := msg.(*wabbitamqp.Delivery)
+	if !ok || d.Delivery == nil {
+		// Only deliveries from a real RabbitMQ carry properties other than
+		// the message id.
+		return amqperr.Publishing{
+			Headers:      headers,
+			MessageId:    msg.MessageId(),
+			DeliveryMode: amqperr.Persistent,
+			Body:         msg.Body(),
+		}
+	}
+	return amqperr.Publishing{
+		Headers:         headers,
+		ContentType:     d.Delivery.ContentType,
+		ContentEncoding: d.Delivery.ContentEncoding,
+		DeliveryMode:    d.Delivery.DeliveryMode,
+		Priority:        d.Delivery.Priority,
+		CorrelationId:   d.Delivery.CorrelationId,
+		ReplyTo:         d.Delivery.ReplyTo,
+		MessageId:       d.Delivery.MessageId,
+		Timestamp:       d.Delivery.Timestamp,
+		Type:            d.Delivery.Type,
+		UserId:          d.Delivery.UserId,
+		AppId:           d.Delivery.AppId,
+		Body:            d.Delivery.Body,
+	}
+}
+
+// nack NACKs a message that could not be delivered to target. Messages that
+// failed because the shutdown timeout elapsed are always requeued, as they
+// were not given a chance to be delivered.
+func (d *Dispatcher) nack(ctx context.Context, msg wabbit.Delivery, target string) {
+	requeue := d.requeue || ctx.Err() != nil
+	logging.FromContext(ctx).Warnf("Failed to deliver to %q requeue: %v", target, requeue)
+	if err := msg.Nack(ackMultiple, requeue); err != nil {
+		logging.FromContext(ctx).Warn("failed to NACK event: ", err)
 	}
 }
 
@@ -30,11 +30,13 @@ import (
 	"time"
 
 	"github.com/NeowayLabs/wabbit"
+	wabbitamqp "github.com/NeowayLabs/wabbit/amqp"
 	"github.com/NeowayLabs/wabbit/amqptest/server"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
 	ce "github.com/cloudevents/sdk-go/v2/event"
 	"github.com/google/go-cmp/cmp"
+	"github.com/streadway/amqp"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 )
 
@@ -136,7 +138,7 @@ func TestFailToConsume(t *testing.T) {
 	if err != nil {
 		t.Error("Failed to create rabbit and queue")
 	}
-	d := NewDispatcher("", "", false, 1, backoffDelay, eventingduckv1.BackoffPolicyExponential)
+	d := NewDispatcher("", "", false, 1, backoffDelay, eventingduckv1.BackoffPolicyExponential, time.Second, "")
 	err = d.ConsumeFromQueue(context.TODO(), ch, "nosuchqueue")
 	if err == nil {
 		t.Fatal("Did not fail to consume.", err)
@@ -157,6 +159,8 @@ func TestEndToEnd(t *testing.T) {
 		// Broker config, how many events to expect, how to respond, etc.
 		brokerReceiveCount int
 		brokerHandlers     []handlerFunc
+		// Don't send responses anywhere.
+		noReply bool
 
 		// Delivery configuration
 		requeue       bool
@@ -197,12 +201,12 @@ func TestEndToEnd(t *testing.T) {
 			expectedSubscriberBodies: []string{expectedData, expectedData2},
 			consumeErr:               context.Canceled,
 		},
-		"two events, first malformed, one delivered": {
-			subscriberReceiveCount:   1,
-			subscriberHandlers:       []handlerFunc{accepted},
+		"two events, first not a cloudevent, both delivered": {
+			subscriberReceiveCount:   2,
+			subscriberHandlers:       []handlerFunc{accepted, accepted},
 			rawMessages:              [][]byte{[]byte("garbage")},
 			events:                   []ce.Event{createEvent(eventData2)},
-			expectedSubscriberBodies: []string{expectedData2},
+			expectedSubscriberBodies: []string{"garbage", expectedData2},
 			consumeErr:               context.Canceled,
 		},
 		"One event, success, response, goes to broker, accepted": {
@@ -227,6 +231,15 @@ func TestEndToEnd(t *testing.T) {
 			expectedBrokerBodies:     []string{expectedResponseData},
 			consumeErr:               context.Canceled,
 		},
+		"One event, success, response, dropped without reply": {
+			subscriberReceiveCount:   1,
+			subscriberHandlers:       []handlerFunc{accepted},
+			events:                   []ce.Event{createEvent(eventData)},
+			expectedSubscriberBodies: []string{expectedData},
+			responseEvents:           []ce.Event{createEvent(responseData)},
+			noReply:                  true,
+			consumeErr:               context.Canceled,
+		},
 		// ** With requeues **
 		"One event, success, no response, requeue": {
 			subscriberReceiveCount:   1,
@@ -322,7 +335,11 @@ func TestEndToEnd(t *testing.T) {
 				backoffPolicy = eventingduckv1.BackoffPolicyLinear
 
 			}
-			d := NewDispatcher(broker.URL, subscriber.URL, tc.requeue, tc.maxRetries, backoffDelay, backoffPolicy)
+			brokerURL := broker.URL
+			if tc.noReply {
+				brokerURL = ""
+			}
+			d := NewDispatcher(brokerURL, subscriber.URL, tc.requeue, tc.maxRetries, backoffDelay, backoffPolicy, time.Second, "")
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -384,6 +401,241 @@ func TestEndToEnd(t *testing.T) {
 	}
 }
 
+func TestShutdown(t *testing.T) {
+	testCases := map[string]struct {
+		shutdownTimeout time.Duration
+		// Whether the subscriber completes before the shutdown timeout elapses.
+		completes bool
+		// How many messages we expect to be left in the queue.
+		wantMessages int
+	}{
+		"in-flight message completes and is acked": {
+			shutdownTimeout: 5 * time.Second,
+			completes:       true,
+			wantMessages:    0,
+		},
+		"in-flight message times out and is requeued": {
+			shutdownTimeout: 100 * time.Millisecond,
+			completes:       false,
+			wantMessages:    1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer subscriber.Close()
+
+			ch, srv, err := createRabbitAndQueue()
+			if err != nil {
+				t.Fatal("Failed to create Rabbit and queue:", err)
+			}
+			defer srv.Stop()
+
+			b, err := json.Marshal(createEvent(eventData))
+			if err != nil {
+				t.Fatal("Failed to marshal the event:", err)
+			}
+			if err := ch.Publish(exchangeName, "process.data", b, nil); err != nil {
+				t.Fatal("Failed to publish event:", err)
+			}
+
+			d := NewDispatcher("", subscriber.URL, false, 0, time.Second, eventingduckv1.BackoffPolicyExponential, tc.shutdownTimeout, "")
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- d.ConsumeFromQueue(ctx, ch, queueName)
+			}()
+
+			select {
+			case <-started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the subscriber to receive the event")
+			}
+			cancel()
+
+			if tc.completes {
+				select {
+				case err := <-errCh:
+					t.Fatal("Consumer returned before the in-flight message completed:", err)
+				case <-time.After(100 * time.Millisecond):
+				}
+				close(release)
+			} else {
+				defer close(release)
+			}
+
+			select {
+			case err := <-errCh:
+				if err != context.Canceled {
+					t.Errorf("unexpected consumer error, want %v got %v", context.Canceled, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the consumer to stop")
+			}
+
+			// The fake server does not report queue depth, so count what is left by
+			// consuming it.
+			msgs, err := ch.Consume(queueName, "", nil)
+			if err != nil {
+				t.Fatal("Failed to consume from queue:", err)
+			}
+			gotMessages := 0
+		count:
+			for {
+				select {
+				case <-msgs:
+					gotMessages++
+				case <-time.After(200 * time.Millisecond):
+					break count
+				}
+			}
+			ch.Close()
+			if gotMessages != tc.wantMessages {
+				t.Errorf("queue has %d messages, wanted %d", gotMessages, tc.wantMessages)
+			}
+		})
+	}
+}
+
+func TestDeadLetterInvalidEvent(t *testing.T) {
+	const (
+		dlxName = "knative-testbroker-dlx"
+		dlqName = "dlq"
+	)
+	subscriberDone := make(chan bool, 1)
+	subscriberHandler := &fakeHandler{
+		handlers:  []handlerFunc{accepted},
+		done:      subscriberDone,
+		exitAfter: 1,
+	}
+	subscriber := httptest.NewServer(subscriberHandler)
+	defer subscriber.Close()
+
+	ch, srv, err := createRabbitAndQueue()
+	if err != nil {
+		t.Fatal("Failed to create Rabbit and queue:", err)
+	}
+	defer srv.Stop()
+	if err := ch.ExchangeDeclare(dlxName, "direct", nil); err != nil {
+		t.Fatal("Failed to declare DLX:", err)
+	}
+	if _, err := ch.QueueDeclare(dlqName, nil); err != nil {
+		t.Fatal("Failed to declare DLQ:", err)
+	}
+	if err := ch.QueueBind(dlqName, "", dlxName, nil); err != nil {
+		t.Fatal("Failed to bind DLQ:", err)
+	}
+
+	// A binary mode message with an unknown specversion can not be converted,
+	// the valid event that follows it must still be delivered.
+	invalid := wabbit.Option{"headers": amqp.Table{"cloudEvents_specversion": "0.1"}}
+	if err := ch.Publish(exchangeName, "process.data", []byte("invalid"), invalid); err != nil {
+		t.Fatal("Failed to publish invalid message:", err)
+	}
+	b, err := json.Marshal(createEvent(eventData))
+	if err != nil {
+		t.Fatal("Failed to marshal the event:", err)
+	}
+	if err := ch.Publish(exchangeName, "process.data", b, nil); err != nil {
+		t.Fatal("Failed to publish event:", err)
+	}
+
+	d := NewDispatcher("", subscriber.URL, false, 0, time.Second, eventingduckv1.BackoffPolicyExponential, time.Second, dlxName)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- d.ConsumeFromQueue(ctx, ch, queueName)
+	}()
+
+	select {
+	case <-subscriberDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the subscriber to receive the event")
+	}
+	cancel()
+	<-errCh
+
+	if diff := cmp.Diff([]string{expectedData}, subscriberHandler.getBodies()); diff != "" {
+		t.Error("unexpected subscriber diff (-want, +got) = ", diff)
+	}
+
+	msgs, err := ch.Consume(dlqName, "", nil)
+	if err != nil {
+		t.Fatal("Failed to consume from DLQ:", err)
+	}
+	select {
+	case msg := <-msgs:
+		if string(msg.Body()) != "invalid" {
+			t.Errorf("unexpected dead-lettered message body %q", msg.Body())
+		}
+		if reason, _ := msg.Headers()[DeadLetterReasonHeader].(string); reason == "" {
+			t.Errorf("dead-lettered message has no %s header", DeadLetterReasonHeader)
+		}
+	case <-time.After(time.Second):
+		t.Error("Timed out waiting for the invalid message in the DLQ")
+	}
+	ch.Close()
+}
+
+func TestDeadLetterMessage(t *testing.T) {
+	timestamp := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	msg := &wabbitamqp.Delivery{Delivery: &amqp.Delivery{
+		Headers:         amqp.Table{"cloudEvents_specversion": "0.1"},
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+		DeliveryMode:    amqp.Persistent,
+		Priority:        5,
+		CorrelationId:   "correlation",
+		ReplyTo:         "replies",
+		Expiration:      "60000",
+		MessageId:       "id",
+		Timestamp:       timestamp,
+		Type:            "type",
+		UserId:          "user",
+		AppId:           "app",
+		RoutingKey:      "process.data",
+		Body:            []byte("invalid"),
+	}}
+
+	want := amqp.Publishing{
+		Headers: amqp.Table{
+			"cloudEvents_specversion": "0.1",
+			DeadLetterReasonHeader:    "unknown specversion",
+		},
+		ContentType:     "application/json",
+		ContentEncoding: "gzip",
+		DeliveryMode:    amqp.Persistent,
+		Priority:        5,
+		CorrelationId:   "correlation",
+		ReplyTo:         "replies",
+		MessageId:       "id",
+		Timestamp:       timestamp,
+		Type:            "type",
+		UserId:          "user",
+		AppId:           "app",
+		Body:            []byte("invalid"),
+	}
+	if diff := cmp.Diff(want, deadLetterMessage(msg, "unknown specversion")); diff != "" {
+		t.Error("unexpected dead letter message (-want, +got) =", diff)
+	}
+	if _, ok := msg.Delivery.Headers[DeadLetterReasonHeader]; ok {
+		t.Error("the headers of the delivery were modified")
+	}
+}
+
 func createRabbitAndQueue() (wabbit.Channel, *server.AMQPServer, error) {
 	fakeServer := server.NewServer(rabbitURL)
 	err := fakeServer.Start()
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	wabbitamqp "github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+)
+
+const (
+	// RawMessageEventType is the type of the CloudEvents wrapping messages
+	// that were not published as CloudEvents.
+	RawMessageEventType = "dev.knative.rabbitmq.message"
+
+	// DeadLetterReasonHeader is set on messages routed to the dead letter
+	// exchange because they could not be converted into a CloudEvent.
+	DeadLetterReasonHeader = "x-knative-dead-letter-reason"
+
+	// Content type of wrapped messages that have none.
+	defaultContentType = "application/octet-stream"
+)
+
+// The AMQP binding of CloudEvents carries attributes in binary content mode
+// as application properties with these prefixes. Earlier drafts of the
+// binding used ':' as the separator.
+var binaryVersions = []*spec.Versions{
+	spec.WithPrefix("cloudEvents_"),
+	spec.WithPrefix("cloudEvents:"),
+}
+
+// properties are the AMQP message properties used when converting a message
+// into a CloudEvent.
+type properties struct {
+	contentType string
+	messageID   string
+	typ         string
+	appID       string
+	routingKey  string
+	timestamp   time.Time
+}
+
+// deliveryProperties returns the properties of the message. Only deliveries
+// from a real RabbitMQ carry properties other than the message id.
+func deliveryProperties(msg wabbit.Delivery) properties {
+	p := properties{messageID: msg.MessageId()}
+	if d, ok := msg.(*wabbitamqp.Delivery); ok && d.Delivery != nil {
+		p.contentType = d.Delivery.ContentType
+		p.typ = d.Delivery.Type
+		p.appID = d.Delivery.AppId
+		p.routingKey = d.Delivery.RoutingKey
+		p.timestamp = d.Delivery.Timestamp
+	}
+	return p
+}
+
+// toEvent converts the message into a CloudEvent. Messages published as
+// CloudEvents, in either structured or binary content mode, are converted back
+// into the original event. Any other message is wrapped into a new event built
+// from its properties, using source when the message has no app-id.
+// An error is returned if the message claims to be a CloudEvent but is not a
+// valid one.
+func toEvent(msg wabbit.Delivery, source string) (*cloudevents.Event, error) {
+	props := deliveryProperties(msg)
+
+	if event, err := dialer.BinaryToEvent(binaryVersions, msg.Headers(), props.contentType, msg.Body()); event != nil || err != nil {
+		return event, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(props.contentType)
+	event := cloudevents.NewEvent()
+	err := json.Unmarshal(msg.Body(), &event)
+	if err == nil {
+		err = event.Validate()
+	}
+	if err == nil {
+		return &event, nil
+	}
+	if mediaType == cloudevents.ApplicationCloudEventsJSON {
+		return nil, fmt.Errorf("invalid structured CloudEvent: %w", err)
+	}
+
+	return wrapMessage(props, source, msg.Body())
+}
+
+// wrapMessage wraps a message that is not a CloudEvent into a new one.
+func wrapMessage(props properties, source string, body []byte) (*cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if props.messageID != "" {
+		event.SetID(props.messageID)
+	} else {
+		event.SetID(string(uuid.NewUUID()))
+	}
+	if props.typ != "" {
+		event.SetType(props.typ)
+	} else {
+		event.SetType(RawMessageEventType)
+	}
+	if props.appID != "" {
+		event.SetSource(props.appID)
+	} else {
+		event.SetSource(source)
+	}
+	if props.routingKey != "" {
+		event.SetSubject(props.routingKey)
+	}
+	if !props.timestamp.IsZero() {
+		event.SetTime(props.timestamp)
+	}
+
+	contentType := props.contentType
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	if err := event.SetData(contentType, body); err != nil {
+		return nil, fmt.Errorf("failed to wrap message: %w", err)
+	}
+	if err := event.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to wrap message: %w", err)
+	}
+	return &event, nil
+}
+
+// rawMessageSource is the source of the events wrapping messages consumed
+// from the queue that have no app-id.
+func rawMessageSource(queueName string) string {
+	return fmt.Sprintf("/rabbitmq/queues/%s", queueName)
+}
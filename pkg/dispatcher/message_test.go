@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	wabbitamqp "github.com/NeowayLabs/wabbit/amqp"
+	"github.com/streadway/amqp"
+)
+
+func TestToEvent(t *testing.T) {
+	ts := time.Date(2021, 9, 1, 10, 0, 0, 0, time.UTC)
+	structured, err := json.Marshal(createEvent(eventData))
+	if err != nil {
+		t.Fatal("Failed to marshal the event:", err)
+	}
+
+	for _, tt := range []struct {
+		name            string
+		delivery        amqp.Delivery
+		wantErr         bool
+		wantID          string
+		wantType        string
+		wantSource      string
+		wantSubject     string
+		wantContentType string
+		wantData        string
+		wantExtension   map[string]interface{}
+	}{{
+		name: "structured",
+		delivery: amqp.Delivery{
+			ContentType: "application/json",
+			Body:        structured,
+		},
+		wantID:          "test-id",
+		wantType:        "testtype",
+		wantSource:      "testsource",
+		wantSubject:     "testsource-testtype",
+		wantContentType: "application/json",
+		wantData:        expectedData,
+	}, {
+		name: "structured with invalid body",
+		delivery: amqp.Delivery{
+			ContentType: "application/cloudevents+json; charset=utf-8",
+			Body:        []byte(`{"specversion":"1.0"}`),
+		},
+		wantErr: true,
+	}, {
+		name: "binary",
+		delivery: amqp.Delivery{
+			ContentType: "text/plain",
+			Headers: amqp.Table{
+				"cloudEvents_specversion": "1.0",
+				"cloudEvents_id":          "binary-id",
+				"cloudEvents_type":        "binarytype",
+				"cloudEvents_source":      "binarysource",
+				"cloudEvents_myext":       "myval",
+				"unrelated":               "header",
+			},
+			Body: []byte("hello"),
+		},
+		wantID:          "binary-id",
+		wantType:        "binarytype",
+		wantSource:      "binarysource",
+		wantContentType: "text/plain",
+		wantData:        "hello",
+		wantExtension:   map[string]interface{}{"myext": "myval"},
+	}, {
+		name: "binary with legacy prefix",
+		delivery: amqp.Delivery{
+			Headers: amqp.Table{
+				"cloudEvents:specversion": "1.0",
+				"cloudEvents:id":          "binary-id",
+				"cloudEvents:type":        "binarytype",
+				"cloudEvents:source":      "binarysource",
+			},
+		},
+		wantID:     "binary-id",
+		wantType:   "binarytype",
+		wantSource: "binarysource",
+	}, {
+		name: "binary missing required attributes",
+		delivery: amqp.Delivery{
+			Headers: amqp.Table{
+				"cloudEvents_specversion": "1.0",
+				"cloudEvents_id":          "binary-id",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "binary with unknown specversion",
+		delivery: amqp.Delivery{
+			Headers: amqp.Table{"cloudEvents_specversion": "0.1"},
+		},
+		wantErr: true,
+	}, {
+		name: "raw message with properties",
+		delivery: amqp.Delivery{
+			MessageId:   "raw-id",
+			Type:        "order.created",
+			AppId:       "orders",
+			RoutingKey:  "orders.eu",
+			Timestamp:   ts,
+			ContentType: "application/xml",
+			Body:        []byte("<order/>"),
+		},
+		wantID:          "raw-id",
+		wantType:        "order.created",
+		wantSource:      "orders",
+		wantSubject:     "orders.eu",
+		wantContentType: "application/xml",
+		wantData:        "<order/>",
+	}, {
+		name: "raw message without properties",
+		delivery: amqp.Delivery{
+			Body: []byte("garbage"),
+		},
+		wantType:        RawMessageEventType,
+		wantSource:      rawMessageSource(queueName),
+		wantContentType: defaultContentType,
+		wantData:        "garbage",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := tt.delivery
+			event, err := toEvent(&wabbitamqp.Delivery{Delivery: &delivery}, rawMessageSource(queueName))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got event %v", event)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if tt.wantID != "" && event.ID() != tt.wantID {
+				t.Errorf("unexpected id, want %q got %q", tt.wantID, event.ID())
+			}
+			if event.ID() == "" {
+				t.Error("event has no id")
+			}
+			if event.Type() != tt.wantType {
+				t.Errorf("unexpected type, want %q got %q", tt.wantType, event.Type())
+			}
+			if event.Source() != tt.wantSource {
+				t.Errorf("unexpected source, want %q got %q", tt.wantSource, event.Source())
+			}
+			if event.Subject() != tt.wantSubject {
+				t.Errorf("unexpected subject, want %q got %q", tt.wantSubject, event.Subject())
+			}
+			if event.DataContentType() != tt.wantContentType {
+				t.Errorf("unexpected datacontenttype, want %q got %q", tt.wantContentType, event.DataContentType())
+			}
+			if string(event.Data()) != tt.wantData {
+				t.Errorf("unexpected data, want %q got %q", tt.wantData, event.Data())
+			}
+			for key, want := range tt.wantExtension {
+				if got := event.Extensions()[key]; got != want {
+					t.Errorf("unexpected extension %q, want %v got %v", key, want, got)
+				}
+			}
+			if !delivery.Timestamp.IsZero() && !event.Time().Equal(delivery.Timestamp) {
+				t.Errorf("unexpected time, want %v got %v", delivery.Timestamp, event.Time())
+			}
+		})
+	}
+}
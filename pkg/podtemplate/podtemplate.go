@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podtemplate customizes the pods of the receive adapters, ingresses
+// and dispatchers with pod template overlays, set globally in a ConfigMap or
+// per resource.
+package podtemplate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// IngressAnnotationKey is the annotation of Brokers holding the overlay
+	// of the pod template of their ingress.
+	IngressAnnotationKey = "rabbitmq.eventing.knative.dev/ingress-pod-template"
+	// DispatcherAnnotationKey is the annotation of Brokers holding the
+	// overlay of the pod template of their dispatchers and of the ones of
+	// their Triggers.
+	DispatcherAnnotationKey = "rabbitmq.eventing.knative.dev/dispatcher-pod-template"
+	// HashAnnotationKey is the annotation of the pod templates merged with
+	// overlays holding the hash of the merged template. Comparing it rolls
+	// out the removal of overlay fields, which semantic derivative
+	// comparisons of the templates ignore.
+	HashAnnotationKey = "rabbitmq.eventing.knative.dev/pod-template-hash"
+)
+
+// Apply merges the overlays, in order, into the generated pod template. Each
+// overlay is applied as a strategic merge patch: containers, volumes and
+// environment variables are merged by name, maps are merged and other fields
+// are replaced. The labels of the generated template, selected by its
+// Deployment, can't be overridden. The result only depends on its inputs so
+// that reconcilers don't fight over the merged fields on each resync. If any
+// overlay is merged, the hash of the result is set in HashAnnotationKey.
+func Apply(template *corev1.PodTemplateSpec, overlays ...*corev1.PodTemplateSpec) error {
+	labels := template.Labels
+	merged := false
+	for _, overlay := range overlays {
+		if overlay == nil {
+			continue
+		}
+		merged = true
+		original, err := json.Marshal(template)
+		if err != nil {
+			return err
+		}
+		patch, err := patchOf(overlay)
+		if err != nil {
+			return err
+		}
+		patched, err := strategicpatch.StrategicMergePatch(original, patch, corev1.PodTemplateSpec{})
+		if err != nil {
+			return fmt.Errorf("merging the pod template: %w", err)
+		}
+		result := corev1.PodTemplateSpec{}
+		if err := json.Unmarshal(patched, &result); err != nil {
+			return err
+		}
+		*template = result
+	}
+
+	if len(labels) > 0 && template.Labels == nil {
+		template.Labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		template.Labels[k] = v
+	}
+	if !merged {
+		return nil
+	}
+
+	delete(template.Annotations, HashAnnotationKey)
+	b, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(b)
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string, 1)
+	}
+	template.Annotations[HashAnnotationKey] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// HashChanged tells if the expected pod template wasn't merged with the same
+// overlays into the same result as the current one.
+func HashChanged(current, expected *corev1.PodTemplateSpec) bool {
+	return current.Annotations[HashAnnotationKey] != expected.Annotations[HashAnnotationKey]
+}
+
+// Validate checks that the overlay can be merged into pod templates.
+func Validate(overlay *corev1.PodTemplateSpec) error {
+	// Containers are merged by name.
+	for i, c := range overlay.Spec.Containers {
+		if c.Name == "" {
+			return fmt.Errorf("spec.containers[%d].name is required", i)
+		}
+	}
+	for i, c := range overlay.Spec.InitContainers {
+		if c.Name == "" {
+			return fmt.Errorf("spec.initContainers[%d].name is required", i)
+		}
+	}
+	return Apply(&corev1.PodTemplateSpec{}, overlay)
+}
+
+// Parse parses the pod template overlay in data, in YAML or JSON.
+func Parse(data string) (*corev1.PodTemplateSpec, error) {
+	overlay := &corev1.PodTemplateSpec{}
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(data), len(data)).Decode(overlay); err != nil {
+		return nil, err
+	}
+	if err := Validate(overlay); err != nil {
+		return nil, err
+	}
+	return overlay, nil
+}
+
+// FromAnnotation returns the pod template overlay in the annotation key of
+// obj, or nil if there's none.
+func FromAnnotation(obj metav1.Object, key string) (*corev1.PodTemplateSpec, error) {
+	data, ok := obj.GetAnnotations()[key]
+	if !ok {
+		return nil, nil
+	}
+	overlay, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", key, err)
+	}
+	return overlay, nil
+}
+
+// patchOf returns the strategic merge patch of the overlay. The null values
+// of the fields it doesn't set, such as its containers, are dropped as they
+// would otherwise delete the generated ones.
+func patchOf(overlay *corev1.PodTemplateSpec) ([]byte, error) {
+	b, err := json.Marshal(overlay)
+	if err != nil {
+		return nil, err
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(b, &patch); err != nil {
+		return nil, err
+	}
+	return json.Marshal(dropNulls(patch))
+}
+
+func dropNulls(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if e == nil {
+				delete(v, k)
+			} else {
+				v[k] = dropNulls(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = dropNulls(e)
+		}
+	}
+	return v
+}
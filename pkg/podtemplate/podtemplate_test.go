@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podtemplate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func generated() *corev1.PodTemplateSpec {
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app": "dispatcher"},
+			Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "dispatcher",
+				Image: "image",
+				Env: []corev1.EnvVar{{
+					Name:  "QUEUE_NAME",
+					Value: "queue",
+				}},
+			}},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	testCases := map[string]struct {
+		overlays []*corev1.PodTemplateSpec
+		want     func(*corev1.PodTemplateSpec)
+	}{
+		"no overlay": {
+			want: func(*corev1.PodTemplateSpec) {},
+		},
+		"nil overlay": {
+			overlays: []*corev1.PodTemplateSpec{nil},
+			want:     func(*corev1.PodTemplateSpec) {},
+		},
+		"pod spec": {
+			overlays: []*corev1.PodTemplateSpec{{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "sa",
+					NodeSelector:       map[string]string{"disk": "ssd"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					}},
+				},
+			}},
+			want: func(pt *corev1.PodTemplateSpec) {
+				pt.Spec.ServiceAccountName = "sa"
+				pt.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+				pt.Spec.Tolerations = []corev1.Toleration{{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+				}}
+			},
+		},
+		"container merged by name": {
+			overlays: []*corev1.PodTemplateSpec{{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "dispatcher",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("64Mi"),
+							},
+						},
+						Env: []corev1.EnvVar{{
+							Name:  "GODEBUG",
+							Value: "madvdontneed=1",
+						}},
+					}},
+				},
+			}},
+			want: func(pt *corev1.PodTemplateSpec) {
+				c := &pt.Spec.Containers[0]
+				c.Resources.Limits = corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				}
+				c.Env = []corev1.EnvVar{{
+					Name:  "GODEBUG",
+					Value: "madvdontneed=1",
+				}, {
+					Name:  "QUEUE_NAME",
+					Value: "queue",
+				}}
+			},
+		},
+		"metadata": {
+			overlays: []*corev1.PodTemplateSpec{{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "other", "team": "a"},
+					Annotations: map[string]string{"sidecar.istio.io/inject": "false"},
+				},
+			}},
+			want: func(pt *corev1.PodTemplateSpec) {
+				pt.Labels["team"] = "a"
+				pt.Annotations["sidecar.istio.io/inject"] = "false"
+			},
+		},
+		"overlays in order": {
+			overlays: []*corev1.PodTemplateSpec{{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "global",
+					PriorityClassName:  "high",
+				},
+			}, {
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "resource",
+				},
+			}},
+			want: func(pt *corev1.PodTemplateSpec) {
+				pt.Spec.ServiceAccountName = "resource"
+				pt.Spec.PriorityClassName = "high"
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := generated()
+			if err := Apply(got, tc.overlays...); err != nil {
+				t.Fatal("Apply() =", err)
+			}
+
+			// Applying the overlays again gives the same template.
+			again := generated()
+			if err := Apply(again, tc.overlays...); err != nil {
+				t.Fatal("Apply() =", err)
+			}
+			if !equality.Semantic.DeepEqual(got, again) {
+				t.Error("unstable pod template (-first, +second):", cmp.Diff(got, again))
+			}
+
+			merged := false
+			for _, overlay := range tc.overlays {
+				merged = merged || overlay != nil
+			}
+			if _, hashed := got.Annotations[HashAnnotationKey]; hashed != merged {
+				t.Errorf("hash annotation set: %t, want %t", hashed, merged)
+			}
+			delete(got.Annotations, HashAnnotationKey)
+			want := generated()
+			tc.want(want)
+			if !equality.Semantic.DeepEqual(want, got) {
+				t.Error("unexpected pod template (-want, +got):", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestHashChanged(t *testing.T) {
+	current := generated()
+	err := Apply(current, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"disk": "ssd"},
+			Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+		},
+	})
+	if err != nil {
+		t.Fatal("Apply() =", err)
+	}
+
+	// The toleration is removed from the overlay.
+	expected := generated()
+	err = Apply(expected, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{NodeSelector: map[string]string{"disk": "ssd"}},
+	})
+	if err != nil {
+		t.Fatal("Apply() =", err)
+	}
+	if !HashChanged(current, expected) {
+		t.Error("HashChanged() = false after removing a field of the overlay")
+	}
+
+	// The overlay is removed.
+	if !HashChanged(current, generated()) {
+		t.Error("HashChanged() = false after removing the overlay")
+	}
+
+	if HashChanged(current, current.DeepCopy()) {
+		t.Error("HashChanged() = true for the same template")
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := map[string]struct {
+		data    string
+		want    *corev1.PodTemplateSpec
+		wantErr bool
+	}{
+		"yaml": {
+			data: `
+spec:
+  nodeSelector:
+    disk: ssd
+`,
+			want: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{NodeSelector: map[string]string{"disk": "ssd"}},
+			},
+		},
+		"json": {
+			data: `{"spec": {"serviceAccountName": "sa"}}`,
+			want: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{ServiceAccountName: "sa"},
+			},
+		},
+		"invalid": {
+			data:    `spec: [`,
+			wantErr: true,
+		},
+		"container without name": {
+			data: `
+spec:
+  containers:
+  - image: image
+`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.data)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !equality.Semantic.DeepEqual(tc.want, got) {
+				t.Error("unexpected overlay (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestFromAnnotation(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Annotations: map[string]string{
+			IngressAnnotationKey:    `{"spec": {"serviceAccountName": "sa"}}`,
+			DispatcherAnnotationKey: `{`,
+		},
+	}
+
+	got, err := FromAnnotation(obj, IngressAnnotationKey)
+	if err != nil {
+		t.Fatal("FromAnnotation() =", err)
+	}
+	if got.Spec.ServiceAccountName != "sa" {
+		t.Errorf("FromAnnotation() = %v, want the ServiceAccount sa", got)
+	}
+
+	if _, err := FromAnnotation(obj, DispatcherAnnotationKey); err == nil {
+		t.Error("FromAnnotation() = nil, want an error for an invalid overlay")
+	}
+
+	got, err = FromAnnotation(&metav1.ObjectMeta{}, IngressAnnotationKey)
+	if err != nil || got != nil {
+		t.Errorf("FromAnnotation() = %v, %v, want nil without annotation", got, err)
+	}
+}
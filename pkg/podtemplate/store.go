@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podtemplate
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap, in the namespace of the
+	// controllers, holding the overlays applied to the pods of all the
+	// resources, keyed by component.
+	ConfigMapName = "config-rabbitmq-pod-templates"
+
+	// ReceiveAdapterKey is the key of the overlay of the receive adapters of
+	// RabbitmqSources.
+	ReceiveAdapterKey = "receive-adapter"
+	// IngressKey is the key of the overlay of the ingresses of Brokers and
+	// RabbitmqChannels.
+	IngressKey = "ingress"
+	// DispatcherKey is the key of the overlay of the dispatchers of Brokers,
+	// Triggers and RabbitmqChannels.
+	DispatcherKey = "dispatcher"
+)
+
+// Store holds the overlays of the ConfigMap, updated as it changes.
+type Store struct {
+	logger *zap.SugaredLogger
+
+	mu        sync.RWMutex
+	templates map[string]*corev1.PodTemplateSpec
+}
+
+// NewStore returns an empty Store.
+func NewStore(logger *zap.SugaredLogger) *Store {
+	return &Store{
+		logger:    logger,
+		templates: map[string]*corev1.PodTemplateSpec{},
+	}
+}
+
+// WatchConfigMap keeps the store up to date with the ConfigMap, which is
+// optional when the watcher supports it. onUpdate is called after each update
+// of the store, to reconcile the resources whose pods the overlays apply to.
+func (s *Store) WatchConfigMap(cmw configmap.Watcher, onUpdate func()) {
+	update := func(cm *corev1.ConfigMap) {
+		if s.update(cm) && onUpdate != nil {
+			onUpdate()
+		}
+	}
+	if dw, ok := cmw.(configmap.DefaultingWatcher); ok {
+		dw.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName},
+		}, update)
+		return
+	}
+	cmw.Watch(ConfigMapName, update)
+}
+
+// UpdateFromConfigMap replaces the overlays of the store with the ones of
+// the ConfigMap. The store is left unchanged if any of them is invalid.
+func (s *Store) UpdateFromConfigMap(cm *corev1.ConfigMap) {
+	s.update(cm)
+}
+
+// update updates the store from the ConfigMap, returning whether it did.
+func (s *Store) update(cm *corev1.ConfigMap) bool {
+	templates := make(map[string]*corev1.PodTemplateSpec, len(cm.Data))
+	for key, data := range cm.Data {
+		if key == "_example" {
+			continue
+		}
+		overlay, err := Parse(data)
+		if err != nil {
+			s.logger.Errorw("Invalid pod template, keeping the previous ones", zap.String("key", key), zap.Error(err))
+			return false
+		}
+		templates[key] = overlay
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates = templates
+	s.logger.Infow("Update from pod templates ConfigMap", zap.Any("ConfigMap", cm))
+	return true
+}
+
+// Get returns the overlay of the component key, if any. It must not be
+// modified.
+func (s *Store) Get(key string) *corev1.PodTemplateSpec {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.templates[key]
+}
+
+// Apply merges the overlay of the component key, then the given ones, into
+// the generated pod template. A nil store only applies the given overlays.
+func (s *Store) Apply(template *corev1.PodTemplateSpec, key string, overlays ...*corev1.PodTemplateSpec) error {
+	return Apply(template, append([]*corev1.PodTemplateSpec{s.Get(key)}, overlays...)...)
+}
+
+// ApplyWithAnnotation merges the overlay of the component key, then the one
+// in the annotation annotationKey of obj, into the generated pod template.
+func (s *Store) ApplyWithAnnotation(template *corev1.PodTemplateSpec, key string, obj metav1.Object, annotationKey string) error {
+	overlay, err := FromAnnotation(obj, annotationKey)
+	if err != nil {
+		return err
+	}
+	return s.Apply(template, key, overlay)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podtemplate
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(logtesting.TestLogger(t))
+	updates := 0
+	s.WatchConfigMap(configmap.NewStaticWatcher(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName},
+		Data: map[string]string{
+			"_example":        "not a pod template",
+			ReceiveAdapterKey: `{"spec": {"priorityClassName": "high"}}`,
+		},
+	}), func() { updates++ })
+
+	if updates != 1 {
+		t.Errorf("WatchConfigMap() called onUpdate %d times, want 1", updates)
+	}
+
+	if got := s.Get(ReceiveAdapterKey); got == nil || got.Spec.PriorityClassName != "high" {
+		t.Errorf("Get(%q) = %v, want the priority class high", ReceiveAdapterKey, got)
+	}
+	if got := s.Get(DispatcherKey); got != nil {
+		t.Errorf("Get(%q) = %v, want nil", DispatcherKey, got)
+	}
+
+	// Invalid ConfigMaps leave the store unchanged.
+	s.UpdateFromConfigMap(&corev1.ConfigMap{
+		Data: map[string]string{
+			ReceiveAdapterKey: `{"spec": {"priorityClassName": "low"}}`,
+			DispatcherKey:     `{`,
+		},
+	})
+	if got := s.Get(ReceiveAdapterKey); got == nil || got.Spec.PriorityClassName != "high" {
+		t.Errorf("Get(%q) = %v, want the previous overlay", ReceiveAdapterKey, got)
+	}
+
+	pt := &corev1.PodTemplateSpec{}
+	err := s.Apply(pt, ReceiveAdapterKey, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{ServiceAccountName: "sa"},
+	})
+	if err != nil {
+		t.Fatal("Apply() =", err)
+	}
+	if pt.Spec.PriorityClassName != "high" || pt.Spec.ServiceAccountName != "sa" {
+		t.Errorf("Apply() = %v, want both overlays applied", pt)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	if got := s.Get(IngressKey); got != nil {
+		t.Errorf("Get() = %v, want nil", got)
+	}
+	pt := &corev1.PodTemplateSpec{}
+	if err := s.Apply(pt, IngressKey, &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{ServiceAccountName: "sa"},
+	}); err != nil {
+		t.Fatal("Apply() =", err)
+	}
+	if pt.Spec.ServiceAccountName != "sa" {
+		t.Errorf("Apply() = %v, want the overlay applied", pt)
+	}
+}
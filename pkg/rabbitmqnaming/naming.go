@@ -19,8 +19,10 @@ package naming
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/kmeta"
 
+	messagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 )
 
@@ -67,3 +69,35 @@ func CreateTriggerDeadLetterQueueName(t *eventingv1.Trigger) string {
 	triggerDLQBase := fmt.Sprintf("t.%s.%s.dlq.", t.Namespace, t.Name)
 	return kmeta.ChildName(triggerDLQBase, string(t.GetUID()))
 }
+
+// ChannelExchangeName creates a name for the fanout Exchange of a Channel.
+// Format is c.Namespace.Name.ChannelUID
+func ChannelExchangeName(c *messagingv1alpha1.RabbitmqChannel) string {
+	exchangeBase := fmt.Sprintf("c.%s.%s.", c.Namespace, c.Name)
+	return kmeta.ChildName(exchangeBase, string(c.GetUID()))
+}
+
+// SubscriberDLXExchangeName creates a DLX name that's used if the delivery of
+// the subscriber with the given UID has a DeadLetterSink.
+// Format is s.Namespace.ChannelName.dlx.SubscriberUID
+func SubscriberDLXExchangeName(c *messagingv1alpha1.RabbitmqChannel, uid types.UID) string {
+	exchangeBase := fmt.Sprintf("s.%s.%s.dlx.", c.Namespace, c.Name)
+	return kmeta.ChildName(exchangeBase, string(uid))
+}
+
+// CreateSubscriberQueueName creates a queue name for the events of the
+// subscriber of a Channel with the given UID.
+// Format is s.Namespace.ChannelName.SubscriberUID
+func CreateSubscriberQueueName(c *messagingv1alpha1.RabbitmqChannel, uid types.UID) string {
+	subscriberQueueBase := fmt.Sprintf("s.%s.%s.", c.Namespace, c.Name)
+	return kmeta.ChildName(subscriberQueueBase, string(uid))
+}
+
+// CreateSubscriberDeadLetterQueueName creates a dead letter queue name for the
+// subscriber of a Channel with the given UID if its delivery has a
+// DeadLetterSink.
+// Format is s.Namespace.ChannelName.dlq.SubscriberUID
+func CreateSubscriberDeadLetterQueueName(c *messagingv1alpha1.RabbitmqChannel, uid types.UID) string {
+	subscriberDLQBase := fmt.Sprintf("s.%s.%s.dlq.", c.Namespace, c.Name)
+	return kmeta.ChildName(subscriberDLQBase, string(uid))
+}
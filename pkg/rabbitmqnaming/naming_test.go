@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	messagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 )
 
@@ -29,8 +30,19 @@ const (
 	triggerName = "testtrigger"
 	triggerUID  = "triggeruid"
 	namespace   = "foobar"
+	channelName = "testchannel"
+	channelUID  = "channeluid"
+	subUID      = "subscriberuid"
 )
 
+var channel = &messagingv1alpha1.RabbitmqChannel{
+	ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      channelName,
+		UID:       channelUID,
+	},
+}
+
 func TestExchangeName(t *testing.T) {
 	for _, tt := range []struct {
 		name      string
@@ -111,3 +123,35 @@ func TestCreateTriggerDeadLetterQueueName(t *testing.T) {
 		t.Errorf("Unexpected name for foobar/trigger Trigger DLQ: want:\n%q\ngot:\n%q", want, got)
 	}
 }
+
+func TestChannelExchangeName(t *testing.T) {
+	want := "c.foobar.testchannel.channeluid"
+	got := ChannelExchangeName(channel)
+	if want != got {
+		t.Errorf("Unexpected name for foobar/testchannel Channel Exchange: want:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestSubscriberDLXExchangeName(t *testing.T) {
+	want := "s.foobar.testchannel.dlx.subscriberuid"
+	got := SubscriberDLXExchangeName(channel, subUID)
+	if want != got {
+		t.Errorf("Unexpected name for foobar/testchannel Subscriber DLX: want:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestCreateSubscriberQueueName(t *testing.T) {
+	want := "s.foobar.testchannel.subscriberuid"
+	got := CreateSubscriberQueueName(channel, subUID)
+	if want != got {
+		t.Errorf("Unexpected name for foobar/testchannel Subscriber Queue: want:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestCreateSubscriberDeadLetterQueueName(t *testing.T) {
+	want := "s.foobar.testchannel.dlq.subscriberuid"
+	got := CreateSubscriberDeadLetterQueueName(channel, subUID)
+	if want != got {
+		t.Errorf("Unexpected name for foobar/testchannel Subscriber DLQ: want:\n%q\ngot:\n%q", want, got)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"log"
 
 	"knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	rabbitmqclient "knative.dev/eventing-rabbitmq/third_party/pkg/client/injection/client"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 
@@ -102,6 +103,7 @@ func NewController(
 		ingressServiceAccountName: env.IngressServiceAccount,
 		brokerClass:               env.BrokerClass,
 		dispatcherImage:           env.DispatcherImage,
+		podTemplates:              podtemplate.NewStore(logging.FromContext(ctx)),
 		rabbitClientSet:           rabbitmqclient.Get(ctx),
 		exchangeLister:            exchangeInformer.Lister(),
 		queueLister:               queueInformer.Lister(),
@@ -154,5 +156,8 @@ func NewController(
 		FilterFunc: controller.FilterControllerGK(eventingv1.Kind("Broker")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
+	r.podTemplates.WatchConfigMap(cmw, func() {
+		impl.FilteredGlobalResync(pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, env.BrokerClass, false /*allowUnset*/), brokerInformer.Informer())
+	})
 	return impl
 }
@@ -20,6 +20,9 @@ import (
 	"os"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	"knative.dev/pkg/configmap"
 	. "knative.dev/pkg/reconciler/testing"
 
@@ -46,7 +49,9 @@ func TestNew(t *testing.T) {
 	os.Setenv("BROKER_INGRESS_IMAGE", "ingressimage")
 	os.Setenv("BROKER_INGRESS_SERVICE_ACCOUNT", "ingresssa")
 	os.Setenv("BROKER_DLQ_DISPATCHER_IMAGE", "dlqdispatcherimage")
-	c := NewController(ctx, configmap.NewStaticWatcher())
+	c := NewController(ctx, configmap.NewStaticWatcher(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: podtemplate.ConfigMapName},
+	}))
 
 	if c == nil {
 		t.Fatal("Expected NewController to return a non-nil value")
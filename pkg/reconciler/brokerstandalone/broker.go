@@ -37,6 +37,7 @@ import (
 	"knative.dev/pkg/network"
 
 	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/brokerstandalone/resources"
 	triggerresources "knative.dev/eventing-rabbitmq/pkg/reconciler/triggerstandalone/resources"
@@ -84,6 +85,10 @@ type Reconciler struct {
 	// Image to use for the DeadLetterSink dispatcher
 	dispatcherImage string
 
+	// Pod template overlays of the ConfigMap, applied to the ingress and
+	// dispatcher before the ones of the Broker annotations.
+	podTemplates *podtemplate.Store
+
 	// Which HTTP transport to use
 	transport http.RoundTripper
 	// For testing...
@@ -212,7 +217,8 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, d *v1.Deployment)
 		}
 	} else if err != nil {
 		return err
-	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) {
+	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) ||
+		podtemplate.HashChanged(&current.Spec.Template, &d.Spec.Template) {
 		// Don't modify the informers copy.
 		desired := current.DeepCopy()
 		desired.Spec = d.Spec
@@ -259,6 +265,9 @@ func (r *Reconciler) reconcileIngressDeployment(ctx context.Context, b *eventing
 		RabbitMQSecretName: resources.SecretName(b.Name),
 		BrokerUrlSecretKey: resources.BrokerURLSecretKey,
 	})
+	if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.IngressKey, b, podtemplate.IngressAnnotationKey); err != nil {
+		return err
+	}
 	return r.reconcileDeployment(ctx, expected)
 }
 
@@ -282,6 +291,9 @@ func (r *Reconciler) reconcileDLXDispatcherDeployment(ctx context.Context, b *ev
 			Subscriber:         sub,
 			BrokerIngressURL:   b.Status.Address.URL,
 		})
+		if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.DispatcherKey, b, podtemplate.DispatcherAnnotationKey); err != nil {
+			return err
+		}
 		return r.reconcileDeployment(ctx, expected)
 	}
 	// However if there's not, then ensure that one doesn't exist and delete it if does.
@@ -28,6 +28,7 @@ import (
 
 	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
 	"knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
@@ -97,6 +98,7 @@ func NewController(
 		brokerClass:               env.BrokerClass,
 		dialerFunc:                dialer.RealDialer,
 		dispatcherImage:           env.DispatcherImage,
+		podTemplates:              podtemplate.NewStore(logging.FromContext(ctx)),
 	}
 
 	impl := brokerreconciler.NewImpl(ctx, r, env.BrokerClass, func(impl *controller.Impl) controller.Options {
@@ -133,5 +135,8 @@ func NewController(
 		FilterFunc: controller.FilterControllerGK(eventingv1.Kind("Broker")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
+	r.podTemplates.WatchConfigMap(cmw, func() {
+		impl.FilteredGlobalResync(pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, env.BrokerClass, false /*allowUnset*/), brokerInformer.Informer())
+	})
 	return impl
 }
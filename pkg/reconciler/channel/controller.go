@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
+	rabbitmqchannelinformer "knative.dev/eventing-rabbitmq/pkg/client/injection/informers/messaging/v1alpha1/rabbitmqchannel"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/messaging/v1alpha1/rabbitmqchannel"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel/resources"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+)
+
+const (
+	ingressImageEnvVar    = "RABBITMQ_CHANNEL_INGRESS_IMAGE"
+	dispatcherImageEnvVar = "RABBITMQ_CHANNEL_DISPATCHER_IMAGE"
+)
+
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher) *controller.Impl {
+
+	ingressImage, defined := os.LookupEnv(ingressImageEnvVar)
+	if !defined {
+		logging.FromContext(ctx).Errorf("required environment variable '%s' not defined", ingressImageEnvVar)
+		return nil
+	}
+	dispatcherImage, defined := os.LookupEnv(dispatcherImageEnvVar)
+	if !defined {
+		logging.FromContext(ctx).Errorf("required environment variable '%s' not defined", dispatcherImageEnvVar)
+		return nil
+	}
+
+	channelInformer := rabbitmqchannelinformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	endpointsInformer := endpointsinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+
+	r := &Reconciler{
+		KubeClientSet:    kubeclient.Get(ctx),
+		deploymentLister: deploymentInformer.Lister(),
+		serviceLister:    serviceInformer.Lister(),
+		endpointsLister:  endpointsInformer.Lister(),
+		secretLister:     secretInformer.Lister(),
+		ingressImage:     ingressImage,
+		dispatcherImage:  dispatcherImage,
+		dialerFunc:       dialer.RealDialer,
+		podTemplates:     podtemplate.NewStore(logging.FromContext(ctx)),
+	}
+
+	impl := rabbitmqchannel.NewImpl(ctx, r)
+	r.connectionResolver = &connection.Resolver{
+		SecretLister: secretInformer.Lister(),
+		RabbitLister: rabbit.Get(ctx),
+		Tracker:      impl.Tracker,
+	}
+
+	logging.FromContext(ctx).Info("Setting up rabbitmqchannel event handlers")
+
+	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+
+	channelInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqChannel")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqChannel")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqChannel")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Reconcile the channels whose connection was resolved from the Secrets.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret")),
+	))
+
+	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.LabelExistsFilterFunc(resources.ChannelLabelKey),
+		Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("" /*any namespace*/, resources.ChannelLabelKey)),
+	})
+	r.podTemplates.WatchConfigMap(cmw, func() {
+		impl.GlobalResync(channelInformer.Informer())
+	})
+	return impl
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	reconcilerrabbitmqchannel "knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/messaging/v1alpha1/rabbitmqchannel"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel/resources"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/network"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+)
+
+type Reconciler struct {
+	// KubeClientSet allows us to talk to the k8s for core APIs
+	KubeClientSet kubernetes.Interface
+
+	ingressImage    string
+	dispatcherImage string
+
+	deploymentLister appsv1listers.DeploymentLister
+	serviceLister    corev1listers.ServiceLister
+	endpointsLister  corev1listers.EndpointsLister
+	secretLister     corev1listers.SecretLister
+
+	// connectionResolver resolves the connections of the channels.
+	connectionResolver *connection.Resolver
+	// uriResolver resolves the DeadLetterSinks of the subscribers.
+	uriResolver *resolver.URIResolver
+
+	// podTemplates holds the pod template overlays of the ConfigMap.
+	podTemplates *podtemplate.Store
+
+	// Which dialer to use.
+	dialerFunc dialer.DialerFunc
+}
+
+var _ reconcilerrabbitmqchannel.Interface = (*Reconciler)(nil)
+var _ reconcilerrabbitmqchannel.Finalizer = (*Reconciler)(nil)
+
+// ReconcileKind reconciles the resources of the channel:
+// 1. Secret holding the connection to RabbitMQ
+// 2. RabbitMQ fanout Exchange
+// 3. Ingress Deployment publishing to the Exchange
+// 4. K8s Service that points to the ingress Deployment
+// 5. RabbitMQ Queue and dispatcher Deployment of each subscriber
+func (r *Reconciler) ReconcileKind(ctx context.Context, c *v1alpha1.RabbitmqChannel) pkgreconciler.Event {
+	c.Status.InitializeConditions()
+
+	conn, err := r.connectionResolver.ResolveSpec(ctx, c.Namespace, &c.Spec.Connection, c)
+	if err != nil {
+		c.Status.MarkConnectionNotReady("ConnectionNotResolved", "Failed to resolve spec.connection: %v", err)
+		return fmt.Errorf("resolving connection: %w", err)
+	}
+	if err := r.reconcileSecret(ctx, c, resources.MakeSecret(c, conn.URL)); err != nil {
+		c.Status.MarkConnectionNotReady("SecretFailure", "Failed to reconcile secret: %v", err)
+		return fmt.Errorf("reconciling the connection secret: %w", err)
+	}
+	c.Status.MarkConnectionReady()
+
+	rabbitmqURL := conn.URL.String()
+	err = resources.DeclareExchange(r.dialerFunc, &resources.ExchangeArgs{
+		ExchangeName: naming.ChannelExchangeName(c),
+		RabbitmqURL:  rabbitmqURL,
+	})
+	if err != nil {
+		c.Status.MarkExchangeFailed("ExchangeFailure", "Failed to declare exchange: %v", err)
+		return fmt.Errorf("declaring the exchange: %w", err)
+	}
+	c.Status.MarkExchangeReady()
+
+	d, err := r.reconcileDeployment(ctx, c, podtemplate.IngressKey, resources.MakeIngressDeployment(&resources.IngressArgs{
+		Channel: c,
+		Image:   r.ingressImage,
+	}))
+	if err != nil {
+		c.Status.MarkIngressFailed("DeploymentFailure", "Failed to reconcile deployment: %v", err)
+		return fmt.Errorf("reconciling the ingress deployment: %w", err)
+	}
+	c.Status.MarkIngressDeployed(d)
+
+	ep, err := r.reconcileService(ctx, c, resources.MakeIngressService(c))
+	if err != nil {
+		c.Status.MarkNoAddress("ServiceFailure", "Failed to reconcile service: %v", err)
+		return fmt.Errorf("reconciling the ingress service: %w", err)
+	}
+	c.Status.MarkAddress(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(ep.Name, ep.Namespace),
+	}, ep)
+
+	return r.reconcileSubscribers(ctx, c, rabbitmqURL)
+}
+
+// FinalizeKind deletes the RabbitMQ resources of the channel, the K8s ones
+// being garbage collected.
+func (r *Reconciler) FinalizeKind(ctx context.Context, c *v1alpha1.RabbitmqChannel) pkgreconciler.Event {
+	rabbitmqURL, err := r.rabbitmqURL(c)
+	// Leaving the channel around because RabbitMQ can't be reached seems worse
+	// than leaking its resources, as the finalizer would have to be removed
+	// manually.
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Failed to get the RabbitMQ URL, there might be leaked rabbit resources", zap.Error(err))
+		return nil
+	}
+
+	uids, err := r.dispatchedSubscribers(c)
+	if err != nil {
+		return err
+	}
+	for _, sub := range c.Spec.Subscribers {
+		uids.Insert(string(sub.UID))
+	}
+	for _, uid := range uids.List() {
+		r.deleteSubscriberResources(ctx, c, rabbitmqURL, types.UID(uid))
+	}
+	err = resources.DeleteExchange(&resources.ExchangeArgs{
+		ExchangeName: naming.ChannelExchangeName(c),
+		RabbitmqURL:  rabbitmqURL,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Problem deleting exchange", zap.Error(err))
+	}
+	return nil
+}
+
+// rabbitmqURL returns the URL of RabbitMQ from the Secret of the channel.
+func (r *Reconciler) rabbitmqURL(c *v1alpha1.RabbitmqChannel) (string, error) {
+	s, err := r.secretLister.Secrets(c.Namespace).Get(resources.SecretName(c.Name))
+	if err != nil {
+		return "", err
+	}
+	val := s.Data[connection.BrokerURLSecretKey]
+	if val == nil {
+		return "", fmt.Errorf("secret missing key %s", connection.BrokerURLSecretKey)
+	}
+	return string(val), nil
+}
+
+// reconcileSecret reconciles the K8s Secret 'secret' owned by the channel.
+func (r *Reconciler) reconcileSecret(ctx context.Context, c *v1alpha1.RabbitmqChannel, secret *corev1.Secret) error {
+	current, err := r.secretLister.Secrets(secret.Namespace).Get(secret.Name)
+	if apierrors.IsNotFound(err) {
+		_, err = r.KubeClientSet.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(current, c) {
+		return fmt.Errorf("secret %q is not owned by RabbitmqChannel %q", current.Name, c.Name)
+	} else if !equality.Semantic.DeepEqual(secret.Data, current.Data) || !equality.Semantic.DeepEqual(secret.Labels, current.Labels) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Data = secret.Data
+		desired.Labels = secret.Labels
+		_, err = r.KubeClientSet.CoreV1().Secrets(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+		return err
+	}
+	return nil
+}
+
+// reconcileDeployment reconciles the K8s Deployment 'd' owned by the channel,
+// merging the pod template overlay of the component key into it.
+func (r *Reconciler) reconcileDeployment(ctx context.Context, c *v1alpha1.RabbitmqChannel, key string, d *v1.Deployment) (*v1.Deployment, error) {
+	if err := r.podTemplates.Apply(&d.Spec.Template, key); err != nil {
+		return nil, err
+	}
+	current, err := r.deploymentLister.Deployments(d.Namespace).Get(d.Name)
+	if apierrors.IsNotFound(err) {
+		return r.KubeClientSet.AppsV1().Deployments(d.Namespace).Create(ctx, d, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, c) {
+		return nil, fmt.Errorf("deployment %q is not owned by RabbitmqChannel %q", current.Name, c.Name)
+	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) ||
+		podtemplate.HashChanged(&current.Spec.Template, &d.Spec.Template) ||
+		envRemoved(&current.Spec.Template, &d.Spec.Template) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Spec = d.Spec
+		return r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	}
+	return current, nil
+}
+
+// envRemoved tells if the containers of the current pod template have more
+// env vars than the expected ones, such as the ones of a removed
+// DeadLetterSink, which DeepDerivative doesn't tell.
+func envRemoved(current, expected *corev1.PodTemplateSpec) bool {
+	for i, c := range expected.Spec.Containers {
+		if i < len(current.Spec.Containers) && len(current.Spec.Containers[i].Env) != len(c.Env) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileService reconciles the K8s Service 'svc' owned by the channel,
+// returning its Endpoints.
+func (r *Reconciler) reconcileService(ctx context.Context, c *v1alpha1.RabbitmqChannel, svc *corev1.Service) (*corev1.Endpoints, error) {
+	current, err := r.serviceLister.Services(svc.Namespace).Get(svc.Name)
+	if apierrors.IsNotFound(err) {
+		current, err = r.KubeClientSet.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, c) {
+		return nil, fmt.Errorf("service %q is not owned by RabbitmqChannel %q", current.Name, c.Name)
+	}
+
+	// spec.clusterIP is immutable and is set on existing services. If we don't set this to the same value, we will
+	// encounter an error while updating.
+	svc.Spec.ClusterIP = current.Spec.ClusterIP
+	if !equality.Semantic.DeepDerivative(svc.Spec, current.Spec) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Spec = svc.Spec
+		if _, err = r.KubeClientSet.CoreV1().Services(current.Namespace).Update(ctx, desired, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.endpointsLister.Endpoints(svc.Namespace).Get(svc.Name)
+}
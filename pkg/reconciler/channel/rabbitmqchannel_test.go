@@ -0,0 +1,661 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	"github.com/streadway/amqp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	fakerabbitmqclient "knative.dev/eventing-rabbitmq/pkg/client/injection/client/fake"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/messaging/v1alpha1/rabbitmqchannel"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel/resources"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	rt "knative.dev/eventing-rabbitmq/pkg/reconciler/testing"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	v1addr "knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/network"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
+	. "knative.dev/pkg/reconciler/testing"
+	_ "knative.dev/pkg/system/testing"
+)
+
+const (
+	testNS          = "test-namespace"
+	channelName     = "test-channel"
+	channelUID      = "test-channel-uid"
+	finalizerName   = "rabbitmqchannels.messaging.knative.dev"
+	ingressImage    = "ingressimage"
+	dispatcherImage = "dispatcherimage"
+	rabbitURL       = "amqp://localhost:5672/%2f"
+
+	subscriberUID  = types.UID("subscriber-uid")
+	subscriber2UID = types.UID("subscriber2-uid")
+)
+
+var (
+	testKey = fmt.Sprintf("%s/%s", testNS, channelName)
+
+	ingressName    = resources.IngressName(channelName)
+	channelAddress = &apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(ingressName, testNS),
+	}
+
+	subscriberURI, _     = apis.ParseURL("http://subscriber.example.com/")
+	deadLetterSinkURI, _ = apis.ParseURL("http://dls.example.com/")
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		Key:  "too/many/parts",
+	}, {
+		Name: "key not found",
+		Key:  testKey,
+	}, {
+		Name: "creates the secret, ingress deployment and service",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelURI(rabbitURL)),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated %q finalizers`, channelName),
+			Eventf(corev1.EventTypeWarning, "InternalError", `reconciling the ingress service: endpoints %q not found`, ingressName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(),
+		},
+		WantCreates: []runtime.Object{
+			channelSecret(rabbitURL),
+			ingressDeployment(),
+			resources.MakeIngressService(newChannel()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithInitRabbitmqChannelConditions,
+				rt.WithRabbitmqChannelConnectionReady,
+				rt.WithRabbitmqChannelExchangeReady,
+				rt.WithRabbitmqChannelIngressDeployed(ingressDeployment()),
+				rt.WithRabbitmqChannelNoAddress("ServiceFailure", "Failed to reconcile service: endpoints %q not found", ingressName)),
+		}},
+	}, {
+		Name: "creates the dispatchers of the subscribers",
+		Key:  testKey,
+		Objects: append(readyResources(),
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(deadLetterSubscriber(), subscriber(subscriber2UID))),
+		),
+		WantCreates: []runtime.Object{
+			dispatcherDeployment(deadLetterSubscriber(), true),
+			dispatcherDeployment(deadLetterSubscriber(), false),
+			dispatcherDeployment(subscriber(subscriber2UID), false),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(deadLetterSubscriber(), subscriber(subscriber2UID)),
+				withChannelReady,
+				rt.WithRabbitmqChannelSubscriberStatuses(
+					unavailableSubscriber(subscriberUID),
+					unavailableSubscriber(subscriber2UID),
+				)),
+		}},
+	}, {
+		Name: "updates the secret and ingress deployment",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL)),
+			channelSecret("amqp://stale:5672"),
+			ingressDeployment(func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Image = "staleimage"
+			}),
+			resources.MakeIngressService(newChannel()),
+			ingressEndpoints(),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: channelSecret(rabbitURL),
+		}, {
+			Object: ingressDeployment(),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithInitRabbitmqChannelConditions,
+				rt.WithRabbitmqChannelConnectionReady,
+				rt.WithRabbitmqChannelExchangeReady,
+				rt.WithRabbitmqChannelIngressDeployed(ingressDeployment()),
+				rt.WithRabbitmqChannelAddress(channelAddress, ingressEndpoints())),
+		}},
+	}, {
+		Name: "removes the fields dropped from the pod template overlay",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL)),
+			channelSecret(rabbitURL),
+			ingressDeployment(func(d *appsv1.Deployment) {
+				podtemplate.Apply(&d.Spec.Template, &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{NodeSelector: map[string]string{"disk": "ssd"}},
+				})
+			}),
+			resources.MakeIngressService(newChannel()),
+			ingressEndpoints(),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: ingressDeployment(),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithInitRabbitmqChannelConditions,
+				rt.WithRabbitmqChannelConnectionReady,
+				rt.WithRabbitmqChannelExchangeReady,
+				rt.WithRabbitmqChannelIngressDeployed(ingressDeployment()),
+				rt.WithRabbitmqChannelAddress(channelAddress, ingressEndpoints())),
+		}},
+	}, {
+		Name: "ready subscriber",
+		Key:  testKey,
+		Objects: append(readyResources(),
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(subscriber(subscriber2UID))),
+			dispatcherDeployment(subscriber(subscriber2UID), false, withDeploymentAvailable),
+		),
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(subscriber(subscriber2UID)),
+				withChannelReady,
+				rt.WithRabbitmqChannelSubscriberStatuses(eventingduckv1.SubscriberStatus{
+					UID:   subscriber2UID,
+					Ready: corev1.ConditionTrue,
+				})),
+		}},
+	}, {
+		Name: "adds a DeadLetterSink to an existing subscriber",
+		Key:  testKey,
+		Ctx:  withDeclaredQueues(subscriberQueues(subscriberUID)),
+		Objects: append(readyResources(),
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(deadLetterSubscriber())),
+			dispatcherDeployment(subscriber(subscriberUID), false, withDeploymentAvailable),
+		),
+		WantCreates: []runtime.Object{
+			dispatcherDeployment(deadLetterSubscriber(), true),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: dispatcherDeployment(deadLetterSubscriber(), false, withDeploymentAvailable),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(deadLetterSubscriber()),
+				withChannelReady,
+				rt.WithRabbitmqChannelSubscriberStatuses(eventingduckv1.SubscriberStatus{
+					UID:   subscriberUID,
+					Ready: corev1.ConditionTrue,
+				})),
+		}},
+	}, {
+		Name: "removes the DeadLetterSink of an existing subscriber",
+		Key:  testKey,
+		Ctx:  withDeclaredQueues(subscriberQueues(subscriberUID)),
+		Objects: append(readyResources(),
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(subscriber(subscriberUID))),
+			dispatcherDeployment(deadLetterSubscriber(), false, withDeploymentAvailable),
+			dispatcherDeployment(deadLetterSubscriber(), true),
+		),
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: dispatcherDeployment(subscriber(subscriberUID), false, withDeploymentAvailable),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS,
+				Verb:      "delete",
+				Resource:  appsv1.SchemeGroupVersion.WithResource("deployments"),
+			},
+			Name: resources.DispatcherName(channelName, subscriberUID, true),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelSubscribers(subscriber(subscriberUID)),
+				withChannelReady,
+				rt.WithRabbitmqChannelSubscriberStatuses(eventingduckv1.SubscriberStatus{
+					UID:   subscriberUID,
+					Ready: corev1.ConditionTrue,
+				})),
+		}},
+	}, {
+		Name: "deletes the dispatchers of removed subscribers",
+		Key:  testKey,
+		Objects: append(readyResources(),
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL)),
+			dispatcherDeployment(subscriber(subscriber2UID), false),
+		),
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS,
+				Verb:      "delete",
+				Resource:  appsv1.SchemeGroupVersion.WithResource("deployments"),
+			},
+			Name: resources.DispatcherName(channelName, subscriber2UID, false),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				withChannelReady,
+				rt.WithRabbitmqChannelSubscriberStatuses()),
+		}},
+	}, {
+		Name: "connection not resolved",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelCredentials("credentials")),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", `resolving connection: secret "credentials" not found`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelCredentials("credentials"),
+				rt.WithInitRabbitmqChannelConditions,
+				rt.WithRabbitmqChannelConnectionNotReady("ConnectionNotResolved", `Failed to resolve spec.connection: secret "credentials" not found`)),
+		}},
+	}, {
+		Name: "ingress deployment not owned by the channel",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL)),
+			channelSecret(rabbitURL),
+			ingressDeployment(func(d *appsv1.Deployment) {
+				d.OwnerReferences = nil
+			}),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", `reconciling the ingress deployment: deployment %q is not owned by RabbitmqChannel %q`, ingressName, channelName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithInitRabbitmqChannelConditions,
+				rt.WithRabbitmqChannelConnectionReady,
+				rt.WithRabbitmqChannelExchangeReady,
+				rt.WithRabbitmqChannelIngressFailed("DeploymentFailure", "Failed to reconcile deployment: deployment %q is not owned by RabbitmqChannel %q", ingressName, channelName)),
+		}},
+	}, {
+		Name: "channel deleted",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelDeletionTimestamp),
+			channelSecret(rabbitURL),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated %q finalizers`, channelName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(),
+		},
+	}, {
+		Name: "channel deleted without its secret",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqChannel(channelName, testNS,
+				rt.WithRabbitmqChannelUID(channelUID),
+				rt.WithRabbitmqChannelFinalizers(finalizerName),
+				rt.WithRabbitmqChannelURI(rabbitURL),
+				rt.WithRabbitmqChannelDeletionTimestamp),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated %q finalizers`, channelName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, rt.MakeFactory(func(ctx context.Context, listers *rt.Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = v1addr.WithDuck(ctx)
+		fakeServer := server.NewServer(rabbitURL)
+		fakeServer.Start()
+		r := &Reconciler{
+			KubeClientSet:    fakekubeclient.Get(ctx),
+			ingressImage:     ingressImage,
+			dispatcherImage:  dispatcherImage,
+			deploymentLister: listers.GetDeploymentLister(),
+			serviceLister:    listers.GetServiceLister(),
+			endpointsLister:  listers.GetEndpointsLister(),
+			secretLister:     listers.GetSecretLister(),
+			connectionResolver: &connection.Resolver{
+				SecretLister: listers.GetSecretLister(),
+				Tracker:      tracker.New(func(types.NamespacedName) {}, 0),
+			},
+			uriResolver:  resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			podTemplates: podtemplate.NewStore(logger),
+			dialerFunc:   fakeDialer(ctx),
+		}
+		return rabbitmqchannel.NewReconciler(ctx, logger,
+			fakerabbitmqclient.Get(ctx), listers.GetRabbitmqChannelLister(),
+			controller.GetEventRecorder(ctx),
+			r,
+		)
+	},
+		false,
+		logger,
+	))
+}
+
+// newChannel returns the channel the expected resources belong to.
+func newChannel() *v1alpha1.RabbitmqChannel {
+	return rt.NewRabbitmqChannel(channelName, testNS,
+		rt.WithRabbitmqChannelUID(channelUID),
+		rt.WithRabbitmqChannelFinalizers(finalizerName),
+		rt.WithRabbitmqChannelURI(rabbitURL))
+}
+
+// withChannelReady marks the channel as it is with readyResources.
+func withChannelReady(c *v1alpha1.RabbitmqChannel) {
+	for _, opt := range []rt.RabbitmqChannelOption{
+		rt.WithInitRabbitmqChannelConditions,
+		rt.WithRabbitmqChannelConnectionReady,
+		rt.WithRabbitmqChannelExchangeReady,
+		rt.WithRabbitmqChannelIngressDeployed(ingressDeployment(withDeploymentAvailable)),
+		rt.WithRabbitmqChannelAddress(channelAddress, ingressEndpoints()),
+	} {
+		opt(c)
+	}
+}
+
+func subscriber(uid types.UID) eventingduckv1.SubscriberSpec {
+	return eventingduckv1.SubscriberSpec{
+		UID:           uid,
+		SubscriberURI: subscriberURI,
+	}
+}
+
+func deadLetterSubscriber() eventingduckv1.SubscriberSpec {
+	sub := subscriber(subscriberUID)
+	sub.Delivery = &eventingduckv1.DeliverySpec{
+		DeadLetterSink: &duckv1.Destination{URI: deadLetterSinkURI},
+	}
+	return sub
+}
+
+func unavailableSubscriber(uid types.UID) eventingduckv1.SubscriberStatus {
+	return eventingduckv1.SubscriberStatus{
+		UID:     uid,
+		Ready:   corev1.ConditionUnknown,
+		Message: fmt.Sprintf("The dispatcher Deployment %q is unavailable.", resources.DispatcherName(channelName, uid, false)),
+	}
+}
+
+// readyResources returns the resources of a channel whose ingress is ready.
+func readyResources() []runtime.Object {
+	return []runtime.Object{
+		channelSecret(rabbitURL),
+		ingressDeployment(withDeploymentAvailable),
+		resources.MakeIngressService(newChannel()),
+		ingressEndpoints(),
+	}
+}
+
+func channelSecret(uri string) *corev1.Secret {
+	u, _ := url.Parse(uri)
+	return resources.MakeSecret(newChannel(), u)
+}
+
+func ingressDeployment(opts ...func(*appsv1.Deployment)) *appsv1.Deployment {
+	d := resources.MakeIngressDeployment(&resources.IngressArgs{
+		Channel: newChannel(),
+		Image:   ingressImage,
+	})
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func dispatcherDeployment(sub eventingduckv1.SubscriberSpec, dlq bool, opts ...func(*appsv1.Deployment)) *appsv1.Deployment {
+	c := newChannel()
+	args := &resources.DispatcherArgs{
+		Channel:       c,
+		SubscriberUID: sub.UID,
+		Image:         dispatcherImage,
+		QueueName:     naming.CreateSubscriberQueueName(c, sub.UID),
+		Subscriber:    sub.SubscriberURI,
+		Delivery:      sub.Delivery,
+	}
+	if sub.Delivery != nil && sub.Delivery.DeadLetterSink != nil {
+		args.DLXName = naming.SubscriberDLXExchangeName(c, sub.UID)
+	}
+	if dlq {
+		args = &resources.DispatcherArgs{
+			Channel:       c,
+			SubscriberUID: sub.UID,
+			Image:         dispatcherImage,
+			QueueName:     naming.CreateSubscriberDeadLetterQueueName(c, sub.UID),
+			Subscriber:    sub.Delivery.DeadLetterSink.URI,
+			DLQ:           true,
+		}
+	}
+	d := resources.MakeDispatcherDeployment(args)
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func withDeploymentAvailable(d *appsv1.Deployment) {
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:   appsv1.DeploymentAvailable,
+		Status: corev1.ConditionTrue,
+	}}
+}
+
+func ingressEndpoints() *corev1.Endpoints {
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      ingressName,
+			Labels:    resources.IngressLabels(channelName),
+		},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "127.0.0.1"}},
+		}},
+	}
+}
+
+func patchFinalizers() clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = channelName
+	action.Namespace = testNS
+	action.Patch = []byte(`{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":""}}`)
+	return action
+}
+
+func patchRemoveFinalizers() clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = channelName
+	action.Namespace = testNS
+	action.Patch = []byte(`{"metadata":{"finalizers":[],"resourceVersion":""}}`)
+	return action
+}
+
+type declaredQueuesKey struct{}
+
+// withDeclaredQueues returns a context in which the queues were already
+// declared with the given arguments.
+func withDeclaredQueues(queues map[string]amqp.Table) context.Context {
+	return context.WithValue(context.Background(), declaredQueuesKey{}, queues)
+}
+
+// subscriberQueues returns the queues of the subscriber with the given UID,
+// with the arguments they're declared with.
+func subscriberQueues(uid types.UID) map[string]amqp.Table {
+	c := newChannel()
+	return map[string]amqp.Table{
+		naming.CreateSubscriberQueueName(c, uid): {
+			"x-dead-letter-exchange": naming.SubscriberDLXExchangeName(c, uid),
+		},
+		naming.CreateSubscriberDeadLetterQueueName(c, uid): nil,
+	}
+}
+
+// fakeDialer dials the fake server, which doesn't support fanout exchanges,
+// declaring topic exchanges in their place, and doesn't check the arguments
+// of the queues already declared, which RabbitMQ rejects unless equivalent.
+func fakeDialer(ctx context.Context) dialer.DialerFunc {
+	queues, _ := ctx.Value(declaredQueuesKey{}).(map[string]amqp.Table)
+	if queues == nil {
+		queues = make(map[string]amqp.Table)
+	}
+	return func(rabbitURL string) (wabbit.Conn, error) {
+		conn, err := dialer.TestDialer(rabbitURL)
+		if err != nil {
+			return nil, err
+		}
+		return fakeConn{Conn: conn, queues: queues}, nil
+	}
+}
+
+type fakeConn struct {
+	wabbit.Conn
+	queues map[string]amqp.Table
+}
+
+func (c fakeConn) Channel() (wabbit.Channel, error) {
+	ch, err := c.Conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	return fakeChannel{Channel: ch, queues: c.queues}, nil
+}
+
+type fakeChannel struct {
+	wabbit.Channel
+	queues map[string]amqp.Table
+}
+
+func (c fakeChannel) ExchangeDeclare(name, kind string, opt wabbit.Option) error {
+	if kind == "fanout" {
+		kind = "topic"
+	}
+	return c.Channel.ExchangeDeclare(name, kind, opt)
+}
+
+func (c fakeChannel) QueueDeclare(name string, opt wabbit.Option) (wabbit.Queue, error) {
+	args, _ := opt["args"].(amqp.Table)
+	if declared, ok := c.queues[name]; ok {
+		const dlx = "x-dead-letter-exchange"
+		if declared[dlx] != args[dlx] {
+			return nil, &amqp.Error{
+				Code:   amqp.PreconditionFailed,
+				Reason: fmt.Sprintf("PRECONDITION_FAILED - inequivalent arg '%s' for queue '%s'", dlx, name),
+			}
+		}
+	}
+	c.queues[name] = args
+	return c.Channel.QueueDeclare(name, opt)
+}
+
+func (c fakeChannel) QueueDelete(name string, opt wabbit.Option) (int, error) {
+	delete(c.queues, name)
+	return c.Channel.QueueDelete(name, opt)
+}
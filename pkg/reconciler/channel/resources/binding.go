@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/NeowayLabs/wabbit"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/io"
+)
+
+// BindingArgs are the arguments to bind a Queue to a fanout Exchange.
+type BindingArgs struct {
+	QueueName    string
+	ExchangeName string
+	RabbitmqURL  string
+}
+
+// MakeBinding binds the Queue to the Exchange, which routes every message to
+// it regardless of its routing key. Binding again is a no-op.
+func MakeBinding(dialerFunc dialer.DialerFunc, args *BindingArgs) error {
+	conn, err := dialerFunc(args.RabbitmqURL)
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(conn)
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(channel)
+
+	return channel.QueueBind(
+		args.QueueName,
+		"", // routing key
+		args.ExchangeName,
+		wabbit.Option{"noWait": false},
+	)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/rickb777/date/period"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/system"
+)
+
+const (
+	dispatcherContainerName = "dispatcher"
+
+	// defaultRetry is the number of retries of deliveries whose delivery spec
+	// doesn't set one, as for Triggers.
+	defaultRetry = 5
+)
+
+// DispatcherArgs are the arguments to create a dispatcher Deployment. There's
+// one of these created for each subscriber of a channel, and another one for
+// its dead letters if its delivery has a DeadLetterSink.
+type DispatcherArgs struct {
+	Channel       *v1alpha1.RabbitmqChannel
+	SubscriberUID types.UID
+	Image         string
+	QueueName     string
+	Subscriber    *apis.URL
+	// Reply is where the responses of the subscriber are sent, they are
+	// dropped if it's nil.
+	Reply    *apis.URL
+	Delivery *eventingduckv1.DeliverySpec
+	// DLQ is true for the dispatcher of the dead letters of the subscriber.
+	DLQ bool
+	// DLXName is the exchange messages that can't be converted into
+	// CloudEvents are dead-lettered to. It is left empty for the dispatchers
+	// of dead letter queues: their queue is the one bound to the DLX, so
+	// dead-lettering would route these messages back to it over and over.
+	// They are NACK-ed without requeueing, which drops them, instead.
+	DLXName string
+}
+
+// DispatcherName returns the name of the dispatcher of the subscriber with the
+// given UID.
+func DispatcherName(channelName string, uid types.UID, dlq bool) string {
+	if dlq {
+		return kmeta.ChildName(fmt.Sprintf("%s-%s", channelName, uid), "-dlq-dispatcher")
+	}
+	return kmeta.ChildName(fmt.Sprintf("%s-%s", channelName, uid), "-dispatcher")
+}
+
+// MakeDispatcherDeployment creates the in-memory representation of the
+// Deployment dispatching the messages of a subscriber's queue.
+func MakeDispatcherDeployment(args *DispatcherArgs) *appsv1.Deployment {
+	one := int32(1)
+	role := DispatcherRole
+	if args.DLQ {
+		role = DLQDispatcherRole
+	}
+	labels := DispatcherLabels(args.Channel.Name, args.SubscriberUID, role)
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.Channel.Namespace,
+			Name:      DispatcherName(args.Channel.Name, args.SubscriberUID, args.DLQ),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.Channel),
+			},
+			Labels: labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &one,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  dispatcherContainerName,
+						Image: args.Image,
+						Env: []corev1.EnvVar{{
+							Name:  system.NamespaceEnvKey,
+							Value: system.Namespace(),
+						}, {
+							Name: "RABBIT_URL",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: SecretName(args.Channel.Name),
+									},
+									Key: connection.BrokerURLSecretKey,
+								},
+							},
+						}, {
+							Name:  "QUEUE_NAME",
+							Value: args.QueueName,
+						}, {
+							Name:  "SUBSCRIBER",
+							Value: args.Subscriber.String(),
+						}, {
+							Name:  "BROKER_INGRESS_URL",
+							Value: args.Reply.String(),
+						}, {
+							// Failed events are dead-lettered, if at all, by
+							// RabbitMQ rather than requeued.
+							Name:  "REQUEUE",
+							Value: "false",
+						}},
+					}},
+				},
+			},
+		},
+	}
+
+	container := &d.Spec.Template.Spec.Containers[0]
+	if delivery := args.Delivery; delivery != nil {
+		retry := int32(defaultRetry)
+		if delivery.Retry != nil {
+			retry = *delivery.Retry
+		}
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "RETRY",
+			Value: fmt.Sprint(retry),
+		})
+		if delivery.BackoffPolicy != nil {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  "BACKOFF_POLICY",
+				Value: string(*delivery.BackoffPolicy),
+			})
+		}
+		// The delay was validated as an ISO 8601 duration.
+		if delivery.BackoffDelay != nil {
+			if p, err := period.Parse(*delivery.BackoffDelay); err == nil {
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name:  "BACKOFF_DELAY",
+					Value: p.DurationApprox().String(),
+				})
+			}
+		}
+	}
+	if args.DLXName != "" {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "DLX_NAME",
+			Value: args.DLXName,
+		})
+	}
+	return d
+}
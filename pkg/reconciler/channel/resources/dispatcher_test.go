@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing"
+)
+
+const (
+	channelName   = "testchannel"
+	channelUID    = "channel-test-uid"
+	subscriberUID = "subscriber-test-uid"
+	ns            = "testnamespace"
+	image         = "dispatcherimage"
+	queueName     = "s.testnamespace.testchannel.subscriber-test-uid"
+)
+
+var channel = &v1alpha1.RabbitmqChannel{
+	ObjectMeta: metav1.ObjectMeta{Name: channelName, Namespace: ns, UID: channelUID},
+}
+
+func ownerReferences() []metav1.OwnerReference {
+	trueValue := true
+	return []metav1.OwnerReference{{
+		APIVersion:         "messaging.knative.dev/v1alpha1",
+		Kind:               "RabbitmqChannel",
+		Name:               channelName,
+		UID:                channelUID,
+		Controller:         &trueValue,
+		BlockOwnerDeletion: &trueValue,
+	}}
+}
+
+func TestMakeDispatcherDeployment(t *testing.T) {
+	got := MakeDispatcherDeployment(&DispatcherArgs{
+		Channel:       channel,
+		SubscriberUID: subscriberUID,
+		Image:         image,
+		QueueName:     queueName,
+		Subscriber:    apis.HTTP("function.example.com"),
+		Reply:         apis.HTTP("reply.example.com"),
+	})
+
+	one := int32(1)
+	labels := map[string]string{
+		"messaging.knative.dev/channel":     channelName,
+		"messaging.knative.dev/channelRole": "dispatcher",
+		"messaging.knative.dev/subscriber":  subscriberUID,
+	}
+	want := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       ns,
+			Name:            "testchannel-subscriber-test-uid-dispatcher",
+			OwnerReferences: ownerReferences(),
+			Labels:          labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &one,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "dispatcher",
+						Image: image,
+						Env: []corev1.EnvVar{{
+							Name:  system.NamespaceEnvKey,
+							Value: system.Namespace(),
+						}, {
+							Name: "RABBIT_URL",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "testchannel-channel-rabbit",
+									},
+									Key: "brokerURL",
+								},
+							},
+						}, {
+							Name:  "QUEUE_NAME",
+							Value: queueName,
+						}, {
+							Name:  "SUBSCRIBER",
+							Value: "http://function.example.com",
+						}, {
+							Name:  "BROKER_INGRESS_URL",
+							Value: "http://reply.example.com",
+						}, {
+							Name:  "REQUEUE",
+							Value: "false",
+						}},
+					}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected diff (-want, +got) = ", diff)
+	}
+}
+
+func TestMakeDispatcherDeploymentEnv(t *testing.T) {
+	ten := int32(10)
+	linear := eventingduckv1.BackoffPolicyLinear
+	delay := "PT0.5S"
+
+	tests := []struct {
+		name     string
+		args     *DispatcherArgs
+		wantName string
+		wantEnv  map[string]string
+	}{{
+		name: "no reply",
+		args: &DispatcherArgs{
+			Subscriber: apis.HTTP("function.example.com"),
+		},
+		wantName: "testchannel-subscriber-test-uid-dispatcher",
+		wantEnv: map[string]string{
+			"BROKER_INGRESS_URL": "",
+		},
+	}, {
+		name: "delivery and DLX",
+		args: &DispatcherArgs{
+			Subscriber: apis.HTTP("function.example.com"),
+			Delivery: &eventingduckv1.DeliverySpec{
+				Retry:         &ten,
+				BackoffPolicy: &linear,
+				BackoffDelay:  &delay,
+			},
+			DLXName: "s.testnamespace.testchannel.dlx.subscriber-test-uid",
+		},
+		wantName: "testchannel-subscriber-test-uid-dispatcher",
+		wantEnv: map[string]string{
+			"RETRY":          "10",
+			"BACKOFF_POLICY": "linear",
+			"BACKOFF_DELAY":  "500ms",
+			"DLX_NAME":       "s.testnamespace.testchannel.dlx.subscriber-test-uid",
+		},
+	}, {
+		name: "default retry",
+		args: &DispatcherArgs{
+			Subscriber: apis.HTTP("function.example.com"),
+			Delivery:   &eventingduckv1.DeliverySpec{},
+		},
+		wantName: "testchannel-subscriber-test-uid-dispatcher",
+		wantEnv: map[string]string{
+			"RETRY": "5",
+		},
+	}, {
+		name: "dead letters",
+		args: &DispatcherArgs{
+			Subscriber: apis.HTTP("dls.example.com"),
+			DLQ:        true,
+		},
+		wantName: "testchannel-subscriber-test-uid-dlq-dispatcher",
+		wantEnv: map[string]string{
+			"SUBSCRIBER": "http://dls.example.com",
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.args.Channel = channel
+			tc.args.SubscriberUID = subscriberUID
+			got := MakeDispatcherDeployment(tc.args)
+			if got.Name != tc.wantName {
+				t.Errorf("Expected name %q, but got %q", tc.wantName, got.Name)
+			}
+			env := map[string]string{}
+			for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			for name, want := range tc.wantEnv {
+				if got, ok := env[name]; !ok || got != want {
+					t.Errorf("Expected %s=%q, but got %q", name, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/NeowayLabs/wabbit"
+	"github.com/streadway/amqp"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/io"
+)
+
+// ExchangeArgs are the arguments to create a fanout Exchange of a channel,
+// either the one of the channel or the DLX of a subscriber.
+type ExchangeArgs struct {
+	ExchangeName string
+	RabbitmqURL  string
+}
+
+// DeclareExchange declares the fanout Exchange.
+func DeclareExchange(dialerFunc dialer.DialerFunc, args *ExchangeArgs) error {
+	conn, err := dialerFunc(args.RabbitmqURL)
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(conn)
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(channel)
+
+	return channel.ExchangeDeclare(
+		args.ExchangeName,
+		"fanout", // kind
+		wabbit.Option{
+			"durable":    true,
+			"autoDelete": false,
+			"internal":   false,
+			"noWait":     false,
+		},
+	)
+}
+
+// DeleteExchange deletes the Exchange.
+func DeleteExchange(args *ExchangeArgs) error {
+	conn, err := amqp.Dial(args.RabbitmqURL)
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(conn)
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer io.CloseAmqpResourceAndExitOnError(channel)
+
+	return channel.ExchangeDelete(
+		args.ExchangeName,
+		false, // if-unused
+		false, // nowait
+	)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel/resources"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/testrabbit"
+)
+
+const exchangeName = "c.foobar.testchannel.channel-test-uid"
+
+func TestExchangeDeclaration(t *testing.T) {
+	ctx := context.Background()
+	rabbitContainer := testrabbit.AutoStartRabbit(t, ctx)
+	defer testrabbit.TerminateContainer(t, ctx, rabbitContainer)
+
+	err := resources.DeclareExchange(dialer.RealDialer, &resources.ExchangeArgs{
+		ExchangeName: exchangeName,
+		RabbitmqURL:  testrabbit.BrokerUrl(t, ctx, rabbitContainer).String(),
+	})
+
+	assert.NilError(t, err)
+	exchanges := testrabbit.FindOwnedExchanges(t, ctx, rabbitContainer)
+	assert.Equal(t, len(exchanges), 1)
+	createdExchange := exchanges[0]
+	assert.Equal(t, createdExchange["durable"], true)
+	assert.Equal(t, createdExchange["auto_delete"], false)
+	assert.Equal(t, createdExchange["internal"], false)
+	assert.Equal(t, createdExchange["type"], "fanout")
+	assert.Equal(t, createdExchange["name"], exchangeName)
+}
+
+func TestExchangeDeletion(t *testing.T) {
+	ctx := context.Background()
+	rabbitContainer := testrabbit.AutoStartRabbit(t, ctx)
+	defer testrabbit.TerminateContainer(t, ctx, rabbitContainer)
+	testrabbit.CreateExchange(t, ctx, rabbitContainer, exchangeName, "fanout")
+
+	err := resources.DeleteExchange(&resources.ExchangeArgs{
+		ExchangeName: exchangeName,
+		RabbitmqURL:  testrabbit.BrokerUrl(t, ctx, rabbitContainer).String(),
+	})
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(testrabbit.FindOwnedExchanges(t, ctx, rabbitContainer)), 0)
+}
+
+func TestBinding(t *testing.T) {
+	ctx := context.Background()
+	rabbitContainer := testrabbit.AutoStartRabbit(t, ctx)
+	defer testrabbit.TerminateContainer(t, ctx, rabbitContainer)
+	queueName := "s.foobar.testchannel.subscriber-test-uid"
+	testrabbit.CreateExchange(t, ctx, rabbitContainer, exchangeName, "fanout")
+	testrabbit.CreateDurableQueue(t, ctx, rabbitContainer, queueName)
+
+	args := &resources.BindingArgs{
+		QueueName:    queueName,
+		ExchangeName: exchangeName,
+		RabbitmqURL:  testrabbit.BrokerUrl(t, ctx, rabbitContainer).String(),
+	}
+	// Binding again is a no-op.
+	assert.NilError(t, resources.MakeBinding(dialer.RealDialer, args))
+	assert.NilError(t, resources.MakeBinding(dialer.RealDialer, args))
+
+	var bindings []testrabbit.Binding
+	for _, b := range testrabbit.FindBindings(t, ctx, rabbitContainer) {
+		if b["source"] == exchangeName {
+			bindings = append(bindings, b)
+		}
+	}
+	assert.Equal(t, len(bindings), 1)
+	assert.Equal(t, bindings[0]["destination"], queueName)
+	assert.Equal(t, bindings[0]["destination_type"], "queue")
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/system"
+)
+
+const (
+	ingressContainerName = "ingress"
+)
+
+// IngressArgs are the arguments to create a channel's ingress Deployment.
+type IngressArgs struct {
+	Channel *v1alpha1.RabbitmqChannel
+	Image   string
+}
+
+func IngressName(channelName string) string {
+	return fmt.Sprintf("%s-channel-ingress", channelName)
+}
+
+// MakeIngressDeployment creates the in-memory representation of the channel's
+// ingress Deployment, publishing the events sent to the channel to its
+// exchange.
+func MakeIngressDeployment(args *IngressArgs) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.Channel.Namespace,
+			Name:      IngressName(args.Channel.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.Channel),
+			},
+			Labels: IngressLabels(args.Channel.Name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: IngressLabels(args.Channel.Name),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: IngressLabels(args.Channel.Name),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: args.Image,
+						Name:  ingressContainerName,
+						Env: []corev1.EnvVar{{
+							Name:  system.NamespaceEnvKey,
+							Value: system.Namespace(),
+						}, {
+							Name: "BROKER_URL",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: SecretName(args.Channel.Name),
+									},
+									Key: connection.BrokerURLSecretKey,
+								},
+							},
+						}, {
+							Name:  "EXCHANGE_NAME",
+							Value: naming.ChannelExchangeName(args.Channel),
+						}},
+						Ports: []corev1.ContainerPort{{
+							ContainerPort: 8080,
+							Name:          "http",
+						}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// MakeIngressService creates the in-memory representation of the channel's
+// ingress Service, the address of the channel.
+func MakeIngressService(c *v1alpha1.RabbitmqChannel) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.Namespace,
+			Name:      IngressName(c.Name),
+			Labels:    IngressLabels(c.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(c),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: IngressLabels(c.Name),
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+}
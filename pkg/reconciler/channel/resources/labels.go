@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ChannelLabelKey is the label of the resources of a channel, set to its
+	// name.
+	ChannelLabelKey = "messaging.knative.dev/channel"
+	// RoleLabelKey is the label telling the ingress and dispatchers of a
+	// channel apart.
+	RoleLabelKey = "messaging.knative.dev/channelRole"
+	// SubscriberLabelKey is the label of the dispatchers of a channel, set to
+	// the UID of their subscriber.
+	SubscriberLabelKey = "messaging.knative.dev/subscriber"
+
+	IngressRole       = "ingress"
+	DispatcherRole    = "dispatcher"
+	DLQDispatcherRole = "dispatcher-dlq"
+)
+
+// IngressLabels generates the labels present on all resources representing the
+// ingress of the given channel.
+func IngressLabels(channelName string) map[string]string {
+	return map[string]string{
+		ChannelLabelKey: channelName,
+		RoleLabelKey:    IngressRole,
+	}
+}
+
+// DispatcherLabels generates the labels present on the dispatcher of the
+// subscriber of the given channel, role telling if it dispatches the events
+// or the dead letters of the subscriber.
+func DispatcherLabels(channelName string, uid types.UID, role string) map[string]string {
+	return map[string]string{
+		ChannelLabelKey:    channelName,
+		RoleLabelKey:       role,
+		SubscriberLabelKey: string(uid),
+	}
+}
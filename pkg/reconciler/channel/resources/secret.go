@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/pkg/kmeta"
+)
+
+// MakeSecret creates the Secret holding the URL of RabbitMQ read by the
+// ingress and dispatchers of the channel.
+func MakeSecret(c *v1alpha1.RabbitmqChannel, rabbitmqURL *url.URL) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.Namespace,
+			Name:      SecretName(c.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(c),
+			},
+			Labels: map[string]string{ChannelLabelKey: c.Name},
+		},
+		Data: map[string][]byte{
+			connection.BrokerURLSecretKey: []byte(rabbitmqURL.String()),
+		},
+	}
+}
+
+func SecretName(channelName string) string {
+	return fmt.Sprintf("%s-channel-rabbit", channelName)
+}
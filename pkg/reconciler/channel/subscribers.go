@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/channel/resources"
+	triggerresources "knative.dev/eventing-rabbitmq/pkg/reconciler/triggerstandalone/resources"
+	"knative.dev/eventing/pkg/apis/duck"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+)
+
+// This file contains the logic reconciling the queue and dispatchers of each
+// subscriber of a channel.
+
+// reconcileSubscribers reconciles the resources of each subscriber of the
+// channel, reporting their readiness in its status, and deletes those of the
+// subscribers that were removed.
+func (r *Reconciler) reconcileSubscribers(ctx context.Context, c *v1alpha1.RabbitmqChannel, rabbitmqURL string) error {
+	logger := logging.FromContext(ctx)
+
+	var firstErr error
+	subscribed := sets.NewString()
+	statuses := make([]eventingduckv1.SubscriberStatus, 0, len(c.Spec.Subscribers))
+	for i := range c.Spec.Subscribers {
+		sub := &c.Spec.Subscribers[i]
+		subscribed.Insert(string(sub.UID))
+
+		status := eventingduckv1.SubscriberStatus{
+			UID:                sub.UID,
+			ObservedGeneration: sub.Generation,
+		}
+		d, err := r.reconcileSubscriber(ctx, c, sub, rabbitmqURL)
+		switch {
+		case err != nil:
+			logger.Errorw("Problem reconciling subscriber", zap.String("uid", string(sub.UID)), zap.Error(err))
+			status.Ready = corev1.ConditionFalse
+			status.Message = err.Error()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("reconciling subscriber %q: %w", sub.UID, err)
+			}
+		case duck.DeploymentIsAvailable(&d.Status, false):
+			status.Ready = corev1.ConditionTrue
+		default:
+			status.Ready = corev1.ConditionUnknown
+			status.Message = fmt.Sprintf("The dispatcher Deployment %q is unavailable.", d.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	c.Status.Subscribers = statuses
+
+	dispatched, err := r.dispatchedSubscribers(c)
+	if err != nil {
+		return err
+	}
+	for _, uid := range dispatched.Difference(subscribed).List() {
+		r.deleteSubscriberResources(ctx, c, rabbitmqURL, types.UID(uid))
+		for _, dlq := range []bool{false, true} {
+			if err := r.deleteDispatcher(ctx, c, types.UID(uid), dlq); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("deleting the dispatcher of subscriber %q: %w", uid, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// reconcileSubscriber reconciles the queue of the subscriber, bound to the
+// exchange of the channel, and the Deployment dispatching its messages, which
+// it returns. The queue always dead-letters to the DLX of the subscriber, as
+// the arguments of a queue can't change once declared: if the delivery of the
+// subscriber has a DeadLetterSink, the DLX is declared with its own queue,
+// dispatched to the DeadLetterSink, otherwise RabbitMQ drops the messages
+// dead-lettered to the missing or unbound DLX.
+func (r *Reconciler) reconcileSubscriber(ctx context.Context, c *v1alpha1.RabbitmqChannel, sub *eventingduckv1.SubscriberSpec, rabbitmqURL string) (*v1.Deployment, error) {
+	// The delivery of the channel is the default of its subscribers.
+	delivery := sub.Delivery
+	if delivery == nil {
+		delivery = c.Spec.Delivery
+	}
+
+	dlxName := naming.SubscriberDLXExchangeName(c, sub.UID)
+	var dispatcherDLXName string
+	if delivery != nil && delivery.DeadLetterSink != nil {
+		deadLetterSinkURI, err := r.uriResolver.URIFromDestinationV1(ctx, *delivery.DeadLetterSink, c)
+		if err != nil {
+			return nil, fmt.Errorf("resolving the DeadLetterSink: %w", err)
+		}
+
+		dispatcherDLXName = dlxName
+		err = resources.DeclareExchange(r.dialerFunc, &resources.ExchangeArgs{
+			ExchangeName: dlxName,
+			RabbitmqURL:  rabbitmqURL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("declaring the DLX: %w", err)
+		}
+		dlqName := naming.CreateSubscriberDeadLetterQueueName(c, sub.UID)
+		_, err = triggerresources.DeclareQueue(r.dialerFunc, &triggerresources.QueueArgs{
+			QueueName:   dlqName,
+			RabbitmqURL: rabbitmqURL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("declaring the dead letter queue: %w", err)
+		}
+		err = resources.MakeBinding(r.dialerFunc, &resources.BindingArgs{
+			QueueName:    dlqName,
+			ExchangeName: dlxName,
+			RabbitmqURL:  rabbitmqURL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("binding the dead letter queue: %w", err)
+		}
+		_, err = r.reconcileDeployment(ctx, c, podtemplate.DispatcherKey, resources.MakeDispatcherDeployment(&resources.DispatcherArgs{
+			Channel:       c,
+			SubscriberUID: sub.UID,
+			Image:         r.dispatcherImage,
+			QueueName:     dlqName,
+			Subscriber:    deadLetterSinkURI,
+			DLQ:           true,
+			// No DLXName, see DispatcherArgs.DLXName.
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("reconciling the dead letter dispatcher deployment: %w", err)
+		}
+	} else if err := r.deleteDeadLetterQueue(ctx, c, sub.UID, rabbitmqURL); err != nil {
+		return nil, err
+	}
+
+	queueName := naming.CreateSubscriberQueueName(c, sub.UID)
+	_, err := triggerresources.DeclareQueue(r.dialerFunc, &triggerresources.QueueArgs{
+		QueueName:   queueName,
+		RabbitmqURL: rabbitmqURL,
+		DLX:         dlxName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("declaring the queue: %w", err)
+	}
+	err = resources.MakeBinding(r.dialerFunc, &resources.BindingArgs{
+		QueueName:    queueName,
+		ExchangeName: naming.ChannelExchangeName(c),
+		RabbitmqURL:  rabbitmqURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("binding the queue: %w", err)
+	}
+
+	// Subscribers without a SubscriberURI forward the events to their reply.
+	subscriberURI, replyURI := sub.SubscriberURI, sub.ReplyURI
+	if subscriberURI == nil {
+		subscriberURI, replyURI = replyURI, nil
+	}
+	d, err := r.reconcileDeployment(ctx, c, podtemplate.DispatcherKey, resources.MakeDispatcherDeployment(&resources.DispatcherArgs{
+		Channel:       c,
+		SubscriberUID: sub.UID,
+		Image:         r.dispatcherImage,
+		QueueName:     queueName,
+		Subscriber:    subscriberURI,
+		Reply:         replyURI,
+		Delivery:      delivery,
+		DLXName:       dispatcherDLXName,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("reconciling the dispatcher deployment: %w", err)
+	}
+	return d, nil
+}
+
+// deleteDeadLetterQueue deletes the dead letter queue of the subscriber with
+// the given UID and its dispatcher Deployment, if the subscriber had a
+// DeadLetterSink. The queue is deleted first, as the dispatcher Deployment
+// is what tells that there is one left to delete.
+func (r *Reconciler) deleteDeadLetterQueue(ctx context.Context, c *v1alpha1.RabbitmqChannel, uid types.UID, rabbitmqURL string) error {
+	name := resources.DispatcherName(c.Name, uid, true)
+	if _, err := r.deploymentLister.Deployments(c.Namespace).Get(name); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	err := triggerresources.DeleteQueue(r.dialerFunc, &triggerresources.QueueArgs{
+		QueueName:   naming.CreateSubscriberDeadLetterQueueName(c, uid),
+		RabbitmqURL: rabbitmqURL,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting the dead letter queue: %w", err)
+	}
+	if err := r.deleteDispatcher(ctx, c, uid, true); err != nil {
+		return fmt.Errorf("deleting the dead letter dispatcher deployment: %w", err)
+	}
+	return nil
+}
+
+// dispatchedSubscribers returns the UIDs of the subscribers of the channel
+// that have a dispatcher Deployment.
+func (r *Reconciler) dispatchedSubscribers(c *v1alpha1.RabbitmqChannel) (sets.String, error) {
+	deployments, err := r.deploymentLister.Deployments(c.Namespace).List(labels.SelectorFromSet(map[string]string{
+		resources.ChannelLabelKey: c.Name,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	uids := sets.NewString()
+	for _, d := range deployments {
+		if uid := d.Labels[resources.SubscriberLabelKey]; uid != "" {
+			uids.Insert(uid)
+		}
+	}
+	return uids, nil
+}
+
+// deleteDispatcher deletes the dispatcher Deployment of the subscriber with
+// the given UID, if any.
+func (r *Reconciler) deleteDispatcher(ctx context.Context, c *v1alpha1.RabbitmqChannel, uid types.UID, dlq bool) error {
+	name := resources.DispatcherName(c.Name, uid, dlq)
+	if _, err := r.deploymentLister.Deployments(c.Namespace).Get(name); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	err := r.KubeClientSet.AppsV1().Deployments(c.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// deleteSubscriberResources deletes the RabbitMQ resources of the subscriber
+// with the given UID. Failures are only logged as the subscriber is gone
+// anyway.
+func (r *Reconciler) deleteSubscriberResources(ctx context.Context, c *v1alpha1.RabbitmqChannel, rabbitmqURL string, uid types.UID) {
+	logger := logging.FromContext(ctx).With(zap.String("uid", string(uid)))
+
+	for _, queueName := range []string{
+		naming.CreateSubscriberQueueName(c, uid),
+		naming.CreateSubscriberDeadLetterQueueName(c, uid),
+	} {
+		err := triggerresources.DeleteQueue(r.dialerFunc, &triggerresources.QueueArgs{
+			QueueName:   queueName,
+			RabbitmqURL: rabbitmqURL,
+		})
+		if err != nil {
+			logger.Errorw("Problem deleting subscriber queue", zap.String("queue", queueName), zap.Error(err))
+		}
+	}
+	err := resources.DeleteExchange(&resources.ExchangeArgs{
+		ExchangeName: naming.SubscriberDLXExchangeName(c, uid),
+		RabbitmqURL:  rabbitmqURL,
+	})
+	if err != nil {
+		logger.Errorw("Problem deleting subscriber DLX", zap.Error(err))
+	}
+}
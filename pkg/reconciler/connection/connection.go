@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connection resolves the references to RabbitMQ of the resources
+// connecting to it.
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	duckv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/duck/v1beta1"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	apisduck "knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/network"
+	"knative.dev/pkg/tracker"
+)
+
+const (
+	// BrokerURLSecretKey is the key of the URI of the brokers in the
+	// referenced Secrets, the same as in the Secrets configuring Brokers.
+	BrokerURLSecretKey = "brokerURL"
+	// CACertSecretKey is the key of the CA bundle in the Secrets of
+	// RabbitmqClusters.
+	CACertSecretKey = "ca.crt"
+
+	amqpPort  = 5672
+	amqpsPort = 5671
+)
+
+// ErrUnsupportedRef is returned when resolving a reference to anything but a
+// Secret or a RabbitmqCluster.
+var ErrUnsupportedRef = errors.New("connection reference not supported, only [kind: Secret, apiVersion: v1 or kind: RabbitmqCluster, apiVersion: rabbitmq.com/v1beta1]")
+
+// Connection is the RabbitMQ connection resolved from a reference.
+type Connection struct {
+	URL *url.URL
+	// CACert is the PEM encoded CA bundle of the RabbitmqCluster, if any.
+	CACert []byte
+}
+
+// Resolver resolves references to RabbitMQ into connections.
+type Resolver struct {
+	SecretLister corev1listers.SecretLister
+	RabbitLister apisduck.InformerFactory
+	// Tracker tracks the Secrets and RabbitmqClusters read to resolve a
+	// connection for the resource resolving it, which must be reconciled
+	// again when they change.
+	Tracker tracker.Interface
+
+	// watchRabbits adds the handler of the changes of RabbitmqClusters to
+	// their informer, started on the first reference to resolve.
+	watchRabbits sync.Once
+}
+
+// Resolve resolves ref, a v1 Secret holding the URI of the brokers or a
+// RabbitmqCluster, into a connection of obj. The namespace of ref must be
+// set.
+func (r *Resolver) Resolve(ctx context.Context, ref *duckv1.KReference, obj interface{}) (*Connection, error) {
+	switch fmt.Sprintf("%s.%s", ref.Kind, ref.APIVersion) {
+	case "Secret.v1":
+		return r.fromSecret(ref, obj)
+	case "RabbitmqCluster.rabbitmq.com/v1beta1":
+		return r.fromRabbit(ctx, ref, obj)
+	default:
+		return nil, ErrUnsupportedRef
+	}
+}
+
+// ResolveSpec resolves spec, either a URI and credentials or a reference to
+// RabbitMQ, into a connection of obj. Credentials and references default to
+// namespace.
+func (r *Resolver) ResolveSpec(ctx context.Context, namespace string, spec *sourcesv1beta1.RabbitmqSourceConnectionSpec, obj interface{}) (*Connection, error) {
+	conn := &Connection{}
+	if spec.Ref != nil {
+		ref := spec.Ref.DeepCopy()
+		if ref.Namespace == "" {
+			ref.Namespace = namespace
+		}
+		var err error
+		if conn, err = r.Resolve(ctx, ref, obj); err != nil {
+			return nil, err
+		}
+	} else {
+		uri := spec.URI
+		if !strings.Contains(uri, "://") {
+			uri = "amqp://" + uri
+		}
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Credentials != nil {
+			user, err := r.secretValue(namespace, &spec.Credentials.User, obj)
+			if err != nil {
+				return nil, err
+			}
+			password, err := r.secretValue(namespace, &spec.Credentials.Password, obj)
+			if err != nil {
+				return nil, err
+			}
+			u.User = url.UserPassword(user, password)
+		}
+		conn.URL = u
+	}
+
+	if spec.Vhost != "" {
+		if spec.Ref != nil {
+			conn.URL.Path, conn.URL.RawPath = "", ""
+		}
+		if err := dialer.JoinVhost(conn.URL, spec.Vhost); err != nil {
+			return nil, err
+		}
+	}
+	if spec.TLS != nil {
+		conn.URL.Scheme = "amqps"
+	}
+	return conn, nil
+}
+
+// secretValue returns the value of the Secret key selected by sel.
+func (r *Resolver) secretValue(namespace string, sel *corev1.SecretKeySelector, obj interface{}) (string, error) {
+	secret, err := r.secret(namespace, sel.Name, obj)
+	if err != nil {
+		return "", err
+	}
+	val, ok := secret.Data[sel.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q missing key %s", sel.Name, sel.Key)
+	}
+	return string(val), nil
+}
+
+// secret tracks and returns the Secret name in namespace.
+func (r *Resolver) secret(namespace, name string, obj interface{}) (*corev1.Secret, error) {
+	err := r.Tracker.TrackReference(tracker.Reference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  namespace,
+		Name:       name,
+	}, obj)
+	if err != nil {
+		return nil, err
+	}
+	return r.SecretLister.Secrets(namespace).Get(name)
+}
+
+func (r *Resolver) fromSecret(ref *duckv1.KReference, obj interface{}) (*Connection, error) {
+	s, err := r.secret(ref.Namespace, ref.Name, obj)
+	if err != nil {
+		return nil, err
+	}
+	val := s.Data[BrokerURLSecretKey]
+	if val == nil {
+		return nil, fmt.Errorf("secret missing key %s", BrokerURLSecretKey)
+	}
+	u, err := url.Parse(string(val))
+	if err != nil {
+		return nil, fmt.Errorf("secret key %s is not a valid URL: %w", BrokerURLSecretKey, err)
+	}
+	return &Connection{URL: u}, nil
+}
+
+func (r *Resolver) fromRabbit(ctx context.Context, ref *duckv1.KReference, obj interface{}) (*Connection, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(ref.Kind))
+
+	err = r.Tracker.TrackReference(tracker.Reference{
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Namespace:  ref.Namespace,
+		Name:       ref.Name,
+	}, obj)
+	if err != nil {
+		return nil, err
+	}
+	informer, lister, err := r.RabbitLister.Get(ctx, gvr)
+	if err != nil {
+		return nil, err
+	}
+	r.watchRabbits.Do(func() {
+		informer.AddEventHandler(controller.HandleAll(
+			controller.EnsureTypeMeta(r.Tracker.OnChanged, gv.WithKind(ref.Kind)),
+		))
+	})
+	o, err := lister.ByNamespace(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	rab := o.(*duckv1beta1.Rabbit)
+
+	if rab.Status.DefaultUser == nil || rab.Status.DefaultUser.SecretReference == nil || rab.Status.DefaultUser.ServiceReference == nil {
+		return nil, fmt.Errorf("rabbit \"%s/%s\" not ready", ref.Namespace, ref.Name)
+	}
+
+	secretRef := rab.Status.DefaultUser.SecretReference
+	s, err := r.secret(secretRef.Namespace, secretRef.Name, obj)
+	if err != nil {
+		return nil, err
+	}
+	username, ok := s.Data[secretRef.Keys["username"]]
+	if !ok {
+		return nil, fmt.Errorf("rabbit Secret missing key %s", secretRef.Keys["username"])
+	}
+	password, ok := s.Data[secretRef.Keys["password"]]
+	if !ok {
+		return nil, fmt.Errorf("rabbit Secret missing key %s", secretRef.Keys["password"])
+	}
+
+	serviceRef := rab.Status.DefaultUser.ServiceReference
+	host := network.GetServiceHostname(serviceRef.Name, serviceRef.Namespace)
+	conn := &Connection{
+		URL: &url.URL{
+			Scheme: "amqp",
+			User:   url.UserPassword(string(username), string(password)),
+			Host:   fmt.Sprintf("%s:%d", host, amqpPort),
+		},
+	}
+
+	if tls := rab.Spec.TLS; tls != nil && tls.SecretName != "" {
+		conn.URL.Scheme = "amqps"
+		conn.URL.Host = fmt.Sprintf("%s:%d", host, amqpsPort)
+		if tls.CASecretName != "" {
+			ca, err := r.secret(rab.Namespace, tls.CASecretName, obj)
+			if err != nil {
+				return nil, err
+			}
+			if conn.CACert = ca.Data[CACertSecretKey]; len(conn.CACert) == 0 {
+				return nil, fmt.Errorf("rabbit CA Secret missing key %s", CACertSecretKey)
+			}
+		}
+	}
+	return conn, nil
+}
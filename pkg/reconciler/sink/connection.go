@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/sink/resources"
+)
+
+// This file contains the logic resolving Spec.Connection into the Secret read
+// by the publisher.
+
+// reconcileConnectionSecret resolves the connection of the sink, either its
+// URI and credentials or its reference to RabbitMQ, into the Secret read by
+// the publisher.
+func (r *Reconciler) reconcileConnectionSecret(ctx context.Context, s *v1alpha1.RabbitmqSink) (*corev1.Secret, error) {
+	conn, err := r.connectionResolver.ResolveSpec(ctx, s.Namespace, &s.Spec.Connection, s)
+	if err != nil {
+		return nil, err
+	}
+	return r.reconcileSecret(ctx, s, resources.MakeConnectionSecret(&resources.ConnectionArgs{
+		Sink:   s,
+		URL:    conn.URL,
+		CACert: conn.CACert,
+	}))
+}
+
+// reconcileSecret reconciles the K8s Secret 'secret' owned by the sink.
+func (r *Reconciler) reconcileSecret(ctx context.Context, s *v1alpha1.RabbitmqSink, secret *corev1.Secret) (*corev1.Secret, error) {
+	current, err := r.secretLister.Secrets(secret.Namespace).Get(secret.Name)
+	if apierrors.IsNotFound(err) {
+		return r.KubeClientSet.CoreV1().Secrets(secret.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, s) {
+		return nil, fmt.Errorf("secret %q is not owned by RabbitmqSink %q", current.Name, s.Name)
+	} else if !equality.Semantic.DeepEqual(secret.Data, current.Data) || !equality.Semantic.DeepEqual(secret.Labels, current.Labels) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Data = secret.Data
+		desired.Labels = secret.Labels
+		return r.KubeClientSet.CoreV1().Secrets(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	}
+	return current, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
+	rabbitmqsinkinformer "knative.dev/eventing-rabbitmq/pkg/client/injection/informers/eventing/v1alpha1/rabbitmqsink"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/eventing/v1alpha1/rabbitmqsink"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/sink/resources"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const publisherImageEnvVar = "RABBITMQ_SINK_IMAGE"
+
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher) *controller.Impl {
+
+	publisherImage, defined := os.LookupEnv(publisherImageEnvVar)
+	if !defined {
+		logging.FromContext(ctx).Errorf("required environment variable '%s' not defined", publisherImageEnvVar)
+		return nil
+	}
+
+	sinkInformer := rabbitmqsinkinformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
+	endpointsInformer := endpointsinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+
+	r := &Reconciler{
+		KubeClientSet:    kubeclient.Get(ctx),
+		deploymentLister: deploymentInformer.Lister(),
+		serviceLister:    serviceInformer.Lister(),
+		endpointsLister:  endpointsInformer.Lister(),
+		secretLister:     secretInformer.Lister(),
+		publisherImage:   publisherImage,
+	}
+
+	impl := rabbitmqsink.NewImpl(ctx, r)
+	r.connectionResolver = &connection.Resolver{
+		SecretLister: secretInformer.Lister(),
+		RabbitLister: rabbit.Get(ctx),
+		Tracker:      impl.Tracker,
+	}
+
+	logging.FromContext(ctx).Info("Setting up rabbitmqsink event handlers")
+
+	sinkInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqSink")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Reconcile the sinks whose connection was resolved from the Secrets.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret")),
+	))
+
+	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.LabelExistsFilterFunc(resources.SinkNameLabelKey),
+		Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("" /*any namespace*/, resources.SinkNameLabelKey)),
+	})
+	return impl
+}
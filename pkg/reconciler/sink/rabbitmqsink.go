@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	reconcilerrabbitmqsink "knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/eventing/v1alpha1/rabbitmqsink"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/sink/resources"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/network"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+type Reconciler struct {
+	// KubeClientSet allows us to talk to the k8s for core APIs
+	KubeClientSet kubernetes.Interface
+
+	publisherImage string
+
+	deploymentLister appsv1listers.DeploymentLister
+	serviceLister    corev1listers.ServiceLister
+	endpointsLister  corev1listers.EndpointsLister
+	secretLister     corev1listers.SecretLister
+
+	// connectionResolver resolves the connection references of the sinks.
+	connectionResolver *connection.Resolver
+}
+
+var _ reconcilerrabbitmqsink.Interface = (*Reconciler)(nil)
+
+// ReconcileKind reconciles the resources of the sink:
+// 1. Secret holding the connection to RabbitMQ
+// 2. Publisher Deployment
+// 3. K8s Service that points to the publisher Deployment
+func (r *Reconciler) ReconcileKind(ctx context.Context, s *v1alpha1.RabbitmqSink) pkgreconciler.Event {
+	s.Status.InitializeConditions()
+
+	secret, err := r.reconcileConnectionSecret(ctx, s)
+	if err != nil {
+		s.Status.MarkConnectionNotReady("ConnectionNotResolved", "Failed to resolve spec.connection: %v", err)
+		return fmt.Errorf("resolving connection: %w", err)
+	}
+	s.Status.MarkConnectionReady()
+
+	d, err := r.reconcileDeployment(ctx, s, resources.MakePublisherDeployment(&resources.PublisherArgs{
+		Image:            r.publisherImage,
+		Sink:             s,
+		ConnectionSecret: secret,
+	}))
+	if err != nil {
+		s.Status.MarkNotDeployed("DeploymentFailure", "Failed to reconcile deployment: %v", err)
+		return fmt.Errorf("reconciling the publisher deployment: %w", err)
+	}
+	s.Status.MarkDeployed(d)
+
+	ep, err := r.reconcileService(ctx, s, resources.MakePublisherService(s))
+	if err != nil {
+		s.Status.MarkNoAddress("ServiceFailure", "Failed to reconcile service: %v", err)
+		return fmt.Errorf("reconciling the publisher service: %w", err)
+	}
+	s.Status.MarkAddress(&apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(ep.Name, ep.Namespace),
+	}, ep)
+	return nil
+}
+
+// reconcileDeployment reconciles the K8s Deployment 'd' owned by the sink.
+func (r *Reconciler) reconcileDeployment(ctx context.Context, s *v1alpha1.RabbitmqSink, d *v1.Deployment) (*v1.Deployment, error) {
+	current, err := r.deploymentLister.Deployments(d.Namespace).Get(d.Name)
+	if apierrors.IsNotFound(err) {
+		return r.KubeClientSet.AppsV1().Deployments(d.Namespace).Create(ctx, d, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, s) {
+		return nil, fmt.Errorf("deployment %q is not owned by RabbitmqSink %q", current.Name, s.Name)
+	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Spec = d.Spec
+		return r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	}
+	return current, nil
+}
+
+// reconcileService reconciles the K8s Service 'svc' owned by the sink,
+// returning its Endpoints.
+func (r *Reconciler) reconcileService(ctx context.Context, s *v1alpha1.RabbitmqSink, svc *corev1.Service) (*corev1.Endpoints, error) {
+	current, err := r.serviceLister.Services(svc.Namespace).Get(svc.Name)
+	if apierrors.IsNotFound(err) {
+		current, err = r.KubeClientSet.CoreV1().Services(svc.Namespace).Create(ctx, svc, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, s) {
+		return nil, fmt.Errorf("service %q is not owned by RabbitmqSink %q", current.Name, s.Name)
+	}
+
+	// spec.clusterIP is immutable and is set on existing services. If we don't set this to the same value, we will
+	// encounter an error while updating.
+	svc.Spec.ClusterIP = current.Spec.ClusterIP
+	if !equality.Semantic.DeepDerivative(svc.Spec, current.Spec) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Spec = svc.Spec
+		if _, err = r.KubeClientSet.CoreV1().Services(current.Namespace).Update(ctx, desired, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.endpointsLister.Endpoints(svc.Namespace).Get(svc.Name)
+}
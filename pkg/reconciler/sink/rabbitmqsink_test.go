@@ -0,0 +1,286 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	fakerabbitmqclient "knative.dev/eventing-rabbitmq/pkg/client/injection/client/fake"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/eventing/v1alpha1/rabbitmqsink"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/sink/resources"
+	rt "knative.dev/eventing-rabbitmq/pkg/reconciler/testing"
+	"knative.dev/pkg/apis"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/network"
+	"knative.dev/pkg/tracker"
+
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	testNS         = "test-namespace"
+	sinkName       = "test-sink"
+	sinkUID        = "test-sink-uid"
+	publisherImage = "publisherimage"
+	rabbitURI      = "amqp://rabbit:5672"
+)
+
+var (
+	testKey = fmt.Sprintf("%s/%s", testNS, sinkName)
+
+	publisherName = resources.PublisherName(sinkName)
+	sinkAddress   = &apis.URL{
+		Scheme: "http",
+		Host:   network.GetServiceHostname(publisherName, testNS),
+	}
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		Key:  "too/many/parts",
+	}, {
+		Name: "key not found",
+		Key:  testKey,
+	}, {
+		Name: "sink is being deleted",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithRabbitmqSinkDeletionTimestamp),
+		},
+	}, {
+		Name: "creates the secret, deployment and service",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created")),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", `reconciling the publisher service: endpoints %q not found`, publisherName),
+		},
+		WantCreates: []runtime.Object{
+			connectionSecret(rabbitURI),
+			publisherDeployment(),
+			resources.MakePublisherService(newSink()),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithInitRabbitmqSinkConditions,
+				rt.WithRabbitmqSinkConnectionReady,
+				rt.WithRabbitmqSinkDeployed(publisherDeployment()),
+				rt.WithRabbitmqSinkNoAddress("ServiceFailure", "Failed to reconcile service: endpoints %q not found", publisherName)),
+		}},
+	}, {
+		Name: "updates the secret and deployment",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created")),
+			connectionSecret("amqp://stale:5672"),
+			publisherDeployment(func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Image = "staleimage"
+			}),
+			resources.MakePublisherService(newSink()),
+			publisherEndpoints(),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: connectionSecret(rabbitURI),
+		}, {
+			Object: publisherDeployment(),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithInitRabbitmqSinkConditions,
+				rt.WithRabbitmqSinkConnectionReady,
+				rt.WithRabbitmqSinkDeployed(publisherDeployment()),
+				rt.WithRabbitmqSinkAddress(sinkAddress, publisherEndpoints())),
+		}},
+	}, {
+		Name: "ready",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created")),
+			connectionSecret(rabbitURI),
+			publisherDeployment(withDeploymentAvailable),
+			resources.MakePublisherService(newSink()),
+			publisherEndpoints(),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithInitRabbitmqSinkConditions,
+				rt.WithRabbitmqSinkConnectionReady,
+				rt.WithRabbitmqSinkDeployed(publisherDeployment(withDeploymentAvailable)),
+				rt.WithRabbitmqSinkAddress(sinkAddress, publisherEndpoints())),
+		}},
+	}, {
+		Name: "connection not resolved",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithRabbitmqSinkCredentials("credentials")),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", `resolving connection: secret "credentials" not found`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithRabbitmqSinkCredentials("credentials"),
+				rt.WithInitRabbitmqSinkConditions,
+				rt.WithRabbitmqSinkConnectionNotReady("ConnectionNotResolved", `Failed to resolve spec.connection: secret "credentials" not found`)),
+		}},
+	}, {
+		Name: "deployment not owned by the sink",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created")),
+			connectionSecret(rabbitURI),
+			publisherDeployment(func(d *appsv1.Deployment) {
+				d.OwnerReferences = nil
+			}),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", `reconciling the publisher deployment: deployment %q is not owned by RabbitmqSink %q`, publisherName, sinkName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rt.NewRabbitmqSink(sinkName, testNS,
+				rt.WithRabbitmqSinkUID(sinkUID),
+				rt.WithRabbitmqSinkURI(rabbitURI),
+				rt.WithRabbitmqSinkExchange("orders", "order.created"),
+				rt.WithInitRabbitmqSinkConditions,
+				rt.WithRabbitmqSinkConnectionReady,
+				rt.WithRabbitmqSinkNotDeployed("DeploymentFailure", "Failed to reconcile deployment: deployment %q is not owned by RabbitmqSink %q", publisherName, sinkName)),
+		}},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, rt.MakeFactory(func(ctx context.Context, listers *rt.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			KubeClientSet:    fakekubeclient.Get(ctx),
+			publisherImage:   publisherImage,
+			deploymentLister: listers.GetDeploymentLister(),
+			serviceLister:    listers.GetServiceLister(),
+			endpointsLister:  listers.GetEndpointsLister(),
+			secretLister:     listers.GetSecretLister(),
+			connectionResolver: &connection.Resolver{
+				SecretLister: listers.GetSecretLister(),
+				Tracker:      tracker.New(func(types.NamespacedName) {}, 0),
+			},
+		}
+		return rabbitmqsink.NewReconciler(ctx, logger,
+			fakerabbitmqclient.Get(ctx), listers.GetRabbitmqSinkLister(),
+			controller.GetEventRecorder(ctx),
+			r,
+		)
+	},
+		false,
+		logger,
+	))
+}
+
+// newSink returns the sink the expected resources belong to.
+func newSink() *v1alpha1.RabbitmqSink {
+	return rt.NewRabbitmqSink(sinkName, testNS,
+		rt.WithRabbitmqSinkUID(sinkUID),
+		rt.WithRabbitmqSinkURI(rabbitURI),
+		rt.WithRabbitmqSinkExchange("orders", "order.created"))
+}
+
+func connectionSecret(uri string) *corev1.Secret {
+	u, _ := url.Parse(uri)
+	return resources.MakeConnectionSecret(&resources.ConnectionArgs{
+		Sink: newSink(),
+		URL:  u,
+	})
+}
+
+func publisherDeployment(opts ...func(*appsv1.Deployment)) *appsv1.Deployment {
+	d := resources.MakePublisherDeployment(&resources.PublisherArgs{
+		Image:            publisherImage,
+		Sink:             newSink(),
+		ConnectionSecret: connectionSecret(rabbitURI),
+	})
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+func withDeploymentAvailable(d *appsv1.Deployment) {
+	d.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:   appsv1.DeploymentAvailable,
+		Status: corev1.ConditionTrue,
+	}}
+}
+
+func publisherEndpoints() *corev1.Endpoints {
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      publisherName,
+			Labels:    resources.GetLabels(sinkName),
+		},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: "127.0.0.1"}},
+		}},
+	}
+}
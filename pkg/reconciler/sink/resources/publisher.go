@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	sourcesv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	sourceresources "knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	publisherContainerName = "publisher"
+	publisherPort          = 8080
+)
+
+// PublisherArgs are the arguments to create the publisher Deployment of a
+// RabbitmqSink.
+type PublisherArgs struct {
+	Image string
+	Sink  *v1alpha1.RabbitmqSink
+	// ConnectionSecret holds the connection resolved from the spec of the
+	// sink.
+	ConnectionSecret *corev1.Secret
+}
+
+// PublisherName is the name of the publisher Deployment and Service of the
+// sink.
+func PublisherName(sinkName string) string {
+	return kmeta.ChildName(sinkName, "-rabbitmqsink")
+}
+
+// MakePublisherDeployment creates the Deployment publishing the events sent
+// to the sink.
+func MakePublisherDeployment(args *PublisherArgs) *appsv1.Deployment {
+	spec := args.Sink.Spec
+	env := []corev1.EnvVar{{
+		Name: "RABBITMQ_URL",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: args.ConnectionSecret.Name},
+				Key:                  connection.BrokerURLSecretKey,
+			},
+		},
+	}, {
+		Name:  "RABBITMQ_EXCHANGE",
+		Value: spec.Exchange,
+	}, {
+		Name:  "RABBITMQ_ROUTING_KEY",
+		Value: spec.RoutingKey,
+	}, {
+		Name:  "RABBITMQ_CONTENT_MODE",
+		Value: spec.ContentMode,
+	}}
+	if spec.Connection.SASLMechanism != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_SASL_MECHANISM",
+			Value: spec.Connection.SASLMechanism,
+		})
+	}
+
+	tlsEnv, volumes, volumeMounts := sourceresources.TLSVolumes(connectionTLS(args))
+	env = append(env, tlsEnv...)
+
+	labels := GetLabels(args.Sink.Name)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.Sink.Namespace,
+			Name:      PublisherName(args.Sink.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.Sink),
+			},
+			Labels: labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: spec.ServiceAccountName,
+					Volumes:            volumes,
+					Containers: []corev1.Container{{
+						Name:         publisherContainerName,
+						Image:        args.Image,
+						Env:          env,
+						VolumeMounts: volumeMounts,
+						Ports: []corev1.ContainerPort{{
+							ContainerPort: publisherPort,
+							Name:          "http",
+						}},
+						ReadinessProbe: &corev1.Probe{
+							Handler: corev1.Handler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path: "/healthz",
+									Port: intstr.FromString("http"),
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// MakePublisherService creates the Service addressing the publisher of the
+// sink.
+func MakePublisherService(s *v1alpha1.RabbitmqSink) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.Namespace,
+			Name:      PublisherName(s.Name),
+			Labels:    GetLabels(s.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(s),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: GetLabels(s.Name),
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       80,
+				TargetPort: intstr.FromInt(publisherPort),
+			}},
+		},
+	}
+}
+
+// connectionTLS returns the TLS configuration of the sink, verifying the
+// server with the CA bundle of the connection Secret unless the sink has its
+// own.
+func connectionTLS(args *PublisherArgs) *sourcesv1alpha1.RabbitmqSourceTLSSpec {
+	// The TLS configuration is shared with RabbitmqSources.
+	tls := (*sourcesv1alpha1.RabbitmqSourceTLSSpec)(args.Sink.Spec.Connection.TLS)
+	if len(args.ConnectionSecret.Data[connection.CACertSecretKey]) == 0 {
+		return tls
+	}
+	if tls == nil {
+		tls = &sourcesv1alpha1.RabbitmqSourceTLSSpec{}
+	} else if tls.CACert != nil {
+		return tls
+	} else {
+		tls = tls.DeepCopy()
+	}
+	tls.CACert = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: args.ConnectionSecret.Name},
+		Key:                  connection.CACertSecretKey,
+	}
+	return tls
+}
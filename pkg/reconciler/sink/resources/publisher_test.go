@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+)
+
+var (
+	sink = &v1alpha1.RabbitmqSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sink-name",
+			Namespace: "sink-namespace",
+			UID:       "1234",
+		},
+		Spec: v1alpha1.RabbitmqSinkSpec{
+			Connection: sourcesv1beta1.RabbitmqSourceConnectionSpec{
+				URI: "amqp://rabbitmq:5672",
+			},
+			Exchange:           "orders",
+			RoutingKey:         "orders.{{ .type }}",
+			ContentMode:        v1alpha1.ContentModeBinary,
+			ServiceAccountName: "publisher",
+		},
+	}
+
+	connectionSecret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink-name-rabbitmqsink-connection"},
+	}
+
+	ownerReferences = []metav1.OwnerReference{{
+		APIVersion:         "eventing.knative.dev/v1alpha1",
+		Kind:               "RabbitmqSink",
+		Name:               "sink-name",
+		UID:                "1234",
+		Controller:         &[]bool{true}[0],
+		BlockOwnerDeletion: &[]bool{true}[0],
+	}}
+
+	labels = map[string]string{
+		"eventing.knative.dev/sink":     "rabbitmq-sink-controller",
+		"eventing.knative.dev/sinkName": "sink-name",
+	}
+)
+
+func TestMakePublisherDeployment(t *testing.T) {
+	got := MakePublisherDeployment(&PublisherArgs{
+		Image:            "test-image",
+		Sink:             sink,
+		ConnectionSecret: connectionSecret,
+	})
+
+	want := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "sink-name-rabbitmqsink",
+			Namespace:       "sink-namespace",
+			Labels:          labels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "publisher",
+					Containers: []corev1.Container{{
+						Name:  "publisher",
+						Image: "test-image",
+						Env: []corev1.EnvVar{{
+							Name: "RABBITMQ_URL",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "sink-name-rabbitmqsink-connection"},
+									Key:                  "brokerURL",
+								},
+							},
+						}, {
+							Name:  "RABBITMQ_EXCHANGE",
+							Value: "orders",
+						}, {
+							Name:  "RABBITMQ_ROUTING_KEY",
+							Value: "orders.{{ .type }}",
+						}, {
+							Name:  "RABBITMQ_CONTENT_MODE",
+							Value: "binary",
+						}},
+						Ports: []corev1.ContainerPort{{
+							ContainerPort: 8080,
+							Name:          "http",
+						}},
+						ReadinessProbe: &corev1.Probe{
+							Handler: corev1.Handler{
+								HTTPGet: &corev1.HTTPGetAction{
+									Path: "/healthz",
+									Port: intstr.FromString("http"),
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected deployment (-want, +got) = %v", diff)
+	}
+}
+
+func TestMakePublisherDeploymentWithTLS(t *testing.T) {
+	tlsSink := sink.DeepCopy()
+	tlsSink.Spec.Connection.SASLMechanism = "EXTERNAL"
+	tlsSink.Spec.Connection.TLS = &sourcesv1beta1.RabbitmqSourceTLSSpec{
+		ClientCertSecret: &corev1.LocalObjectReference{Name: "client-secret"},
+	}
+	secret := connectionSecret.DeepCopy()
+	secret.Data = map[string][]byte{"ca.crt": []byte("ca")}
+
+	got := MakePublisherDeployment(&PublisherArgs{
+		Image:            "test-image",
+		Sink:             tlsSink,
+		ConnectionSecret: secret,
+	})
+
+	wantEnv := []corev1.EnvVar{{
+		Name:  "RABBITMQ_SASL_MECHANISM",
+		Value: "EXTERNAL",
+	}, {
+		Name:  "RABBITMQ_TLS_ENABLED",
+		Value: "true",
+	}, {
+		Name:  "RABBITMQ_TLS_CA_FILE",
+		Value: "/etc/rabbitmq/ca/ca.crt",
+	}, {
+		Name:  "RABBITMQ_TLS_CERT_FILE",
+		Value: "/etc/rabbitmq/client/tls.crt",
+	}, {
+		Name:  "RABBITMQ_TLS_KEY_FILE",
+		Value: "/etc/rabbitmq/client/tls.key",
+	}}
+	env := got.Spec.Template.Spec.Containers[0].Env
+	if diff := cmp.Diff(wantEnv, env[len(env)-len(wantEnv):]); diff != "" {
+		t.Errorf("unexpected env (-want, +got) = %v", diff)
+	}
+
+	wantVolumes := []corev1.Volume{{
+		Name: "rabbitmq-ca-cert",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "sink-name-rabbitmqsink-connection",
+				Items: []corev1.KeyToPath{{
+					Key:  "ca.crt",
+					Path: "ca.crt",
+				}},
+			},
+		},
+	}, {
+		Name: "rabbitmq-client-cert",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "client-secret",
+			},
+		},
+	}}
+	if diff := cmp.Diff(wantVolumes, got.Spec.Template.Spec.Volumes); diff != "" {
+		t.Errorf("unexpected volumes (-want, +got) = %v", diff)
+	}
+	if tlsSink.Spec.Connection.TLS.CACert != nil {
+		t.Error("MakePublisherDeployment modified the TLS spec of the sink")
+	}
+}
+
+func TestMakePublisherService(t *testing.T) {
+	want := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "sink-name-rabbitmqsink",
+			Namespace:       "sink-namespace",
+			Labels:          labels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+			}},
+		},
+	}
+	if diff := cmp.Diff(want, MakePublisherService(sink)); diff != "" {
+		t.Errorf("unexpected service (-want, +got) = %v", diff)
+	}
+}
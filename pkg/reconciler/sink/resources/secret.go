@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/pkg/kmeta"
+)
+
+type ConnectionArgs struct {
+	Sink *v1alpha1.RabbitmqSink
+	// URL of the brokers, including the credentials.
+	URL *url.URL
+	// CACert is the PEM encoded CA bundle of the RabbitmqCluster, if any.
+	CACert []byte
+}
+
+// MakeConnectionSecret creates the Secret holding the connection resolved
+// from the spec of the sink, read by the publisher.
+func MakeConnectionSecret(args *ConnectionArgs) *corev1.Secret {
+	data := map[string][]byte{
+		connection.BrokerURLSecretKey: []byte(args.URL.String()),
+	}
+	if len(args.CACert) > 0 {
+		data[connection.CACertSecretKey] = args.CACert
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.Sink.Namespace,
+			Name:      ConnectionSecretName(args.Sink.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.Sink),
+			},
+			Labels: GetLabels(args.Sink.Name),
+		},
+		Data: data,
+	}
+}
+
+func ConnectionSecretName(sinkName string) string {
+	return kmeta.ChildName(sinkName, "-rabbitmqsink-connection")
+}
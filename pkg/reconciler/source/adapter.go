@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	adapter "knative.dev/eventing-rabbitmq/pkg/adapter"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+	"knative.dev/pkg/logging"
+)
+
+// This file contains the logic collecting the state of the connection to
+// RabbitMQ reported by the receive adapter pods of sources.
+
+const (
+	// adapterStatusTimeout bounds the time to get the status of all the
+	// adapters of a source.
+	adapterStatusTimeout = 2 * time.Second
+	// statusRefreshInterval is how often the status of sources is refreshed
+	// when not otherwise requeued.
+	statusRefreshInterval = time.Minute
+)
+
+// adapterStatuses returns the status reported by each running receive adapter
+// pod of the source that could be reached, probing them all at once.
+func (r *Reconciler) adapterStatuses(ctx context.Context, src *v1alpha1.RabbitmqSource) []adapter.Status {
+	logger := logging.FromContext(ctx)
+
+	pods, err := r.podLister.Pods(src.Namespace).List(labels.SelectorFromSet(resources.GetLabels(src.Name)))
+	if err != nil {
+		logger.Warnw("Failed to list the receive adapter pods", zap.Error(err))
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, adapterStatusTimeout)
+	defer cancel()
+
+	results := make([]*adapter.Status, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, pod *corev1.Pod) {
+			defer wg.Done()
+			status, err := r.adapterStatus(ctx, pod)
+			if err != nil {
+				logger.Debugw("Failed to get the status of the receive adapter", zap.String("pod", pod.Name), zap.Error(err))
+				return
+			}
+			results[i] = status
+		}(i, pod)
+	}
+	wg.Wait()
+
+	statuses := []adapter.Status{}
+	for _, status := range results {
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+	return statuses
+}
+
+// adapterStatus gets the status served by the receive adapter in pod.
+func (r *Reconciler) adapterStatus(ctx context.Context, pod *corev1.Pod) (*adapter.Status, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(resources.HealthPort)), adapter.StatusPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	status := &adapter.Status{}
+	if err := json.NewDecoder(res.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
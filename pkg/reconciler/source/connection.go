@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+)
+
+// This file contains the logic dealing with how to handle Spec.ConnectionRef.
+
+// reconcileConnectionSecret resolves the ConnectionRef of the source into the
+// Secret read by the receive adapter. It returns nil if the source has no
+// ConnectionRef.
+func (r *Reconciler) reconcileConnectionSecret(ctx context.Context, src *v1alpha1.RabbitmqSource) (*corev1.Secret, error) {
+	if src.Spec.ConnectionRef == nil {
+		return nil, nil
+	}
+
+	ref := src.Spec.ConnectionRef.DeepCopy()
+	if ref.Namespace == "" {
+		ref.Namespace = src.Namespace
+	}
+	if ref.Name == "" {
+		return nil, errors.New("spec.connectionRef.name is required")
+	}
+
+	conn, err := r.connectionResolver.Resolve(ctx, ref, src)
+	if errors.Is(err, connection.ErrUnsupportedRef) {
+		return nil, errors.New("spec.connectionRef configuration not supported, only [kind: Secret, apiVersion: v1 or kind: RabbitmqCluster, apiVersion: rabbitmq.com/v1beta1]")
+	}
+	if err != nil {
+		return nil, err
+	}
+	args := &resources.ConnectionArgs{Source: src, URL: conn.URL, CACert: conn.CACert}
+	if src.Spec.Vhost != "" {
+		args.URL.Path, args.URL.RawPath = "", ""
+		if err := dialer.JoinVhost(args.URL, src.Spec.Vhost); err != nil {
+			return nil, err
+		}
+	}
+	return r.reconcileSecret(ctx, src, resources.MakeConnectionSecret(args))
+}
+
+// reconcileSecret reconciles the K8s Secret 's' owned by the source.
+func (r *Reconciler) reconcileSecret(ctx context.Context, src *v1alpha1.RabbitmqSource, s *corev1.Secret) (*corev1.Secret, error) {
+	current, err := r.secretLister.Secrets(s.Namespace).Get(s.Name)
+	if apierrors.IsNotFound(err) {
+		return r.KubeClientSet.CoreV1().Secrets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	} else if !metav1.IsControlledBy(current, src) {
+		return nil, fmt.Errorf("secret %q is not owned by RabbitmqSource %q", current.Name, src.Name)
+	} else if !equality.Semantic.DeepEqual(s.Data, current.Data) || !equality.Semantic.DeepEqual(s.Labels, current.Labels) {
+		// Don't modify the informers copy.
+		desired := current.DeepCopy()
+		desired.Data = s.Data
+		desired.Labels = s.Labels
+		return r.KubeClientSet.CoreV1().Secrets(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	}
+	return current, nil
+}
@@ -18,15 +18,24 @@ package rabbitmq
 
 import (
 	"context"
+	"net/http"
 	"os"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
 	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
 	rabbitmqclient "knative.dev/eventing-rabbitmq/pkg/client/injection/client"
+	"knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
 	rabbitmqinformer "knative.dev/eventing-rabbitmq/pkg/client/injection/informers/sources/v1alpha1/rabbitmqsource"
 	"knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/sources/v1alpha1/rabbitmqsource"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
+	secretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -46,17 +55,29 @@ func NewController(
 
 	rabbitmqInformer := rabbitmqinformer.Get(ctx)
 	deploymentInformer := deploymentinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	podInformer := podinformer.Get(ctx, resources.ReceiveAdapterSelector)
 
 	c := &Reconciler{
 		KubeClientSet:       kubeclient.Get(ctx),
 		rabbitmqClientSet:   rabbitmqclient.Get(ctx),
 		rabbitmqLister:      rabbitmqInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
+		secretLister:        secretInformer.Lister(),
+		podLister:           podInformer.Lister(),
 		receiveAdapterImage: raImage,
 		loggingContext:      ctx,
+		dialerFunc:          dialer.ConfigDialer,
+		httpClient:          &http.Client{},
+		podTemplates:        podtemplate.NewStore(logging.FromContext(ctx)),
 	}
 
 	impl := rabbitmqsource.NewImpl(ctx, c)
+	c.connectionResolver = &connection.Resolver{
+		SecretLister: secretInformer.Lister(),
+		RabbitLister: rabbit.Get(ctx),
+		Tracker:      impl.Tracker,
+	}
 	c.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
 
 	logging.FromContext(ctx).Info("Setting up rabbitmq event handlers")
@@ -68,7 +89,20 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(v1alpha1.Kind("RabbitmqSource")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Reconcile the sources whose connection was resolved from the Secrets.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret")),
+	))
+
 	cmw.Watch(logging.ConfigMapName(), c.UpdateFromLoggingConfigMap)
 	cmw.Watch(metrics.ConfigMapName(), c.UpdateFromMetricsConfigMap)
+	c.podTemplates.WatchConfigMap(cmw, func() {
+		impl.GlobalResync(rabbitmqInformer.Informer())
+	})
 	return impl
 }
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+)
+
+// reconcileOffsetStore creates the ConfigMap in which the receive adapter of
+// a source consuming a stream queue stores its offset, along with the Role
+// and RoleBinding giving the adapter access to it.
+func (r *Reconciler) reconcileOffsetStore(ctx context.Context, src *v1alpha1.RabbitmqSource) error {
+	if src.Spec.QueueConfig.Type != v1alpha1.QueueTypeStream {
+		return nil
+	}
+
+	// The ConfigMap is only created, its data belongs to the receive adapter.
+	cm := resources.MakeOffsetConfigMap(src)
+	current, err := r.KubeClientSet.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := r.KubeClientSet.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating offset ConfigMap: %w", err)
+		}
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(current, src) {
+		return fmt.Errorf("configmap %q is not owned by RabbitmqSource %q", current.Name, src.Name)
+	}
+
+	role := resources.MakeOffsetRole(src)
+	currentRole, err := r.KubeClientSet.RbacV1().Roles(role.Namespace).Get(ctx, role.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := r.KubeClientSet.RbacV1().Roles(role.Namespace).Create(ctx, role, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating offset Role: %w", err)
+		}
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(currentRole, src) {
+		return fmt.Errorf("role %q is not owned by RabbitmqSource %q", currentRole.Name, src.Name)
+	} else if !equality.Semantic.DeepEqual(role.Rules, currentRole.Rules) {
+		desired := currentRole.DeepCopy()
+		desired.Rules = role.Rules
+		if _, err := r.KubeClientSet.RbacV1().Roles(role.Namespace).Update(ctx, desired, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("updating offset Role: %w", err)
+		}
+	}
+
+	rb := resources.MakeOffsetRoleBinding(src)
+	currentRB, err := r.KubeClientSet.RbacV1().RoleBindings(rb.Namespace).Get(ctx, rb.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := r.KubeClientSet.RbacV1().RoleBindings(rb.Namespace).Create(ctx, rb, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating offset RoleBinding: %w", err)
+		}
+	} else if err != nil {
+		return err
+	} else if !metav1.IsControlledBy(currentRB, src) {
+		return fmt.Errorf("rolebinding %q is not owned by RabbitmqSource %q", currentRB.Name, src.Name)
+	} else if !equality.Semantic.DeepEqual(rb.Subjects, currentRB.Subjects) {
+		// The role of a binding can't change, only its subjects.
+		desired := currentRB.DeepCopy()
+		desired.Subjects = rb.Subjects
+		if _, err := r.KubeClientSet.RbacV1().RoleBindings(rb.Namespace).Update(ctx, desired, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("updating offset RoleBinding: %w", err)
+		}
+	}
+	return nil
+}
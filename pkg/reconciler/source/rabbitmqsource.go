@@ -19,10 +19,13 @@ package rabbitmq
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"knative.dev/eventing/pkg/utils"
 	"knative.dev/pkg/controller"
 
+	origamqp "github.com/streadway/amqp"
 	"go.uber.org/zap"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -31,10 +34,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/pointer"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
 	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
 	"knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned"
 	reconcilerrabbitmqsource "knative.dev/eventing-rabbitmq/pkg/client/injection/reconciler/sources/v1alpha1/rabbitmqsource"
 	listers "knative.dev/eventing-rabbitmq/pkg/client/listers/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -52,6 +60,10 @@ const (
 	rabbitmqSourceDeploymentDeleted = "RabbitmqSourceDeploymentDeleted"
 	rabbitmqSourceDeploymentFailed  = "RabbitmqSourceDeploymentUpdated"
 	component                       = "rabbitmqsource"
+
+	// restartedAtAnnotationKey is the pod template annotation set by
+	// kubectl rollout restart.
+	restartedAtAnnotationKey = "kubectl.kubernetes.io/restartedAt"
 )
 
 func newDeploymentCreated(namespace, name string) pkgreconciler.Event {
@@ -74,13 +86,28 @@ type Reconciler struct {
 
 	rabbitmqLister   listers.RabbitmqSourceLister
 	deploymentLister appsv1listers.DeploymentLister
+	secretLister     corev1listers.SecretLister
+	podLister        corev1listers.PodLister
 
 	rabbitmqClientSet versioned.Interface
 	loggingContext    context.Context
 	loggingConfig     *pkgLogging.Config
 	metricsConfig     *metrics.ExporterOptions
 
+	// podTemplates holds the pod template overlays of the ConfigMap.
+	podTemplates *podtemplate.Store
+
 	sinkResolver *resolver.URIResolver
+
+	// connectionResolver resolves the ConnectionRef of the sources.
+	connectionResolver *connection.Resolver
+
+	// dialerFunc connects to RabbitMQ to check the topology of the sources
+	// that don't declare it.
+	dialerFunc func(origamqp.Config) dialer.DialerFunc
+
+	// httpClient gets the status of the receive adapters.
+	httpClient *http.Client
 }
 
 var _ reconcilerrabbitmqsource.Interface = (*Reconciler)(nil)
@@ -106,7 +133,36 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.RabbitmqSo
 	}
 	src.Status.MarkSink(sinkURI)
 
-	ra, err := r.createReceiveAdapter(ctx, src, sinkURI)
+	var deadLetterSinkURI *apis.URL
+	if src.Spec.Delivery != nil && src.Spec.Delivery.DeadLetterSink != nil {
+		dest := src.Spec.Delivery.DeadLetterSink.DeepCopy()
+		if dest.Ref != nil && dest.Ref.Namespace == "" {
+			dest.Ref.Namespace = src.GetNamespace()
+		}
+		deadLetterSinkURI, err = r.sinkResolver.URIFromDestinationV1(ctx, *dest, src)
+		if err != nil {
+			src.Status.MarkNoDeadLetterSink("DeadLetterSinkNotFound", "%v", err)
+			return fmt.Errorf("getting dead letter sink URI: %v", err)
+		}
+	}
+	src.Status.MarkDeadLetterSink(deadLetterSinkURI)
+
+	connectionSecret, err := r.reconcileConnectionSecret(ctx, src)
+	if err != nil {
+		src.Status.MarkNotDeployed("ConnectionNotResolved", "Failed to resolve spec.connectionRef: %v", err)
+		return fmt.Errorf("resolving connection: %w", err)
+	}
+
+	if err := r.reconcileOffsetStore(ctx, src); err != nil {
+		src.Status.MarkNotDeployed("OffsetStoreFailed", "Failed to set up the stream offset store: %v", err)
+		return fmt.Errorf("reconciling the offset store: %w", err)
+	}
+
+	// The topology is checked first so that the receive adapter is scaled on
+	// the current state of the queue.
+	topologyErr := r.reconcileTopology(ctx, src, connectionSecret)
+
+	ra, err := r.createReceiveAdapter(ctx, src, sinkURI, deadLetterSinkURI, connectionSecret)
 	if err != nil {
 		logging.FromContext(ctx).Error("Unable to create the receive adapter", zap.Error(err))
 		return err
@@ -114,10 +170,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, src *v1alpha1.RabbitmqSo
 	src.Status.MarkDeployed(ra)
 	src.Status.CloudEventAttributes = r.createCloudEventAttributes(src)
 
-	return nil
+	if topologyErr != nil {
+		return topologyErr
+	}
+	if src.Spec.Scale != nil {
+		// Check the queue again to scale the receive adapter.
+		return controller.NewRequeueAfter(src.Spec.Scale.GetPollingInterval())
+	}
+	// Refresh the state of the queue and of the connection.
+	return controller.NewRequeueAfter(statusRefreshInterval)
 }
 
-func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1alpha1.RabbitmqSource, sinkURI *apis.URL) (*v1.Deployment, error) {
+func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1alpha1.RabbitmqSource, sinkURI, deadLetterSinkURI *apis.URL, connectionSecret *corev1.Secret) (*v1.Deployment, error) {
 
 	loggingConfig, err := logging.ConfigToJSON(r.loggingConfig)
 	if err != nil {
@@ -130,14 +194,22 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1alpha1.Rab
 	}
 
 	raArgs := resources.ReceiveAdapterArgs{
-		Image:         r.receiveAdapterImage,
-		Source:        src,
-		Labels:        resources.GetLabels(src.Name),
-		SinkURI:       sinkURI.String(),
-		MetricsConfig: metricsConfig,
-		LoggingConfig: loggingConfig,
+		Image:            r.receiveAdapterImage,
+		Source:           src,
+		Labels:           resources.GetLabels(src.Name),
+		SinkURI:          sinkURI.String(),
+		MetricsConfig:    metricsConfig,
+		LoggingConfig:    loggingConfig,
+		ConnectionSecret: connectionSecret,
+		Replicas:         pointer.Int32Ptr(resources.Replicas(src, time.Now())),
+	}
+	if deadLetterSinkURI != nil {
+		raArgs.DeadLetterSinkURI = deadLetterSinkURI.String()
 	}
 	expected := resources.MakeReceiveAdapter(&raArgs)
+	if err := r.podTemplates.Apply(&expected.Spec.Template, podtemplate.ReceiveAdapterKey, src.Spec.PodTemplate); err != nil {
+		return nil, fmt.Errorf("applying the pod template: %w", err)
+	}
 
 	ra, err := r.KubeClientSet.AppsV1().Deployments(src.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
@@ -159,8 +231,17 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1alpha1.Rab
 		return nil, err
 	} else if !metav1.IsControlledBy(ra, src) {
 		return nil, fmt.Errorf("deployment %q is not owned by RabbitmqSource %q", ra.Name, src.Name)
-	} else if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) {
+	} else if podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) ||
+		podMetadataChanged(ra.Spec.Template.ObjectMeta, expected.Spec.Template.ObjectMeta) ||
+		podtemplate.HashChanged(&ra.Spec.Template, &expected.Spec.Template) ||
+		replicasChanged(ra.Spec.Replicas, expected.Spec.Replicas) ||
+		ra.Spec.Strategy.Type != expected.Spec.Strategy.Type {
+		// Spec changes roll the receive adapter out with its strategy.
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
+		ra.Spec.Template.Labels = expected.Spec.Template.Labels
+		ra.Spec.Template.Annotations = mergeMaps(restartAnnotation(ra.Spec.Template.Annotations), expected.Spec.Template.Annotations)
+		ra.Spec.Replicas = expected.Spec.Replicas
+		ra.Spec.Strategy = expected.Spec.Strategy
 		if ra, err = r.KubeClientSet.AppsV1().Deployments(src.Namespace).Update(ctx, ra, metav1.UpdateOptions{}); err != nil {
 			return ra, err
 		}
@@ -186,6 +267,45 @@ func podSpecChanged(oldPodSpec corev1.PodSpec, newPodSpec corev1.PodSpec) bool {
 	return false
 }
 
+// podMetadataChanged tells if the labels or annotations of the pods are
+// missing any of the expected ones, the others, such as the ones set by
+// kubectl rollout restart, being left alone.
+func podMetadataChanged(old, expected metav1.ObjectMeta) bool {
+	return !equality.Semantic.DeepDerivative(expected.Labels, old.Labels) ||
+		!equality.Semantic.DeepDerivative(expected.Annotations, old.Annotations)
+}
+
+// restartAnnotation returns the annotation set by kubectl rollout restart in
+// annotations, if any, the others being replaced by the expected ones.
+func restartAnnotation(annotations map[string]string) map[string]string {
+	if restartedAt, ok := annotations[restartedAtAnnotationKey]; ok {
+		return map[string]string{restartedAtAnnotationKey: restartedAt}
+	}
+	return nil
+}
+
+// mergeMaps returns a copy of current with the entries of expected.
+func mergeMaps(current, expected map[string]string) map[string]string {
+	if len(current) == 0 && len(expected) == 0 {
+		return current
+	}
+	merged := make(map[string]string, len(current)+len(expected))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range expected {
+		merged[k] = v
+	}
+	return merged
+}
+
+func replicasChanged(oldReplicas, newReplicas *int32) bool {
+	if oldReplicas == nil || newReplicas == nil {
+		return oldReplicas != newReplicas
+	}
+	return *oldReplicas != *newReplicas
+}
+
 func (r *Reconciler) UpdateFromLoggingConfigMap(cfg *corev1.ConfigMap) {
 	if cfg != nil {
 		delete(cfg.Data, "_example")
@@ -215,9 +335,5 @@ func (r *Reconciler) UpdateFromMetricsConfigMap(cfg *corev1.ConfigMap) {
 }
 
 func (r *Reconciler) createCloudEventAttributes(src *v1alpha1.RabbitmqSource) []duckv1.CloudEventAttributes {
-	ceAttribute := duckv1.CloudEventAttributes{
-		Type:   v1alpha1.RabbitmqEventType,
-		Source: v1alpha1.RabbitmqEventSource(src.Namespace, src.Name, src.Spec.Topic),
-	}
-	return []duckv1.CloudEventAttributes{ceAttribute}
+	return src.CloudEventAttributes()
 }
@@ -18,11 +18,16 @@ package resources
 
 const (
 	controllerAgentName = "rabbitmq-source-controller"
+
+	// ReceiveAdapterSelector selects the receive adapters of all the sources.
+	ReceiveAdapterSelector = sourceLabelKey + "=" + controllerAgentName
+
+	sourceLabelKey = "eventing.knative.dev/source"
 )
 
 func GetLabels(name string) map[string]string {
 	return map[string]string{
-		"eventing.knative.dev/source":     controllerAgentName,
+		sourceLabelKey:                    controllerAgentName,
 		"eventing.knative.dev/SourceName": name,
 	}
 }
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/pkg/kmeta"
+)
+
+// MakeOffsetConfigMap creates the ConfigMap in which the receive adapter
+// stores the offset of the last message processed from a stream queue.
+func MakeOffsetConfigMap(src *v1alpha1.RabbitmqSource) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: offsetObjectMeta(src),
+	}
+}
+
+// MakeOffsetRole creates the Role allowing the receive adapter to read and
+// update its offset ConfigMap.
+func MakeOffsetRole(src *v1alpha1.RabbitmqSource) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: offsetObjectMeta(src),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"configmaps"},
+			ResourceNames: []string{OffsetConfigMapName(src.Name)},
+			Verbs:         []string{"get", "update"},
+		}},
+	}
+}
+
+// MakeOffsetRoleBinding binds the offset Role to the service account of the
+// receive adapter.
+func MakeOffsetRoleBinding(src *v1alpha1.RabbitmqSource) *rbacv1.RoleBinding {
+	serviceAccountName := src.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	return &rbacv1.RoleBinding{
+		ObjectMeta: offsetObjectMeta(src),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     OffsetConfigMapName(src.Name),
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: src.Namespace,
+			Name:      serviceAccountName,
+		}},
+	}
+}
+
+// OffsetConfigMapName is the name of the offset ConfigMap, Role and
+// RoleBinding of the source.
+func OffsetConfigMapName(sourceName string) string {
+	return kmeta.ChildName(sourceName, "-rabbitmq-offset")
+}
+
+func offsetObjectMeta(src *v1alpha1.RabbitmqSource) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: src.Namespace,
+		Name:      OffsetConfigMapName(src.Name),
+		OwnerReferences: []metav1.OwnerReference{
+			*kmeta.NewControllerRef(src),
+		},
+		Labels: GetLabels(src.Name),
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1alpha12 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+func TestMakeOffsetRBAC(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		serviceAccountName string
+		wantSubject        string
+	}{{
+		name:        "default service account",
+		wantSubject: "default",
+	}, {
+		name:               "service account",
+		serviceAccountName: "adapter",
+		wantSubject:        "adapter",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &v1alpha12.RabbitmqSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "source-name",
+					Namespace: "source-namespace",
+				},
+				Spec: v1alpha12.RabbitmqSourceSpec{
+					ServiceAccountName: tt.serviceAccountName,
+				},
+			}
+
+			cm := MakeOffsetConfigMap(src)
+			if cm.Name != "source-name-rabbitmq-offset" || cm.Namespace != "source-namespace" {
+				t.Errorf("Unexpected ConfigMap %s/%s", cm.Namespace, cm.Name)
+			}
+
+			wantRules := []rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{"source-name-rabbitmq-offset"},
+				Verbs:         []string{"get", "update"},
+			}}
+			if diff := cmp.Diff(wantRules, MakeOffsetRole(src).Rules); diff != "" {
+				t.Errorf("unexpected rules (-want, +got) = %v", diff)
+			}
+
+			rb := MakeOffsetRoleBinding(src)
+			wantRoleRef := rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "Role",
+				Name:     "source-name-rabbitmq-offset",
+			}
+			if diff := cmp.Diff(wantRoleRef, rb.RoleRef); diff != "" {
+				t.Errorf("unexpected role ref (-want, +got) = %v", diff)
+			}
+			wantSubjects := []rbacv1.Subject{{
+				Kind:      "ServiceAccount",
+				Namespace: "source-namespace",
+				Name:      tt.wantSubject,
+			}}
+			if diff := cmp.Diff(wantSubjects, rb.Subjects); diff != "" {
+				t.Errorf("unexpected subjects (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -17,16 +17,31 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
 	"knative.dev/pkg/kmeta"
 )
 
+const (
+	// HealthPort is the port of the readiness and status endpoints of the
+	// receive adapter.
+	HealthPort = 8080
+
+	caCertVolumeName     = "rabbitmq-ca-cert"
+	caCertMountPath      = "/etc/rabbitmq/ca"
+	caCertFileName       = "ca.crt"
+	clientCertVolumeName = "rabbitmq-client-cert"
+	clientCertMountPath  = "/etc/rabbitmq/client"
+)
+
 type ReceiveAdapterArgs struct {
 	Image         string
 	Source        *v1alpha1.RabbitmqSource
@@ -34,32 +49,44 @@ type ReceiveAdapterArgs struct {
 	SinkURI       string
 	MetricsConfig string
 	LoggingConfig string
+	// DeadLetterSinkURI is the resolved dead letter sink of the source's
+	// delivery spec, if any.
+	DeadLetterSinkURI string
+	// ConnectionSecret holds the connection resolved from the ConnectionRef
+	// of the source, if any. It replaces the brokers and credentials of the
+	// source.
+	ConnectionSecret *corev1.Secret
+	// Replicas is the number of receive adapter pods. Defaults to 1.
+	Replicas *int32
 }
 
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 	replicas := int32(1)
+	if args.Replicas != nil {
+		replicas = *args.Replicas
+	}
 
 	env := []corev1.EnvVar{
-		{
-			Name:  "RABBITMQ_BROKERS",
-			Value: args.Source.Spec.Brokers,
-		},
+		brokersEnv(args),
 		{
 			Name:  "RABBITMQ_TOPIC",
 			Value: args.Source.Spec.Topic,
 		},
-		{
+	}
+	if args.ConnectionSecret == nil {
+		env = append(env, corev1.EnvVar{
 			Name: "RABBITMQ_USER",
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: args.Source.Spec.User.SecretKeyRef,
 			},
-		},
-		{
+		}, corev1.EnvVar{
 			Name: "RABBITMQ_PASSWORD",
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: args.Source.Spec.Password.SecretKeyRef,
 			},
-		},
+		})
+	}
+	env = append(env, []corev1.EnvVar{
 		{
 			Name:  "RABBITMQ_ROUTING_KEY",
 			Value: args.Source.Spec.QueueConfig.RoutingKey,
@@ -144,6 +171,118 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 			Name:  "RABBITMQ_VHOST",
 			Value: args.Source.Spec.Vhost,
 		},
+		{
+			Name:  "RABBITMQ_CONTENT_TYPE",
+			Value: args.Source.Spec.ContentType,
+		},
+		{
+			Name:  "RABBITMQ_FORCE_WRAP",
+			Value: strconv.FormatBool(args.Source.Spec.ForceWrap),
+		},
+	}...)
+
+	if args.Source.Spec.Extensions != nil {
+		extensions, _ := json.Marshal(args.Source.Spec.Extensions)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_EXTENSIONS",
+			Value: string(extensions),
+		})
+	}
+	if args.Source.Spec.Attributes != nil {
+		attributes, _ := json.Marshal(args.Source.Spec.Attributes)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_ATTRIBUTES",
+			Value: string(attributes),
+		})
+	}
+	if args.Source.Spec.Reply != nil {
+		reply, _ := json.Marshal(args.Source.Spec.Reply)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_REPLY",
+			Value: string(reply),
+		})
+	}
+	if args.Source.Spec.Consumer != nil {
+		consumer, _ := json.Marshal(args.Source.Spec.Consumer)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_CONSUMER",
+			Value: string(consumer),
+		})
+	}
+	if args.Source.Spec.Connection != nil {
+		connection, _ := json.Marshal(args.Source.Spec.Connection)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_CONNECTION",
+			Value: string(connection),
+		})
+	}
+	if args.Source.Spec.CloudEventOverrides != nil {
+		overrides, _ := json.Marshal(args.Source.Spec.CloudEventOverrides)
+		env = append(env, corev1.EnvVar{
+			Name:  "K_CE_OVERRIDES",
+			Value: string(overrides),
+		})
+	}
+
+	if args.Source.Spec.QueueConfig.Type != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_QUEUE_CONFIG_TYPE",
+			Value: args.Source.Spec.QueueConfig.Type,
+		})
+	}
+	// The arguments are validated by the webhook.
+	if queueArgs, _ := args.Source.Spec.QueueConfig.DeclareArguments(); len(queueArgs) > 0 {
+		arguments, _ := json.Marshal(queueArgs)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_QUEUE_CONFIG_ARGUMENTS",
+			Value: string(arguments),
+		})
+	}
+	if len(args.Source.Spec.Bindings) > 0 {
+		bindings, _ := json.Marshal(args.Source.Spec.Bindings)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_BINDINGS",
+			Value: string(bindings),
+		})
+	}
+	if args.Source.Spec.TopologyMode != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_TOPOLOGY_MODE",
+			Value: args.Source.Spec.TopologyMode,
+		})
+	}
+	if args.Source.Spec.QueueConfig.Type == v1alpha1.QueueTypeStream {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_QUEUE_CONFIG_STREAM_OFFSET",
+			Value: args.Source.Spec.QueueConfig.StreamOffset,
+		}, corev1.EnvVar{
+			Name:  "RABBITMQ_OFFSET_CONFIGMAP",
+			Value: OffsetConfigMapName(args.Source.Name),
+		})
+	}
+
+	if args.Source.Spec.SASLMechanism != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_SASL_MECHANISM",
+			Value: args.Source.Spec.SASLMechanism,
+		})
+	}
+
+	tlsEnv, volumes, volumeMounts := TLSVolumes(connectionTLS(args))
+	env = append(env, tlsEnv...)
+
+	if args.Source.Spec.Delivery != nil {
+		delivery, _ := json.Marshal(args.Source.Spec.Delivery)
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_DELIVERY",
+			Value: string(delivery),
+		})
+		if args.DeadLetterSinkURI != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  "RABBITMQ_DEAD_LETTER_SINK_URI",
+				Value: args.DeadLetterSinkURI,
+			})
+		}
 	}
 
 	return &v1.Deployment{
@@ -161,6 +300,7 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 				MatchLabels: args.Labels,
 			},
 			Replicas: &replicas,
+			Strategy: deploymentStrategy(args.Source),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
@@ -170,12 +310,26 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: args.Source.Spec.ServiceAccountName,
+					Volumes:            volumes,
 					Containers: []corev1.Container{
 						{
 							Name:            "receive-adapter",
 							Image:           args.Image,
 							ImagePullPolicy: "IfNotPresent",
 							Env:             env,
+							VolumeMounts:    volumeMounts,
+							Ports: []corev1.ContainerPort{{
+								Name:          "health",
+								ContainerPort: HealthPort,
+							}},
+							ReadinessProbe: &corev1.Probe{
+								Handler: corev1.Handler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/healthz",
+										Port: intstr.FromString("health"),
+									},
+								},
+							},
 						},
 					},
 				},
@@ -183,3 +337,127 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) *v1.Deployment {
 		},
 	}
 }
+
+// deploymentStrategy returns how the receive adapter is rolled out when its
+// spec changes. Pods are rolled one after the other, unless consuming from
+// an exclusive queue or as an exclusive consumer, as queues can then have a
+// single consumer, or from a stream, whose offset is stored by a single
+// consumer, in which case they are recreated.
+func deploymentStrategy(src *v1alpha1.RabbitmqSource) v1.DeploymentStrategy {
+	exclusiveConsumer := src.Spec.Consumer != nil && src.Spec.Consumer.Exclusive
+	if src.Spec.QueueConfig.Exclusive || exclusiveConsumer || src.Spec.QueueConfig.Type == v1alpha1.QueueTypeStream {
+		return v1.DeploymentStrategy{Type: v1.RecreateDeploymentStrategyType}
+	}
+	return v1.DeploymentStrategy{Type: v1.RollingUpdateDeploymentStrategyType}
+}
+
+// brokersEnv returns the variable holding the brokers of the source, read
+// from the connection Secret if there's one.
+func brokersEnv(args *ReceiveAdapterArgs) corev1.EnvVar {
+	if args.ConnectionSecret == nil {
+		return corev1.EnvVar{
+			Name:  "RABBITMQ_BROKERS",
+			Value: args.Source.Spec.Brokers,
+		}
+	}
+	return corev1.EnvVar{
+		Name: "RABBITMQ_BROKERS",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: args.ConnectionSecret.Name},
+				Key:                  BrokerURLSecretKey,
+			},
+		},
+	}
+}
+
+// connectionTLS returns the TLS configuration of the source, verifying the
+// server with the CA bundle of the connection Secret unless the source has
+// its own.
+func connectionTLS(args *ReceiveAdapterArgs) *v1alpha1.RabbitmqSourceTLSSpec {
+	tls := args.Source.Spec.TLS
+	if args.ConnectionSecret == nil || len(args.ConnectionSecret.Data[CACertSecretKey]) == 0 {
+		return tls
+	}
+	if tls == nil {
+		tls = &v1alpha1.RabbitmqSourceTLSSpec{}
+	} else if tls.CACert != nil {
+		return tls
+	} else {
+		tls = tls.DeepCopy()
+	}
+	tls.CACert = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: args.ConnectionSecret.Name},
+		Key:                  CACertSecretKey,
+	}
+	return tls
+}
+
+// TLSVolumes returns the environment, volumes and mounts giving the receive
+// adapter, or the publisher of a RabbitmqSink, access to the certificates of
+// the TLS configuration.
+func TLSVolumes(tls *v1alpha1.RabbitmqSourceTLSSpec) ([]corev1.EnvVar, []corev1.Volume, []corev1.VolumeMount) {
+	if tls == nil {
+		return nil, nil, nil
+	}
+
+	env := []corev1.EnvVar{{
+		Name:  "RABBITMQ_TLS_ENABLED",
+		Value: "true",
+	}}
+	if tls.ServerName != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_TLS_SERVER_NAME",
+			Value: tls.ServerName,
+		})
+	}
+
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	if tls.CACert != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: caCertVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tls.CACert.Name,
+					Items: []corev1.KeyToPath{{
+						Key:  tls.CACert.Key,
+						Path: caCertFileName,
+					}},
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      caCertVolumeName,
+			MountPath: caCertMountPath,
+			ReadOnly:  true,
+		})
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_TLS_CA_FILE",
+			Value: path.Join(caCertMountPath, caCertFileName),
+		})
+	}
+	if tls.ClientCertSecret != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: clientCertVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tls.ClientCertSecret.Name,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      clientCertVolumeName,
+			MountPath: clientCertMountPath,
+			ReadOnly:  true,
+		})
+		env = append(env, corev1.EnvVar{
+			Name:  "RABBITMQ_TLS_CERT_FILE",
+			Value: path.Join(clientCertMountPath, corev1.TLSCertKey),
+		}, corev1.EnvVar{
+			Name:  "RABBITMQ_TLS_KEY_FILE",
+			Value: path.Join(clientCertMountPath, corev1.TLSPrivateKeyKey),
+		})
+	}
+	return env, volumes, mounts
+}
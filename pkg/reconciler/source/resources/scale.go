@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"time"
+
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+// Replicas returns the number of receive adapter pods of the source. Scaled
+// sources get enough pods for the messages ready in the queue when last
+// observed, and MinReplicas once the queue has had no message ready for the
+// cooldown period.
+func Replicas(src *v1alpha1.RabbitmqSource, now time.Time) int32 {
+	scale := src.Spec.Scale
+	if scale == nil {
+		if src.Spec.Replicas != nil {
+			return *src.Spec.Replicas
+		}
+		return 1
+	}
+
+	min := scale.GetMinReplicas()
+	active := min
+	if active < 1 {
+		active = 1
+	}
+	queue := src.Status.Queue
+	if queue == nil {
+		// Keep consuming while the state of the queue is unknown.
+		return active
+	}
+	if queue.MessagesReady == 0 {
+		if queue.LastActiveTime != nil && now.Sub(queue.LastActiveTime.Time) < scale.GetCooldownPeriod() {
+			return active
+		}
+		return min
+	}
+
+	perReplica := scale.GetMessagesPerReplica()
+	replicas := (queue.MessagesReady + perReplica - 1) / perReplica
+	if replicas < active {
+		return active
+	}
+	if replicas > scale.MaxReplicas {
+		return scale.MaxReplicas
+	}
+	return replicas
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+)
+
+func TestReplicas(t *testing.T) {
+	now := time.Now()
+	int32Ptr := func(i int32) *int32 { return &i }
+	scale := &v1alpha1.RabbitmqSourceScaleSpec{
+		MaxReplicas:        5,
+		MessagesPerReplica: int32Ptr(10),
+		CooldownPeriod:     &metav1.Duration{Duration: time.Minute},
+	}
+	queue := func(ready int32, lastActive time.Duration) *v1alpha1.RabbitmqSourceQueueStatus {
+		q := &v1alpha1.RabbitmqSourceQueueStatus{MessagesReady: ready}
+		if lastActive != 0 {
+			q.LastActiveTime = &metav1.Time{Time: now.Add(-lastActive)}
+		}
+		return q
+	}
+
+	testCases := map[string]struct {
+		spec   v1alpha1.RabbitmqSourceSpec
+		status v1alpha1.RabbitmqSourceStatus
+		want   int32
+	}{
+		"default": {
+			want: 1,
+		},
+		"replicas": {
+			spec: v1alpha1.RabbitmqSourceSpec{Replicas: int32Ptr(3)},
+			want: 3,
+		},
+		"scale without queue status": {
+			spec: v1alpha1.RabbitmqSourceSpec{Scale: scale},
+			want: 1,
+		},
+		"scale with messages ready": {
+			spec:   v1alpha1.RabbitmqSourceSpec{Scale: scale},
+			status: v1alpha1.RabbitmqSourceStatus{Queue: queue(21, 0)},
+			want:   3,
+		},
+		"scale to max replicas": {
+			spec:   v1alpha1.RabbitmqSourceSpec{Scale: scale},
+			status: v1alpha1.RabbitmqSourceStatus{Queue: queue(1000, 0)},
+			want:   5,
+		},
+		"scale during cooldown": {
+			spec:   v1alpha1.RabbitmqSourceSpec{Scale: scale},
+			status: v1alpha1.RabbitmqSourceStatus{Queue: queue(0, 30*time.Second)},
+			want:   1,
+		},
+		"scale to zero after cooldown": {
+			spec:   v1alpha1.RabbitmqSourceSpec{Scale: scale},
+			status: v1alpha1.RabbitmqSourceStatus{Queue: queue(0, 2*time.Minute)},
+			want:   0,
+		},
+		"scale to min replicas": {
+			spec: v1alpha1.RabbitmqSourceSpec{Scale: &v1alpha1.RabbitmqSourceScaleSpec{
+				MinReplicas: int32Ptr(2),
+				MaxReplicas: 5,
+			}},
+			status: v1alpha1.RabbitmqSourceStatus{Queue: queue(1, 0)},
+			want:   2,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			src := &v1alpha1.RabbitmqSource{Spec: tc.spec, Status: tc.status}
+			if got := Replicas(src, now); got != tc.want {
+				t.Errorf("Replicas() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/connection"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// BrokerURLSecretKey is the key of the URI of the brokers in the Secrets
+	// referenced by RabbitmqSources, the same as in the Secrets configuring
+	// Brokers.
+	BrokerURLSecretKey = connection.BrokerURLSecretKey
+	// CACertSecretKey is the key of the CA bundle of the RabbitmqCluster in
+	// the connection Secret.
+	CACertSecretKey = connection.CACertSecretKey
+)
+
+type ConnectionArgs struct {
+	Source *v1alpha1.RabbitmqSource
+	URL    *url.URL
+	// CACert is the PEM encoded CA bundle of the RabbitmqCluster, if any.
+	CACert []byte
+}
+
+// MakeConnectionSecret creates the Secret holding the connection resolved
+// from the ConnectionRef of the source, read by the receive adapter.
+func MakeConnectionSecret(args *ConnectionArgs) *corev1.Secret {
+	data := map[string][]byte{
+		BrokerURLSecretKey: []byte(args.URL.String()),
+	}
+	if len(args.CACert) > 0 {
+		data[CACertSecretKey] = args.CACert
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.Source.Namespace,
+			Name:      ConnectionSecretName(args.Source.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.Source),
+			},
+			Labels: GetLabels(args.Source.Name),
+		},
+		Data: data,
+	}
+}
+
+func ConnectionSecretName(sourceName string) string {
+	return kmeta.ChildName(sourceName, "-rabbitmq-connection")
+}
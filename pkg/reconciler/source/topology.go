@@ -0,0 +1,360 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	adapter "knative.dev/eventing-rabbitmq/pkg/adapter"
+	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/apis/sources/v1alpha1"
+	"knative.dev/eventing-rabbitmq/pkg/reconciler/source/resources"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// This file contains the logic observing the queue consumed by sources and
+// their connection to RabbitMQ, and checking the existing topology of sources
+// that don't declare it.
+
+const (
+	// topologyRecheckInterval is how long to wait before checking again for
+	// a missing queue or exchange, whose creation isn't observed otherwise.
+	topologyRecheckInterval = time.Minute
+	// defaultDialTimeout bounds the connection of the controller to RabbitMQ
+	// when the source doesn't set a timeout.
+	defaultDialTimeout = 10 * time.Second
+)
+
+// reconcileTopology checks, unless the receive adapter declares them, that
+// the queue and the named exchanges of the source exist, and observes the
+// state of its queue and of the connection to RabbitMQ, as reported by the
+// receive adapters or, until they do, probed by the controller. The messages
+// ready in the queue are only probed for scaled sources.
+func (r *Reconciler) reconcileTopology(ctx context.Context, src *v1alpha1.RabbitmqSource, connectionSecret *corev1.Secret) error {
+	adapters := r.adapterStatuses(ctx, src)
+
+	passive := false
+	switch src.Spec.TopologyMode {
+	case v1alpha1.TopologyModePassive, v1alpha1.TopologyModeNone:
+		passive = true
+	default:
+		markDeclaredTopology(src, adapters)
+	}
+
+	name := src.Spec.QueueConfig.Name
+	if name == "" {
+		name = adapterQueue(adapters)
+	}
+	if !passive && src.Spec.Scale == nil && len(adapters) > 0 {
+		markConnected(src, adapters)
+		src.Status.Queue = adapterQueueStatus(name, adapters)
+		return nil
+	}
+
+	conn, err := r.dialSource(ctx, src, connectionSecret)
+	if err != nil {
+		src.Status.Queue = nil
+		if len(adapters) == 0 {
+			src.Status.MarkNotConnected("ConnectionFailed", "Failed to connect to RabbitMQ: %v", err)
+		} else {
+			markConnected(src, adapters)
+		}
+		if !passive {
+			logging.FromContext(ctx).Warnw("Failed to connect to RabbitMQ to observe the queue", zap.Error(err))
+			return nil
+		}
+		src.Status.MarkTopologyNotReady("ConnectionFailed", "Failed to connect to RabbitMQ: %v", err)
+		return fmt.Errorf("connecting to RabbitMQ: %w", err)
+	}
+	defer conn.Close()
+	markConnected(src, adapters)
+
+	if passive {
+		exchanges := []string{}
+		if src.Spec.ExchangeConfig.Name != "" {
+			exchanges = append(exchanges, src.Spec.ExchangeConfig.Name)
+		}
+		for _, binding := range src.Spec.Bindings {
+			if binding.Exchange != "" {
+				exchanges = append(exchanges, binding.Exchange)
+			}
+		}
+		for _, exchange := range exchanges {
+			err := withChannel(conn, func(ch wabbit.Channel) error {
+				return ch.ExchangeDeclarePassive(exchange, src.Spec.ExchangeConfig.TypeOf, wabbit.Option{})
+			})
+			if err != nil {
+				src.Status.MarkTopologyNotReady("TopologyNotFound", "Exchange %q not found: %v", exchange, err)
+				return controller.NewRequeueAfter(topologyRecheckInterval)
+			}
+		}
+	}
+
+	// Exclusive queues can't be inspected from another connection, their
+	// state is only known from the receive adapter.
+	if !passive && (name == "" || src.Spec.QueueConfig.Exclusive) {
+		src.Status.Queue = adapterQueueStatus(name, adapters)
+		return nil
+	}
+
+	var queue wabbit.Queue
+	err = withChannel(conn, func(ch wabbit.Channel) error {
+		queue, err = ch.QueueDeclarePassive(name, wabbit.Option{})
+		return err
+	})
+	if err != nil {
+		src.Status.Queue = nil
+		if !passive {
+			// The receive adapter hasn't declared the queue yet.
+			return nil
+		}
+		src.Status.MarkTopologyNotReady("TopologyNotFound", "Queue %q not found: %v", name, err)
+		return controller.NewRequeueAfter(topologyRecheckInterval)
+	}
+	if passive {
+		src.Status.MarkTopologyReady()
+	}
+	observeQueue(src, queue, adapters, time.Now())
+	return nil
+}
+
+// markDeclaredTopology marks the topology declared by the receive adapters
+// ready unless one of them failed to declare it.
+func markDeclaredTopology(src *v1alpha1.RabbitmqSource, adapters []adapter.Status) {
+	for _, status := range adapters {
+		if status.TopologyError != "" {
+			src.Status.MarkTopologyNotReady("TopologyDeclarationFailed", "Failed to declare the topology: %s", status.TopologyError)
+			return
+		}
+	}
+	src.Status.MarkTopologyReady()
+}
+
+// markConnected marks the source connected to RabbitMQ unless one of its
+// receive adapters isn't, assuming the controller is connected.
+func markConnected(src *v1alpha1.RabbitmqSource, adapters []adapter.Status) {
+	for _, status := range adapters {
+		if !status.Connected {
+			src.Status.MarkNotConnected("AdapterNotConnected", "The receive adapter isn't connected to RabbitMQ: %s", status.Error)
+			return
+		}
+	}
+	src.Status.MarkConnected()
+}
+
+// adapterQueue returns the name of the queue consumed by the receive
+// adapters, which may be generated by the server.
+func adapterQueue(adapters []adapter.Status) string {
+	for _, status := range adapters {
+		if status.Queue != "" {
+			return status.Queue
+		}
+	}
+	return ""
+}
+
+// adapterQueueStatus returns the state of the queue as reported by the
+// receive adapters, nil if none consumes it.
+func adapterQueueStatus(name string, adapters []adapter.Status) *v1alpha1.RabbitmqSourceQueueStatus {
+	if name == "" {
+		return nil
+	}
+	status := &v1alpha1.RabbitmqSourceQueueStatus{Name: name}
+	for _, a := range adapters {
+		if a.Connected && a.Queue == name {
+			status.Consumers++
+			status.MessagesUnacknowledged += a.MessagesUnacknowledged
+		}
+	}
+	return status
+}
+
+// observeQueue records the state of the queue in the status of the source.
+func observeQueue(src *v1alpha1.RabbitmqSource, queue wabbit.Queue, adapters []adapter.Status, now time.Time) {
+	status := adapterQueueStatus(queue.Name(), adapters)
+	status.MessagesReady = int32(queue.Messages())
+	status.Consumers = int32(queue.Consumers())
+	if src.Status.Queue != nil {
+		status.LastActiveTime = src.Status.Queue.LastActiveTime
+	}
+	if status.MessagesReady > 0 {
+		status.LastActiveTime = &metav1.Time{Time: now}
+	}
+	src.Status.Queue = status
+}
+
+// withChannel calls f with a new channel, as RabbitMQ closes the channel on
+// which a passive declaration fails.
+func withChannel(conn wabbit.Conn, f func(wabbit.Channel) error) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	return f(ch)
+}
+
+// dialSource connects to RabbitMQ the way the receive adapter of the source
+// does, to the first of its nodes accepting the connection.
+func (r *Reconciler) dialSource(ctx context.Context, src *v1alpha1.RabbitmqSource, connectionSecret *corev1.Secret) (wabbit.Conn, error) {
+	urls, err := r.brokersURLs(ctx, src, connectionSecret)
+	if err != nil {
+		return nil, err
+	}
+	config := origamqp.Config{}
+	if config.TLSClientConfig, err = r.tlsConfig(ctx, src, connectionSecret); err != nil {
+		return nil, err
+	}
+	if config.SASL, err = dialer.SASL(src.Spec.SASLMechanism); err != nil {
+		return nil, err
+	}
+	opts := connectionOptions(src.Spec.Connection)
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultDialTimeout
+	}
+	opts.Apply(&config)
+	conn, _, err := dialer.DialNodes(r.dialerFunc(config), urls)
+	return conn, err
+}
+
+// brokersURLs returns the URL of each node of the RabbitMQ server of the
+// source, including its credentials.
+func (r *Reconciler) brokersURLs(ctx context.Context, src *v1alpha1.RabbitmqSource, connectionSecret *corev1.Secret) ([]string, error) {
+	brokers := dialer.Brokers{
+		URI:   src.Spec.Brokers,
+		Vhost: src.Spec.Vhost,
+		TLS:   src.Spec.TLS != nil,
+	}
+	if c := src.Spec.Connection; c != nil {
+		brokers.Nodes = c.Nodes
+	}
+
+	if connectionSecret != nil {
+		// The URL of the Secret has the credentials and vhost.
+		brokers.URI = string(connectionSecret.Data[resources.BrokerURLSecretKey])
+		brokers.Vhost = ""
+	} else {
+		var err error
+		if brokers.User, err = r.secretValue(ctx, src.Namespace, src.Spec.User.SecretKeyRef); err != nil {
+			return nil, err
+		}
+		if brokers.Password, err = r.secretValue(ctx, src.Namespace, src.Spec.Password.SecretKeyRef); err != nil {
+			return nil, err
+		}
+	}
+
+	urls, err := brokers.URLs()
+	if err != nil {
+		return nil, err
+	}
+	if c := src.Spec.Connection; c != nil && c.NodeOrder == v1alpha1.NodeOrderRandom {
+		urls = dialer.Shuffle(urls)
+	}
+	return urls, nil
+}
+
+// connectionOptions returns the options of the connections of the source.
+func connectionOptions(c *v1alpha1.RabbitmqSourceConnectionSpec) dialer.ConnectionOptions {
+	if c == nil {
+		return dialer.ConnectionOptions{}
+	}
+	opts := dialer.ConnectionOptions{
+		ChannelMax: int(c.ChannelMax),
+		Name:       c.Name,
+	}
+	if c.Heartbeat != nil {
+		opts.Heartbeat = c.Heartbeat.Duration
+	}
+	if c.Timeout != nil {
+		opts.Timeout = c.Timeout.Duration
+	}
+	return opts
+}
+
+// tlsConfig returns the TLS configuration of the source, verifying the server
+// with the CA bundle of the connection Secret unless the source has its own.
+func (r *Reconciler) tlsConfig(ctx context.Context, src *v1alpha1.RabbitmqSource, connectionSecret *corev1.Secret) (*tls.Config, error) {
+	var caCert []byte
+	if connectionSecret != nil {
+		caCert = connectionSecret.Data[resources.CACertSecretKey]
+	}
+	spec := src.Spec.TLS
+	if spec == nil && len(caCert) == 0 {
+		return nil, nil
+	}
+	if spec == nil {
+		spec = &v1alpha1.RabbitmqSourceTLSSpec{}
+	}
+
+	config := &tls.Config{
+		ServerName: spec.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if spec.CACert != nil {
+		ca, err := r.secretValue(ctx, src.Namespace, spec.CACert)
+		if err != nil {
+			return nil, err
+		}
+		caCert = []byte(ca)
+	}
+	if len(caCert) > 0 {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificate found in the CA bundle")
+		}
+	}
+	if spec.ClientCertSecret != nil {
+		s, err := r.secretLister.Secrets(src.Namespace).Get(spec.ClientCertSecret.Name)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(s.Data[corev1.TLSCertKey], s.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// secretValue returns the value of the selected Secret key, empty if there's
+// no selector.
+func (r *Reconciler) secretValue(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+	s, err := r.secretLister.Secrets(namespace).Get(selector.Name)
+	if err != nil {
+		return "", err
+	}
+	val, ok := s.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q missing key %s", selector.Name, selector.Key)
+	}
+	return string(val), nil
+}
@@ -45,6 +45,11 @@ import (
 	"knative.dev/pkg/reconciler/testing"
 
 	rabbitclusterv1beta1 "github.com/rabbitmq/cluster-operator/api/v1beta1"
+	rabbitmqeventingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	rabbitmqmessagingv1alpha1 "knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	fakerabbitmqclientset "knative.dev/eventing-rabbitmq/pkg/client/clientset/versioned/fake"
+	rabbitmqeventinglisters "knative.dev/eventing-rabbitmq/pkg/client/listers/eventing/v1alpha1"
+	rabbitmqmessaginglisters "knative.dev/eventing-rabbitmq/pkg/client/listers/messaging/v1alpha1"
 	rabbitv1beta1 "knative.dev/eventing-rabbitmq/third_party/pkg/apis/rabbitmq.com/v1beta1"
 	fakerabbitclientset "knative.dev/eventing-rabbitmq/third_party/pkg/client/clientset/versioned/fake"
 	rabbitlisters "knative.dev/eventing-rabbitmq/third_party/pkg/client/listers/rabbitmq.com/v1beta1"
@@ -69,6 +74,7 @@ var clientSetSchemes = []func(*runtime.Scheme) error{
 	eventingduck.AddToScheme,
 	fakerabbitclientset.AddToScheme,
 	rabbitClusterSchemeBuilder.AddToScheme,
+	fakerabbitmqclientset.AddToScheme,
 }
 
 var rabbitClusterSchemeBuilder = runtime.SchemeBuilder{
@@ -125,6 +131,7 @@ func (l *Listers) GetAllObjects() []runtime.Object {
 	all = append(all, l.GetEventingObjects()...)
 	all = append(all, l.GetKubeObjects()...)
 	all = append(all, l.GetRabbitObjects()...)
+	all = append(all, l.GetRabbitmqObjects()...)
 	return all
 }
 
@@ -221,3 +228,15 @@ func (l *Listers) GetQueueLister() rabbitlisters.QueueLister {
 func (l *Listers) GetBindingLister() rabbitlisters.BindingLister {
 	return rabbitlisters.NewBindingLister(l.indexerFor(&rabbitv1beta1.Binding{}))
 }
+
+func (l *Listers) GetRabbitmqObjects() []runtime.Object {
+	return l.sorter.ObjectsForSchemeFunc(fakerabbitmqclientset.AddToScheme)
+}
+
+func (l *Listers) GetRabbitmqSinkLister() rabbitmqeventinglisters.RabbitmqSinkLister {
+	return rabbitmqeventinglisters.NewRabbitmqSinkLister(l.indexerFor(&rabbitmqeventingv1alpha1.RabbitmqSink{}))
+}
+
+func (l *Listers) GetRabbitmqChannelLister() rabbitmqmessaginglisters.RabbitmqChannelLister {
+	return rabbitmqmessaginglisters.NewRabbitmqChannelLister(l.indexerFor(&rabbitmqmessagingv1alpha1.RabbitmqChannel{}))
+}
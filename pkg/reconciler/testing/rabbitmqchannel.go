@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-rabbitmq/pkg/apis/messaging/v1alpha1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+)
+
+// RabbitmqChannelOption enables further configuration of a RabbitmqChannel.
+type RabbitmqChannelOption func(*v1alpha1.RabbitmqChannel)
+
+// NewRabbitmqChannel creates a RabbitmqChannel with RabbitmqChannelOptions.
+func NewRabbitmqChannel(name, namespace string, o ...RabbitmqChannelOption) *v1alpha1.RabbitmqChannel {
+	c := &v1alpha1.RabbitmqChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	for _, opt := range o {
+		opt(c)
+	}
+	c.SetDefaults(context.Background())
+	return c
+}
+
+// WithRabbitmqChannelUID sets the UID of the RabbitmqChannel. Handy for
+// testing ownerrefs.
+func WithRabbitmqChannelUID(uid string) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.UID = types.UID(uid)
+	}
+}
+
+func WithRabbitmqChannelFinalizers(finalizers ...string) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Finalizers = finalizers
+	}
+}
+
+func WithRabbitmqChannelDeletionTimestamp(c *v1alpha1.RabbitmqChannel) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	c.ObjectMeta.SetDeletionTimestamp(&t)
+}
+
+// WithRabbitmqChannelURI connects the RabbitmqChannel to the RabbitMQ at
+// uri.
+func WithRabbitmqChannelURI(uri string) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Spec.Connection.URI = uri
+	}
+}
+
+// WithRabbitmqChannelCredentials connects the RabbitmqChannel with the user
+// and password keys of the Secret.
+func WithRabbitmqChannelCredentials(secretName string) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Spec.Connection.Credentials = credentials(secretName)
+	}
+}
+
+// WithRabbitmqChannelSubscribers sets the subscribers of the
+// RabbitmqChannel.
+func WithRabbitmqChannelSubscribers(subs ...eventingduckv1.SubscriberSpec) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Spec.Subscribers = subs
+	}
+}
+
+// WithRabbitmqChannelSubscriberStatuses sets the statuses of the subscribers
+// of the RabbitmqChannel, which are empty rather than nil once reconciled.
+func WithRabbitmqChannelSubscriberStatuses(statuses ...eventingduckv1.SubscriberStatus) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		if statuses == nil {
+			statuses = []eventingduckv1.SubscriberStatus{}
+		}
+		c.Status.Subscribers = statuses
+	}
+}
+
+// WithInitRabbitmqChannelConditions initializes the RabbitmqChannel's
+// conditions.
+func WithInitRabbitmqChannelConditions(c *v1alpha1.RabbitmqChannel) {
+	c.Status.InitializeConditions()
+}
+
+// WithRabbitmqChannelConnectionReady calls .Status.MarkConnectionReady on
+// the RabbitmqChannel.
+func WithRabbitmqChannelConnectionReady(c *v1alpha1.RabbitmqChannel) {
+	c.Status.MarkConnectionReady()
+}
+
+// WithRabbitmqChannelConnectionNotReady calls .Status.MarkConnectionNotReady
+// on the RabbitmqChannel.
+func WithRabbitmqChannelConnectionNotReady(reason, messageFormat string, messageA ...interface{}) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Status.MarkConnectionNotReady(reason, messageFormat, messageA...)
+	}
+}
+
+// WithRabbitmqChannelExchangeReady calls .Status.MarkExchangeReady on the
+// RabbitmqChannel.
+func WithRabbitmqChannelExchangeReady(c *v1alpha1.RabbitmqChannel) {
+	c.Status.MarkExchangeReady()
+}
+
+// WithRabbitmqChannelIngressDeployed calls .Status.MarkIngressDeployed on
+// the RabbitmqChannel.
+func WithRabbitmqChannelIngressDeployed(d *appsv1.Deployment) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Status.MarkIngressDeployed(d)
+	}
+}
+
+// WithRabbitmqChannelIngressFailed calls .Status.MarkIngressFailed on the
+// RabbitmqChannel.
+func WithRabbitmqChannelIngressFailed(reason, messageFormat string, messageA ...interface{}) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Status.MarkIngressFailed(reason, messageFormat, messageA...)
+	}
+}
+
+// WithRabbitmqChannelAddress calls .Status.MarkAddress on the
+// RabbitmqChannel.
+func WithRabbitmqChannelAddress(url *apis.URL, ep *corev1.Endpoints) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Status.MarkAddress(url, ep)
+	}
+}
+
+// WithRabbitmqChannelNoAddress calls .Status.MarkNoAddress on the
+// RabbitmqChannel.
+func WithRabbitmqChannelNoAddress(reason, messageFormat string, messageA ...interface{}) RabbitmqChannelOption {
+	return func(c *v1alpha1.RabbitmqChannel) {
+		c.Status.MarkNoAddress(reason, messageFormat, messageA...)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+	sourcesv1beta1 "knative.dev/eventing-rabbitmq/pkg/apis/sources/v1beta1"
+	"knative.dev/pkg/apis"
+)
+
+// RabbitmqSinkOption enables further configuration of a RabbitmqSink.
+type RabbitmqSinkOption func(*v1alpha1.RabbitmqSink)
+
+// NewRabbitmqSink creates a RabbitmqSink with RabbitmqSinkOptions.
+func NewRabbitmqSink(name, namespace string, o ...RabbitmqSinkOption) *v1alpha1.RabbitmqSink {
+	s := &v1alpha1.RabbitmqSink{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	for _, opt := range o {
+		opt(s)
+	}
+	s.SetDefaults(context.Background())
+	return s
+}
+
+// WithRabbitmqSinkUID sets the UID of the RabbitmqSink. Handy for testing
+// ownerrefs.
+func WithRabbitmqSinkUID(uid string) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.UID = types.UID(uid)
+	}
+}
+
+func WithRabbitmqSinkDeletionTimestamp(s *v1alpha1.RabbitmqSink) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	s.ObjectMeta.SetDeletionTimestamp(&t)
+}
+
+// WithRabbitmqSinkURI connects the RabbitmqSink to the RabbitMQ at uri.
+func WithRabbitmqSinkURI(uri string) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Spec.Connection.URI = uri
+	}
+}
+
+// WithRabbitmqSinkCredentials connects the RabbitmqSink with the user and
+// password keys of the Secret.
+func WithRabbitmqSinkCredentials(secretName string) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Spec.Connection.Credentials = credentials(secretName)
+	}
+}
+
+// WithRabbitmqSinkExchange sets the exchange and routing key the events are
+// published with.
+func WithRabbitmqSinkExchange(exchange, routingKey string) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Spec.Exchange = exchange
+		s.Spec.RoutingKey = routingKey
+	}
+}
+
+// WithInitRabbitmqSinkConditions initializes the RabbitmqSink's conditions.
+func WithInitRabbitmqSinkConditions(s *v1alpha1.RabbitmqSink) {
+	s.Status.InitializeConditions()
+}
+
+// WithRabbitmqSinkConnectionReady calls .Status.MarkConnectionReady on the
+// RabbitmqSink.
+func WithRabbitmqSinkConnectionReady(s *v1alpha1.RabbitmqSink) {
+	s.Status.MarkConnectionReady()
+}
+
+// WithRabbitmqSinkConnectionNotReady calls .Status.MarkConnectionNotReady on
+// the RabbitmqSink.
+func WithRabbitmqSinkConnectionNotReady(reason, messageFormat string, messageA ...interface{}) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Status.MarkConnectionNotReady(reason, messageFormat, messageA...)
+	}
+}
+
+// WithRabbitmqSinkDeployed calls .Status.MarkDeployed on the RabbitmqSink.
+func WithRabbitmqSinkDeployed(d *appsv1.Deployment) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Status.MarkDeployed(d)
+	}
+}
+
+// WithRabbitmqSinkNotDeployed calls .Status.MarkNotDeployed on the
+// RabbitmqSink.
+func WithRabbitmqSinkNotDeployed(reason, messageFormat string, messageA ...interface{}) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Status.MarkNotDeployed(reason, messageFormat, messageA...)
+	}
+}
+
+// WithRabbitmqSinkAddress calls .Status.MarkAddress on the RabbitmqSink.
+func WithRabbitmqSinkAddress(url *apis.URL, ep *corev1.Endpoints) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Status.MarkAddress(url, ep)
+	}
+}
+
+// WithRabbitmqSinkNoAddress calls .Status.MarkNoAddress on the RabbitmqSink.
+func WithRabbitmqSinkNoAddress(reason, messageFormat string, messageA ...interface{}) RabbitmqSinkOption {
+	return func(s *v1alpha1.RabbitmqSink) {
+		s.Status.MarkNoAddress(reason, messageFormat, messageA...)
+	}
+}
+
+// credentials returns the credentials of a connection in the user and
+// password keys of the Secret.
+func credentials(secretName string) *sourcesv1beta1.RabbitmqSourceCredentialsSpec {
+	return &sourcesv1beta1.RabbitmqSourceCredentialsSpec{
+		User: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  "user",
+		},
+		Password: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  "password",
+		},
+	}
+}
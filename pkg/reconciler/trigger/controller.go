@@ -20,6 +20,7 @@ import (
 	"context"
 	"log"
 
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	rabbitmqclient "knative.dev/eventing-rabbitmq/third_party/pkg/client/injection/client"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -86,6 +87,7 @@ func NewController(
 		brokerLister:                 brokerInformer.Lister(),
 		triggerLister:                triggerInformer.Lister(),
 		dispatcherImage:              env.DispatcherImage,
+		podTemplates:                 podtemplate.NewStore(logging.FromContext(ctx)),
 		dispatcherServiceAccountName: env.DispatcherServiceAccount,
 		brokerClass:                  env.BrokerClass,
 		exchangeLister:               exchangeInformer.Lister(),
@@ -151,5 +153,8 @@ func NewController(
 			}
 		},
 	))
+	r.podTemplates.WatchConfigMap(cmw, func() {
+		impl.GlobalResync(triggerInformer.Informer())
+	})
 	return impl
 }
@@ -34,6 +34,7 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/logging"
 
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/trigger/resources"
 	rabbitclientset "knative.dev/eventing-rabbitmq/third_party/pkg/client/clientset/versioned"
@@ -69,6 +70,10 @@ type Reconciler struct {
 	dispatcherImage              string
 	dispatcherServiceAccountName string
 
+	// Pod template overlays of the ConfigMap, applied to the dispatchers
+	// before the ones of the Broker annotations.
+	podTemplates *podtemplate.Store
+
 	brokerClass string
 
 	// Dynamic tracker to track KResources. In particular, it tracks the dependency between Triggers and Sources.
@@ -286,7 +291,8 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, d *v1.Deployment)
 		return d, nil
 	} else if err != nil {
 		return nil, err
-	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) {
+	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) ||
+		podtemplate.HashChanged(&current.Spec.Template, &d.Spec.Template) {
 		// Don't modify the informers copy.
 		desired := current.DeepCopy()
 		desired.Spec = d.Spec
@@ -309,6 +315,11 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, t *event
 	if err != nil {
 		return nil, err
 	}
+	// Same exchange as the Trigger queue's x-dead-letter-exchange.
+	dlxName := naming.BrokerExchangeName(b, true)
+	if t.Spec.Delivery != nil && t.Spec.Delivery.DeadLetterSink != nil {
+		dlxName = naming.TriggerDLXExchangeName(t)
+	}
 	expected := resources.MakeDispatcherDeployment(&resources.DispatcherArgs{
 		Trigger:            t,
 		Image:              r.dispatcherImage,
@@ -318,11 +329,15 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, t *event
 		BrokerIngressURL:   b.Status.Address.URL,
 		Subscriber:         sub,
 		Delivery:           delivery,
+		DLXName:            dlxName,
 	})
+	if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.DispatcherKey, b, podtemplate.DispatcherAnnotationKey); err != nil {
+		return nil, err
+	}
 	return r.reconcileDeployment(ctx, expected)
 }
 
-// reconcileDispatcherDeployment reconciles Trigger's dispatcher deployment.
+// reconcileDLXDispatcherDeployment reconciles Trigger's DLQ dispatcher deployment.
 func (r *Reconciler) reconcileDLXDispatcherDeployment(ctx context.Context, t *eventingv1.Trigger, sub *apis.URL) (*v1.Deployment, error) {
 	rabbitmqSecret, err := r.getRabbitmqSecret(ctx, t)
 	if err != nil {
@@ -341,7 +356,11 @@ func (r *Reconciler) reconcileDLXDispatcherDeployment(ctx context.Context, t *ev
 		BrokerIngressURL:   b.Status.Address.URL,
 		Subscriber:         sub,
 		DLX:                true,
+		// No DLXName, see DispatcherArgs.DLXName.
 	})
+	if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.DispatcherKey, b, podtemplate.DispatcherAnnotationKey); err != nil {
+		return nil, err
+	}
 	return r.reconcileDeployment(ctx, expected)
 }
 
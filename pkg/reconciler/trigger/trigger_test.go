@@ -941,6 +941,7 @@ func createDispatcherDeployment() *appsv1.Deployment {
 		BrokerUrlSecretKey: "brokerURL",
 		BrokerIngressURL:   brokerAddress,
 		Subscriber:         subscriberAddress,
+		DLXName:            naming.BrokerExchangeName(ReadyBroker(), true),
 	}
 	return resources.MakeDispatcherDeployment(args)
 }
@@ -963,6 +964,7 @@ func createDifferentDispatcherDeployment() *appsv1.Deployment {
 		BrokerUrlSecretKey: "brokerURL",
 		BrokerIngressURL:   brokerAddress,
 		Subscriber:         subscriberAddress,
+		DLXName:            naming.BrokerExchangeName(ReadyBroker(), true),
 	}
 	return resources.MakeDispatcherDeployment(args)
 }
@@ -32,6 +32,7 @@ import (
 	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
 
 	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
 	triggerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
 	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
@@ -81,6 +82,7 @@ func NewController(
 		brokerLister:                 brokerInformer.Lister(),
 		triggerLister:                triggerInformer.Lister(),
 		dispatcherImage:              env.DispatcherImage,
+		podTemplates:                 podtemplate.NewStore(logging.FromContext(ctx)),
 		dispatcherServiceAccountName: env.DispatcherServiceAccount,
 		brokerClass:                  env.BrokerClass,
 		dialerFunc:                   dialer.RealDialer,
@@ -133,5 +135,8 @@ func NewController(
 			}
 		},
 	))
+	r.podTemplates.WatchConfigMap(cmw, func() {
+		impl.GlobalResync(triggerInformer.Informer())
+	})
 	return impl
 }
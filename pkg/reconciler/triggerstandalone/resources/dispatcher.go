@@ -50,6 +50,12 @@ type DispatcherArgs struct {
 	BrokerIngressURL   *apis.URL
 	Subscriber         *apis.URL
 	DLX                bool
+	// DLXName is the exchange messages that can't be converted into
+	// CloudEvents are dead-lettered to. It is left empty for the dispatchers
+	// of dead letter queues: their queue is the one bound to the DLX, so
+	// dead-lettering would route these messages back to it over and over.
+	// They are NACK-ed without requeueing, which drops them, instead.
+	DLXName string
 }
 
 // MakeDispatcherDeployment creates the in-memory representation of the Broker's Dispatcher Deployment.
@@ -148,6 +154,13 @@ func MakeDispatcherDeployment(args *DispatcherArgs) *appsv1.Deployment {
 				Value: "false",
 			})
 	}
+	if args.DLXName != "" {
+		d.Spec.Template.Spec.Containers[0].Env = append(d.Spec.Template.Spec.Containers[0].Env,
+			corev1.EnvVar{
+				Name:  "DLX_NAME",
+				Value: args.DLXName,
+			})
+	}
 	return d
 }
 
@@ -37,6 +37,7 @@ import (
 	"knative.dev/pkg/logging"
 
 	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
+	"knative.dev/eventing-rabbitmq/pkg/podtemplate"
 	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/triggerstandalone/resources"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
@@ -66,6 +67,10 @@ type Reconciler struct {
 	dispatcherImage              string
 	dispatcherServiceAccountName string
 
+	// Pod template overlays of the ConfigMap, applied to the dispatchers
+	// before the ones of the Broker annotations.
+	podTemplates *podtemplate.Store
+
 	brokerClass string
 
 	// Dynamic tracker to track KResources. In particular, it tracks the dependency between Triggers and Sources.
@@ -281,7 +286,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) p
 		// If trigger didn't but Broker did, use it instead.
 		delivery = broker.Spec.Delivery
 	}
-	_, err = r.reconcileDispatcherDeployment(ctx, broker, t, secretName, subscriberURI, delivery)
+	_, err = r.reconcileDispatcherDeployment(ctx, broker, t, secretName, subscriberURI, delivery, dlxExchange)
 	if err != nil {
 		logging.FromContext(ctx).Error("Problem reconciling dispatcher Deployment", zap.Error(err))
 		t.Status.MarkDependencyFailed("DeploymentFailure", "%v", err)
@@ -350,7 +355,8 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, d *v1.Deployment)
 		return d, nil
 	} else if err != nil {
 		return nil, err
-	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) {
+	} else if !equality.Semantic.DeepDerivative(d.Spec.Template, current.Spec.Template) ||
+		podtemplate.HashChanged(&current.Spec.Template, &d.Spec.Template) {
 		// Don't modify the informers copy.
 		desired := current.DeepCopy()
 		desired.Spec = d.Spec
@@ -364,7 +370,7 @@ func (r *Reconciler) reconcileDeployment(ctx context.Context, d *v1.Deployment)
 }
 
 // reconcileDispatcherDeployment reconciles Trigger's dispatcher deployment.
-func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger, secretName string, sub *apis.URL, delivery *eventingduckv1.DeliverySpec) (*v1.Deployment, error) {
+func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger, secretName string, sub *apis.URL, delivery *eventingduckv1.DeliverySpec, dlxName string) (*v1.Deployment, error) {
 	expected := resources.MakeDispatcherDeployment(&resources.DispatcherArgs{
 		Trigger:            t,
 		Image:              r.dispatcherImage,
@@ -374,7 +380,11 @@ func (r *Reconciler) reconcileDispatcherDeployment(ctx context.Context, b *event
 		BrokerIngressURL:   b.Status.Address.URL,
 		Subscriber:         sub,
 		Delivery:           delivery,
+		DLXName:            dlxName,
 	})
+	if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.DispatcherKey, b, podtemplate.DispatcherAnnotationKey); err != nil {
+		return nil, err
+	}
 	return r.reconcileDeployment(ctx, expected)
 }
 
@@ -389,7 +399,11 @@ func (r *Reconciler) reconcileDLXDispatcherDeployment(ctx context.Context, b *ev
 		BrokerIngressURL:   b.Status.Address.URL,
 		Subscriber:         sub,
 		DLX:                true,
+		// No DLXName, see DispatcherArgs.DLXName.
 	})
+	if err := r.podTemplates.ApplyWithAnnotation(&expected.Spec.Template, podtemplate.DispatcherKey, b, podtemplate.DispatcherAnnotationKey); err != nil {
+		return nil, err
+	}
 	return r.reconcileDeployment(ctx, expected)
 }
 
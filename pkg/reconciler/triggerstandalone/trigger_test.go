@@ -40,6 +40,7 @@ import (
 	clientgotesting "k8s.io/client-go/testing"
 	dialer "knative.dev/eventing-rabbitmq/pkg/amqp"
 	rabbitduck "knative.dev/eventing-rabbitmq/pkg/client/injection/ducks/duck/v1beta1/rabbit"
+	naming "knative.dev/eventing-rabbitmq/pkg/rabbitmqnaming"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/broker"
 	"knative.dev/eventing-rabbitmq/pkg/reconciler/triggerstandalone/resources"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
@@ -928,6 +929,7 @@ func createDispatcherDeployment() *appsv1.Deployment {
 		BrokerUrlSecretKey: "brokerURL",
 		BrokerIngressURL:   brokerAddress,
 		Subscriber:         subscriberAddress,
+		DLXName:            naming.BrokerExchangeName(ReadyBroker(), true),
 	}
 	return resources.MakeDispatcherDeployment(args)
 }
@@ -950,6 +952,7 @@ func createDifferentDispatcherDeployment() *appsv1.Deployment {
 		BrokerUrlSecretKey: "brokerURL",
 		BrokerIngressURL:   brokerAddress,
 		Subscriber:         subscriberAddress,
+		DLXName:            naming.BrokerExchangeName(ReadyBroker(), true),
 	}
 	return resources.MakeDispatcherDeployment(args)
 }
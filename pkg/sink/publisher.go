@@ -0,0 +1,344 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sink implements the data plane of RabbitmqSinks, publishing the
+// CloudEvents received over HTTP to a RabbitMQ exchange.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"text/template"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqp"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	adapter "knative.dev/eventing-rabbitmq/pkg/adapter"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+)
+
+const defaultConfirmsBufferSize = 1000
+
+// HealthPath is the path of the readiness probe of the publisher, ready as
+// long as its channel is open.
+const HealthPath = "/healthz"
+
+var (
+	errNotConfirmed = errors.New("message was not confirmed")
+	errReturned     = errors.New("message was returned as unroutable")
+)
+
+// returnNotifier is implemented by the channels notifying of the mandatory
+// messages returned by RabbitMQ, like the amqp ones.
+type returnNotifier interface {
+	NotifyReturn(chan origamqp.Return) chan origamqp.Return
+}
+
+// Publisher is the HTTP handler publishing the CloudEvents it receives to an
+// exchange, answering once RabbitMQ confirmed them. Messages are published as
+// mandatory, failing the requests of the ones that RabbitMQ couldn't route to
+// any queue.
+type Publisher struct {
+	exchange    string
+	routingKey  *template.Template
+	contentMode string
+	channel     wabbit.Channel
+	logger      *zap.SugaredLogger
+
+	// mu serializes publishing so that delivery tags can be matched with the
+	// publisher confirms sent back by RabbitMQ.
+	mu          sync.Mutex
+	deliveryTag uint64
+	pending     map[uint64]*pendingMessage
+	// closed is true once the channel is closed.
+	closed bool
+}
+
+// pendingMessage is a message published and waiting for its confirm.
+type pendingMessage struct {
+	messageID  string
+	routingKey string
+	// returned is true once RabbitMQ returned the message.
+	returned bool
+	// confirmed receives the outcome of the publishing, and is closed if
+	// the channel is closed first.
+	confirmed chan error
+}
+
+// NewPublisher puts ch in confirm mode and returns the Publisher publishing
+// on it to exchange, with the routing key template and content mode of a
+// RabbitmqSink.
+func NewPublisher(ch wabbit.Channel, exchange, routingKey, contentMode string, logger *zap.SugaredLogger) (*Publisher, error) {
+	tmpl, err := v1alpha1.ParseRoutingKeyTemplate(routingKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid routing key: %w", err)
+	}
+	p := &Publisher{
+		exchange:    exchange,
+		routingKey:  tmpl,
+		contentMode: contentMode,
+		channel:     ch,
+		logger:      logger,
+		pending:     make(map[uint64]*pendingMessage),
+	}
+
+	confirms := ch.NotifyPublish(make(chan wabbit.Confirmation, defaultConfirmsBufferSize))
+	if err := ch.Confirm(false); err != nil {
+		return nil, fmt.Errorf("failed to put channel in confirm mode: %w", err)
+	}
+	var returns chan origamqp.Return
+	if rn, ok := ch.(returnNotifier); ok {
+		// RabbitMQ returns a message before confirming it: without a buffer
+		// the return is handled before the confirm.
+		returns = rn.NotifyReturn(make(chan origamqp.Return))
+	}
+	go p.handleConfirms(confirms, returns)
+	return p, nil
+}
+
+func (p *Publisher) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method == http.MethodGet && request.URL.Path == HealthPath {
+		p.serveHealth(writer)
+		return
+	}
+	if request.Method != http.MethodPost {
+		p.logger.Warnw("unexpected request method", zap.String("method", request.Method))
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := request.Context()
+
+	message := cehttp.NewMessageFromHttpRequest(request)
+	defer message.Finish(nil)
+
+	event, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		p.logger.Warnw("failed to extract event from request", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := event.Validate(); err != nil {
+		p.logger.Warnw("failed to validate extracted event", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	routingKey, err := p.eventRoutingKey(event)
+	if err != nil {
+		p.logger.Warnw("failed to compute the routing key of the event", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	msg, err := p.eventMessage(event)
+	if err != nil {
+		p.logger.Warnw("failed to encode the event", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := p.publish(ctx, routingKey, msg); err != nil {
+		p.logger.Errorw("failed to publish the event", zap.Error(err))
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusAccepted)
+}
+
+// serveHealth answers the readiness probe, failing once the channel is
+// closed.
+func (p *Publisher) serveHealth(writer http.ResponseWriter) {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+// eventRoutingKey executes the routing key template on the attributes and
+// extensions of event.
+func (p *Publisher) eventRoutingKey(event *cloudevents.Event) (string, error) {
+	data := map[string]string{}
+	if version := spec.VS.Version(event.SpecVersion()); version != nil {
+		for _, attr := range version.Attributes() {
+			if v := attr.Get(event.Context); v != nil {
+				if s, err := types.Format(v); err == nil {
+					data[attr.Name()] = s
+				}
+			}
+		}
+	}
+	for name, v := range event.Extensions() {
+		if s, err := types.Format(v); err == nil {
+			data[name] = s
+		}
+	}
+
+	var key bytes.Buffer
+	if err := p.routingKey.Execute(&key, data); err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+// eventMessage encodes event in the content mode of the sink.
+func (p *Publisher) eventMessage(event *cloudevents.Event) (origamqp.Publishing, error) {
+	if p.contentMode != v1alpha1.ContentModeStructured {
+		return adapter.BinaryMessage(event), nil
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return origamqp.Publishing{}, err
+	}
+	return origamqp.Publishing{
+		ContentType:  cloudevents.ApplicationCloudEventsJSON,
+		DeliveryMode: origamqp.Persistent,
+		MessageId:    event.ID(),
+		Body:         body,
+	}, nil
+}
+
+// publish publishes msg and waits for RabbitMQ to confirm it.
+func (p *Publisher) publish(ctx context.Context, routingKey string, msg origamqp.Publishing) error {
+	p.mu.Lock()
+	var err error
+	if c, ok := p.channel.(*amqp.Channel); ok {
+		err = c.Channel.Publish(p.exchange, routingKey, true, false, msg)
+	} else {
+		// Other channels don't support every message property.
+		err = p.channel.Publish(p.exchange, routingKey, msg.Body, wabbit.Option{
+			"headers":      msg.Headers,
+			"contentType":  msg.ContentType,
+			"messageId":    msg.MessageId,
+			"deliveryMode": msg.DeliveryMode,
+		})
+	}
+	if err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	p.deliveryTag++
+	confirmed := make(chan error, 1)
+	p.pending[p.deliveryTag] = &pendingMessage{
+		messageID:  msg.MessageId,
+		routingKey: routingKey,
+		confirmed:  confirmed,
+	}
+	p.mu.Unlock()
+
+	select {
+	case err, ok := <-confirmed:
+		if !ok {
+			return errNotConfirmed
+		}
+		if err != nil {
+			return err
+		}
+	case <-ctx.Done():
+		return fmt.Errorf("gave up waiting for message confirmation: %w", ctx.Err())
+	}
+	return nil
+}
+
+// handleConfirms hands the publisher confirms, and the returns preceding
+// them, over to the requests waiting for them. Once the channel is closed any
+// requests still waiting are failed and the publisher is no longer ready.
+func (p *Publisher) handleConfirms(confirms <-chan wabbit.Confirmation, returns <-chan origamqp.Return) {
+	for {
+		select {
+		case confirm, ok := <-confirms:
+			if !ok {
+				p.close()
+				return
+			}
+			p.confirm(confirm)
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			p.markReturned(ret)
+		}
+	}
+}
+
+// confirm hands the confirm over to the request of its message.
+func (p *Publisher) confirm(confirm wabbit.Confirmation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	m, ok := p.pending[confirm.DeliveryTag()]
+	if !ok {
+		return
+	}
+	switch {
+	case !confirm.Ack():
+		m.confirmed <- errNotConfirmed
+	case m.returned:
+		m.confirmed <- errReturned
+	default:
+		m.confirmed <- nil
+	}
+	delete(p.pending, confirm.DeliveryTag())
+}
+
+// markReturned marks the message returned by RabbitMQ. Returns don't carry
+// the delivery tag of their message, which is the first one pending with the
+// same id and routing key since RabbitMQ returns messages in order.
+func (p *Publisher) markReturned(ret origamqp.Return) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var first *pendingMessage
+	var firstTag uint64
+	for tag, m := range p.pending {
+		if m.returned || m.messageID != ret.MessageId || m.routingKey != ret.RoutingKey {
+			continue
+		}
+		if first == nil || tag < firstTag {
+			first, firstTag = m, tag
+		}
+	}
+	if first == nil {
+		p.logger.Warnw("unexpected returned message", zap.String("messageId", ret.MessageId), zap.String("routingKey", ret.RoutingKey))
+		return
+	}
+	first.returned = true
+}
+
+// close fails the requests still waiting for their confirm once the channel
+// is closed.
+func (p *Publisher) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	for tag, m := range p.pending {
+		close(m.confirmed)
+		delete(p.pending, tag)
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sink
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/wabbit"
+	"github.com/NeowayLabs/wabbit/amqptest"
+	"github.com/NeowayLabs/wabbit/amqptest/server"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	origamqp "github.com/streadway/amqp"
+	"go.uber.org/zap"
+	"knative.dev/eventing-rabbitmq/pkg/apis/eventing/v1alpha1"
+)
+
+func TestPublisher(t *testing.T) {
+	url := "amqp://localhost:5672/sink"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	consumerCh, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer consumerCh.Close()
+
+	queue := "orders.com.example.order.eu"
+	if _, err := consumerCh.QueueDeclare(queue, wabbit.Option{}); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := consumerCh.Consume(queue, "", wabbit.Option{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(method string) *http.Request {
+		req := httptest.NewRequest(method, "/", strings.NewReader("order"))
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", "1234")
+		req.Header.Set("ce-source", "/shop")
+		req.Header.Set("ce-type", "com.example.order")
+		req.Header.Set("ce-region", "eu")
+		req.Header.Set("Content-Type", "text/plain")
+		return req
+	}
+
+	tests := []struct {
+		name        string
+		routingKey  string
+		contentMode string
+		method      string
+		wantCode    int
+		check       func(*testing.T, wabbit.Delivery)
+	}{{
+		name:        "binary",
+		routingKey:  "orders.{{ .type }}.{{ .region }}",
+		contentMode: v1alpha1.ContentModeBinary,
+		method:      http.MethodPost,
+		wantCode:    http.StatusAccepted,
+		check: func(t *testing.T, msg wabbit.Delivery) {
+			if string(msg.Body()) != "order" {
+				t.Errorf("Expected body %q, but got %q", "order", msg.Body())
+			}
+			if msg.MessageId() != "1234" {
+				t.Errorf("Expected message id %q, but got %q", "1234", msg.MessageId())
+			}
+			headers := msg.Headers()
+			for k, v := range map[string]string{
+				"cloudEvents_type":   "com.example.order",
+				"cloudEvents_source": "/shop",
+				"cloudEvents_region": "eu",
+			} {
+				if headers[k] != v {
+					t.Errorf("Expected header %s %q, but got %v", k, v, headers[k])
+				}
+			}
+		},
+	}, {
+		name:        "structured",
+		routingKey:  "orders.{{ .type }}.{{ .region }}",
+		contentMode: v1alpha1.ContentModeStructured,
+		method:      http.MethodPost,
+		wantCode:    http.StatusAccepted,
+		check: func(t *testing.T, msg wabbit.Delivery) {
+			event := cloudevents.NewEvent()
+			if err := json.Unmarshal(msg.Body(), &event); err != nil {
+				t.Fatal(err)
+			}
+			if event.ID() != "1234" || event.Type() != "com.example.order" || event.Extensions()["region"] != "eu" {
+				t.Errorf("Unexpected event %v", event)
+			}
+		},
+	}, {
+		name:        "unroutable",
+		routingKey:  "orders.{{ .type }}",
+		contentMode: v1alpha1.ContentModeBinary,
+		method:      http.MethodPost,
+		wantCode:    http.StatusInternalServerError,
+	}, {
+		name:        "missing attribute",
+		routingKey:  "orders.{{ .subject }}",
+		contentMode: v1alpha1.ContentModeBinary,
+		method:      http.MethodPost,
+		wantCode:    http.StatusBadRequest,
+	}, {
+		name:        "not a POST",
+		routingKey:  "orders.{{ .type }}",
+		contentMode: v1alpha1.ContentModeBinary,
+		method:      http.MethodGet,
+		wantCode:    http.StatusMethodNotAllowed,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ch, err := conn.Channel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ch.Close()
+
+			p, err := NewPublisher(ch, "", tc.routingKey, tc.contentMode, zap.NewNop().Sugar())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := httptest.NewRecorder()
+			p.ServeHTTP(rec, newRequest(tc.method))
+			if rec.Code != tc.wantCode {
+				t.Fatalf("Expected status %d, but got %d", tc.wantCode, rec.Code)
+			}
+			if tc.check == nil {
+				return
+			}
+			select {
+			case msg := <-msgs:
+				tc.check(t, msg)
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the event")
+			}
+		})
+	}
+}
+
+// returningChannel returns the messages published with its unroutable key,
+// as RabbitMQ does for mandatory messages it can't route to any queue,
+// before publishing them to its queue to have them confirmed.
+type returningChannel struct {
+	wabbit.Channel
+	unroutable string
+	queue      string
+	returns    chan origamqp.Return
+}
+
+func (c *returningChannel) NotifyReturn(returns chan origamqp.Return) chan origamqp.Return {
+	c.returns = returns
+	return returns
+}
+
+func (c *returningChannel) Publish(exc, route string, msg []byte, opt wabbit.Option) error {
+	if route == c.unroutable {
+		messageID, _ := opt["messageId"].(string)
+		c.returns <- origamqp.Return{Exchange: exc, RoutingKey: route, MessageId: messageID, Body: msg}
+		route = c.queue
+	}
+	return c.Channel.Publish(exc, route, msg, opt)
+}
+
+func TestPublisher_Returned(t *testing.T) {
+	url := "amqp://localhost:5672/sink-returned"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ch.Close()
+
+	queue := "orders"
+	if _, err := ch.QueueDeclare(queue, wabbit.Option{}); err != nil {
+		t.Fatal(err)
+	}
+	returning := &returningChannel{Channel: ch, unroutable: "orders.com.example.order", queue: queue}
+	p, err := NewPublisher(returning, "", "{{ .subject }}", v1alpha1.ContentModeBinary, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		subject  string
+		wantCode int
+	}{{
+		subject:  "orders",
+		wantCode: http.StatusAccepted,
+	}, {
+		subject:  "orders.com.example.order",
+		wantCode: http.StatusInternalServerError,
+	}, {
+		subject:  "orders",
+		wantCode: http.StatusAccepted,
+	}} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("order"))
+		req.Header.Set("ce-specversion", "1.0")
+		req.Header.Set("ce-id", "1234")
+		req.Header.Set("ce-source", "/shop")
+		req.Header.Set("ce-type", "com.example.order")
+		req.Header.Set("ce-subject", tc.subject)
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, req)
+		if rec.Code != tc.wantCode {
+			t.Errorf("Expected status %d publishing to %q, but got %d", tc.wantCode, tc.subject, rec.Code)
+		}
+	}
+}
+
+func TestNewPublisher_InvalidRoutingKey(t *testing.T) {
+	if _, err := NewPublisher(nil, "", "{{ .type", v1alpha1.ContentModeBinary, zap.NewNop().Sugar()); err == nil {
+		t.Error("Expected an invalid routing key template to fail")
+	}
+}
+
+func TestPublisher_EventMessagePersistent(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	event.SetSource("/shop")
+	event.SetType("com.example.order")
+
+	for _, mode := range []string{v1alpha1.ContentModeBinary, v1alpha1.ContentModeStructured} {
+		p := &Publisher{contentMode: mode}
+		msg, err := p.eventMessage(&event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.DeliveryMode != origamqp.Persistent {
+			t.Errorf("Expected %s messages to be persistent, but got delivery mode %d", mode, msg.DeliveryMode)
+		}
+	}
+}
+
+func TestPublisher_Health(t *testing.T) {
+	url := "amqp://localhost:5672/sink-health"
+	fakeServer := server.NewServer(url)
+	if err := fakeServer.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer fakeServer.Stop()
+
+	conn, err := amqptest.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewPublisher(ch, "", "orders", v1alpha1.ContentModeBinary, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+	health := func() int {
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HealthPath, nil))
+		return rec.Code
+	}
+	if got := health(); got != http.StatusOK {
+		t.Errorf("Expected the publisher to be ready, but got status %d", got)
+	}
+
+	if err := ch.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for health() != http.StatusServiceUnavailable {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the publisher not to be ready once its channel is closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}